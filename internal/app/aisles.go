@@ -0,0 +1,148 @@
+package app
+
+import (
+	"sort"
+	"strings"
+)
+
+// Store aisles in the order a typical shop is walked through
+const (
+	aisleProduce   = "Produce"
+	aisleBakery    = "Bakery"
+	aisleMeat      = "Meat & Seafood"
+	aisleDairy     = "Dairy & Eggs"
+	aislePantry    = "Pantry"
+	aisleSpices    = "Spices & Seasonings"
+	aisleFrozen    = "Frozen"
+	aisleBeverages = "Beverages"
+	aisleOther     = "Other"
+)
+
+var aisleOrder = []string{
+	aisleProduce, aisleBakery, aisleMeat, aisleDairy, aislePantry, aisleSpices, aisleFrozen, aisleBeverages, aisleOther,
+}
+
+// aisleKeywords maps ingredient words to aisles. The longest matching keyword wins,
+// so "coconut milk" lands in the pantry rather than next to the milk.
+var aisleKeywords = map[string]string{
+	// Produce
+	"apple": aisleProduce, "avocado": aisleProduce, "banana": aisleProduce, "basil": aisleProduce,
+	"bell pepper": aisleProduce, "berry": aisleProduce, "broccoli": aisleProduce, "cabbage": aisleProduce,
+	"carrot": aisleProduce, "cauliflower": aisleProduce, "celery": aisleProduce, "chili": aisleProduce,
+	"cilantro": aisleProduce, "cucumber": aisleProduce, "eggplant": aisleProduce, "garlic": aisleProduce,
+	"ginger": aisleProduce, "kale": aisleProduce, "leek": aisleProduce, "lemon": aisleProduce,
+	"lettuce": aisleProduce, "lime": aisleProduce, "mango": aisleProduce, "mint": aisleProduce,
+	"mushroom": aisleProduce, "onion": aisleProduce, "orange": aisleProduce, "parsley": aisleProduce,
+	"pear": aisleProduce, "potato": aisleProduce, "scallion": aisleProduce, "shallot": aisleProduce,
+	"spinach": aisleProduce, "spring onion": aisleProduce, "strawberry": aisleProduce, "tomato": aisleProduce,
+	"zucchini": aisleProduce, "thyme": aisleProduce, "rosemary": aisleProduce, "pumpkin": aisleProduce,
+
+	// Bakery
+	"bagel": aisleBakery, "baguette": aisleBakery, "bread": aisleBakery, "bun": aisleBakery,
+	"croissant": aisleBakery, "pita": aisleBakery, "tortilla": aisleBakery,
+
+	// Meat & Seafood
+	"bacon": aisleMeat, "beef": aisleMeat, "chicken": aisleMeat, "cod": aisleMeat, "duck": aisleMeat,
+	"fish": aisleMeat, "ham": aisleMeat, "lamb": aisleMeat, "mince": aisleMeat, "pork": aisleMeat,
+	"prawn": aisleMeat, "salmon": aisleMeat, "sausage": aisleMeat, "shrimp": aisleMeat,
+	"steak": aisleMeat, "tuna": aisleMeat, "turkey": aisleMeat,
+
+	// Dairy & Eggs
+	"butter": aisleDairy, "buttermilk": aisleDairy, "cheese": aisleDairy, "cream": aisleDairy,
+	"creme fraiche": aisleDairy, "egg": aisleDairy, "milk": aisleDairy, "mozzarella": aisleDairy,
+	"parmesan": aisleDairy, "sour cream": aisleDairy, "yogurt": aisleDairy, "yoghurt": aisleDairy,
+
+	// Pantry
+	"bean": aislePantry, "breadcrumb": aislePantry, "broth": aislePantry, "chickpea": aislePantry,
+	"chocolate": aislePantry, "coconut milk": aislePantry, "cornstarch": aislePantry, "flour": aislePantry,
+	"honey": aislePantry, "lentil": aislePantry, "mustard": aislePantry, "noodle": aislePantry,
+	"oat": aislePantry, "oil": aislePantry, "pasta": aislePantry, "peanut butter": aislePantry,
+	"rice": aislePantry, "sauce": aislePantry, "soy sauce": aislePantry, "spaghetti": aislePantry,
+	"stock": aislePantry, "sugar": aislePantry, "syrup": aislePantry, "tomato paste": aislePantry,
+	"vinegar": aislePantry, "baking powder": aislePantry, "baking soda": aislePantry, "yeast": aislePantry,
+	"canned tomato": aislePantry, "nut": aislePantry, "almond": aislePantry, "walnut": aislePantry,
+
+	// Spices & Seasonings
+	"cinnamon": aisleSpices, "cumin": aisleSpices, "curry": aisleSpices, "nutmeg": aisleSpices,
+	"oregano": aisleSpices, "paprika": aisleSpices, "pepper": aisleSpices, "salt": aisleSpices,
+	"turmeric": aisleSpices, "vanilla": aisleSpices, "chili powder": aisleSpices, "bay leaf": aisleSpices,
+	"dried thyme": aisleSpices, "dried oregano": aisleSpices,
+
+	// Frozen
+	"frozen": aisleFrozen, "ice cream": aisleFrozen, "pea": aisleFrozen,
+
+	// Beverages
+	"beer": aisleBeverages, "coffee": aisleBeverages, "juice": aisleBeverages, "tea": aisleBeverages,
+	"water": aisleBeverages, "wine": aisleBeverages,
+}
+
+// classifyAisle picks the store aisle for an ingredient name
+func classifyAisle(name string) string {
+	words := strings.Fields(ingredientKey(name))
+	if len(words) == 0 {
+		return aisleOther
+	}
+
+	best, bestLength := aisleOther, 0
+	// Check every run of consecutive words, so multi-word keywords can match
+	for start := range words {
+		for end := start + 1; end <= len(words); end++ {
+			phrase := strings.Join(words[start:end], " ")
+			if aisle, ok := aisleKeywords[phrase]; ok && len(phrase) > bestLength {
+				best, bestLength = aisle, len(phrase)
+			}
+		}
+	}
+	return best
+}
+
+// ingredientKey normalizes an ingredient name so "Tomatoes" and "tomato" are treated as the same item
+func ingredientKey(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return r == ' ' || r == ',' || r == '(' || r == ')' || r == '.' || r == '-' || r == '/'
+	})
+	for i, word := range words {
+		words[i] = singularize(word)
+	}
+	return strings.Join(words, " ")
+}
+
+func singularize(word string) string {
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// aisleRank orders known aisles as in aisleOrder and custom aisles before "Other"
+func aisleRank(aisle string) int {
+	for i, known := range aisleOrder {
+		if known == aisle {
+			if aisle == aisleOther {
+				return len(aisleOrder) + 1
+			}
+			return i
+		}
+	}
+	return len(aisleOrder)
+}
+
+// sortAisleNames sorts aisle names in store order, custom aisles alphabetically
+func sortAisleNames(names []string) {
+	sort.SliceStable(names, func(i, j int) bool {
+		ri, rj := aisleRank(names[i]), aisleRank(names[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+}
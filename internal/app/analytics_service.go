@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// viewDedupWindow is how long repeated views by the same viewer count as one
+	viewDedupWindow = 30 * time.Minute
+	// analyticsRollupDays is how many recent days each rollup recomputes, so a missed run catches up
+	analyticsRollupDays = 7
+	// viewRetention is how long raw views are kept after being rolled up, for trending rankings
+	viewRetention        = 90 * 24 * time.Hour
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 366
+	analyticsDayLayout   = "2006-01-02"
+)
+
+type analyticsService struct {
+	recipeAnalyticsRepo interfaces.RecipeAnalyticsRepository
+	recipeRepo          interfaces.RecipeRepository
+}
+
+// NewAnalyticsService creates a new recipe analytics service
+func NewAnalyticsService(recipeAnalyticsRepo interfaces.RecipeAnalyticsRepository, recipeRepo interfaces.RecipeRepository) interfaces.AnalyticsService {
+	return &analyticsService{
+		recipeAnalyticsRepo: recipeAnalyticsRepo,
+		recipeRepo:          recipeRepo,
+	}
+}
+
+// RecordView records that a user viewed a recipe
+func (s *analyticsService) RecordView(ctx context.Context, recipe *domain.Recipe, viewerID uuid.UUID) {
+	if recipe == nil || recipe.UserID == viewerID {
+		return
+	}
+
+	now := time.Now()
+	view := domain.RecipeView{
+		RecipeID:    recipe.ID,
+		ViewerID:    viewerID,
+		WindowStart: now.Truncate(viewDedupWindow),
+		ViewedAt:    now,
+	}
+	if err := s.recipeAnalyticsRepo.RecordView(ctx, view); err != nil {
+		log.Printf("Failed to record view of recipe %s: %v", recipe.ID, err)
+	}
+}
+
+// GetAuthorAnalytics gets the daily activity on the author's recipes and a breakdown per recipe
+func (s *analyticsService) GetAuthorAnalytics(ctx context.Context, authorID uuid.UUID, input interfaces.AuthorAnalyticsInput) (*domain.AuthorAnalytics, error) {
+	from, to, err := analyticsPeriod(input.From, input.To)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.RecipeID != uuid.Nil {
+		recipe, err := s.recipeRepo.GetRecipe(ctx, input.RecipeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.NewNotFoundError("recipe not found")
+			}
+			return nil, err
+		}
+		if recipe.UserID != authorID {
+			return nil, interfaces.NewUnauthorizedError("unauthorized to view analytics of this recipe")
+		}
+	}
+
+	// The stats cover [from, to), so the last day is included by ending at the following midnight
+	end := to.AddDate(0, 0, 1)
+	stats, err := s.recipeAnalyticsRepo.GetAuthorDailyStats(ctx, authorID, input.RecipeID, from, end)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &domain.AuthorAnalytics{
+		From:    from.Format(analyticsDayLayout),
+		To:      to.Format(analyticsDayLayout),
+		Series:  []domain.AnalyticsDay{},
+		Recipes: []domain.RecipeAnalytics{},
+	}
+
+	days := make(map[string]*domain.RecipeStats)
+	for day := from; day.Before(end); day = day.AddDate(0, 0, 1) {
+		analytics.Series = append(analytics.Series, domain.AnalyticsDay{Day: day.Format(analyticsDayLayout)})
+	}
+	for i := range analytics.Series {
+		days[analytics.Series[i].Day] = &analytics.Series[i].RecipeStats
+	}
+
+	recipes := make(map[uuid.UUID]*domain.RecipeStats)
+	var recipeIDs []uuid.UUID
+	for _, stat := range stats {
+		analytics.Totals.Add(stat.RecipeStats)
+		if day, ok := days[stat.Day.Format(analyticsDayLayout)]; ok {
+			day.Add(stat.RecipeStats)
+		}
+		if _, ok := recipes[stat.RecipeID]; !ok {
+			recipes[stat.RecipeID] = &domain.RecipeStats{}
+			recipeIDs = append(recipeIDs, stat.RecipeID)
+		}
+		recipes[stat.RecipeID].Add(stat.RecipeStats)
+	}
+
+	for _, recipeID := range recipeIDs {
+		recipe, err := s.recipeRepo.GetRecipe(ctx, recipeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		analytics.Recipes = append(analytics.Recipes, domain.RecipeAnalytics{
+			RecipeID: recipeID,
+			Title:    recipe.Title,
+			Totals:   *recipes[recipeID],
+		})
+	}
+	sort.SliceStable(analytics.Recipes, func(i, j int) bool {
+		return analytics.Recipes[i].Totals.Views > analytics.Recipes[j].Totals.Views
+	})
+
+	return analytics, nil
+}
+
+// analyticsPeriod parses the first and last day of an analytics period, defaulting to the last 30 days
+func analyticsPeriod(fromStr, toStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if toStr != "" {
+		parsed, err := time.ParseInLocation(analyticsDayLayout, toStr, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, interfaces.NewValidationError("to must be a date formatted as YYYY-MM-DD")
+		}
+		to = parsed
+	}
+
+	from := to.AddDate(0, 0, -(defaultAnalyticsDays - 1))
+	if fromStr != "" {
+		parsed, err := time.ParseInLocation(analyticsDayLayout, fromStr, time.Local)
+		if err != nil {
+			return time.Time{}, time.Time{}, interfaces.NewValidationError("from must be a date formatted as YYYY-MM-DD")
+		}
+		from = parsed
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, interfaces.NewValidationError("from cannot be after to")
+	}
+	if from.AddDate(0, 0, maxAnalyticsDays-1).Before(to) {
+		return time.Time{}, time.Time{}, interfaces.NewValidationError("the period cannot be longer than 366 days")
+	}
+	return from, to, nil
+}
+
+// RollupDailyStats recomputes the daily stats of the last few days and deletes views past their retention
+func (s *analyticsService) RollupDailyStats(ctx context.Context) error {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	from := today.AddDate(0, 0, -(analyticsRollupDays - 1))
+	to := today.AddDate(0, 0, 1)
+
+	if err := s.recipeAnalyticsRepo.RollupDailyStats(ctx, from, to); err != nil {
+		return err
+	}
+	return s.recipeAnalyticsRepo.DeleteViewsBefore(ctx, now.Add(-viewRetention))
+}
@@ -12,6 +12,7 @@ import (
 
 	"cookaholic/internal/infrastructure/cloudinary"
 	"cookaholic/internal/infrastructure/db"
+	"cookaholic/internal/infrastructure/fetcher"
 	"cookaholic/internal/infrastructure/http"
 	"cookaholic/internal/interfaces"
 
@@ -33,8 +34,33 @@ type Application struct {
 	UserFollowerService      interfaces.UserFollowerService
 	CloudinaryService        interfaces.CloudinaryService
 	ImageService             *ImageService
+	RecipeImportService      interfaces.RecipeImportService
+	RecipeExportService      interfaces.RecipeExportService
+	CooklangService          interfaces.CooklangService
+	ShoppingListService      interfaces.ShoppingListService
+	MealPlanService          interfaces.MealPlanService
+	CalendarFeedService      interfaces.CalendarFeedService
+	PantryService            interfaces.PantryService
+	CookLogService           interfaces.CookLogService
+	SimilarRecipeService     interfaces.SimilarRecipeService
+	FeedService              interfaces.FeedService
+	RankingService           interfaces.RankingService
+	AnalyticsService         interfaces.AnalyticsService
+	DuplicateService         interfaces.DuplicateService
+	BulkImportService        interfaces.BulkImportService
+	CostService              interfaces.CostService
+	RecipeNoteService        interfaces.RecipeNoteService
+	IngredientService        interfaces.IngredientService
+	IngredientParserService  interfaces.IngredientParserService
+	TrashService             interfaces.TrashService
 	Server                   *http.Server
 	stopRatingCron           chan bool
+	stopPantryCron           chan bool
+	stopSimilarityCron       chan bool
+	stopRankingCron          chan bool
+	stopAnalyticsCron        chan bool
+	stopCostCron             chan bool
+	stopTrashCron            chan bool
 }
 
 // GetUserService returns the user service
@@ -74,6 +100,82 @@ func (app *Application) GetImageService() interfaces.ImageService {
 	return app.ImageService
 }
 
+func (app *Application) GetRecipeImportService() interfaces.RecipeImportService {
+	return app.RecipeImportService
+}
+
+func (app *Application) GetRecipeExportService() interfaces.RecipeExportService {
+	return app.RecipeExportService
+}
+
+func (app *Application) GetCooklangService() interfaces.CooklangService {
+	return app.CooklangService
+}
+
+func (app *Application) GetShoppingListService() interfaces.ShoppingListService {
+	return app.ShoppingListService
+}
+
+func (app *Application) GetMealPlanService() interfaces.MealPlanService {
+	return app.MealPlanService
+}
+
+func (app *Application) GetCalendarFeedService() interfaces.CalendarFeedService {
+	return app.CalendarFeedService
+}
+
+func (app *Application) GetPantryService() interfaces.PantryService {
+	return app.PantryService
+}
+
+func (app *Application) GetCookLogService() interfaces.CookLogService {
+	return app.CookLogService
+}
+
+func (app *Application) GetSimilarRecipeService() interfaces.SimilarRecipeService {
+	return app.SimilarRecipeService
+}
+
+func (app *Application) GetFeedService() interfaces.FeedService {
+	return app.FeedService
+}
+
+func (app *Application) GetRankingService() interfaces.RankingService {
+	return app.RankingService
+}
+
+func (app *Application) GetAnalyticsService() interfaces.AnalyticsService {
+	return app.AnalyticsService
+}
+
+func (app *Application) GetDuplicateService() interfaces.DuplicateService {
+	return app.DuplicateService
+}
+
+func (app *Application) GetBulkImportService() interfaces.BulkImportService {
+	return app.BulkImportService
+}
+
+func (app *Application) GetCostService() interfaces.CostService {
+	return app.CostService
+}
+
+func (app *Application) GetRecipeNoteService() interfaces.RecipeNoteService {
+	return app.RecipeNoteService
+}
+
+func (app *Application) GetIngredientService() interfaces.IngredientService {
+	return app.IngredientService
+}
+
+func (app *Application) GetIngredientParserService() interfaces.IngredientParserService {
+	return app.IngredientParserService
+}
+
+func (app *Application) GetTrashService() interfaces.TrashService {
+	return app.TrashService
+}
+
 // NewApplication creates a new Application instance
 func NewApplication() (*Application, error) {
 	// Initialize database
@@ -92,9 +194,12 @@ func NewApplication() (*Application, error) {
 	}
 
 	// Auto migrate schemas
-	if err := database.AutoMigrate(&db.UserEntity{}, &db.CategoryEntity{}, &db.RecipeEntity{}, &db.CollectionEntity{}, &db.RecipeCollectionEntity{}, &db.RecipeRatingEntity{}, &db.UserFollowerEntity{}); err != nil {
+	if err := database.AutoMigrate(&db.UserEntity{}, &db.CategoryEntity{}, &db.RecipeEntity{}, &db.CollectionEntity{}, &db.RecipeCollectionEntity{}, &db.RecipeRatingEntity{}, &db.UserFollowerEntity{}, &db.ShoppingListEntity{}, &db.ShoppingListItemEntity{}, &db.MealPlanEntryEntity{}, &db.CalendarFeedEntity{}, &db.PantryItemEntity{}, &db.CookLogEntity{}, &db.RecipeSimilarityEntity{}, &db.FeedItemEntity{}, &db.RecipeScoreEntity{}, &db.RecipeViewEntity{}, &db.RecipeDailyStatsEntity{}, &db.RecipeFingerprintEntity{}, &db.RecipeFingerprintBandEntity{}, &db.RecipeDuplicateEntity{}, &db.ImportJobEntity{}, &db.IngredientPriceEntity{}, &db.RecipeCostEntity{}, &db.RecipeNoteEntity{}, &db.CanonicalIngredientEntity{}, &db.IngredientTermEntity{}); err != nil {
 		return nil, fmt.Errorf("failed to migrate database schema: %w", err)
 	}
+	if err := db.BackfillTrashedAt(database); err != nil {
+		return nil, fmt.Errorf("failed to backfill trash timestamps: %w", err)
+	}
 
 	// Initialize repositories
 	userRepo := db.NewUserRepository(database)
@@ -104,6 +209,21 @@ func NewApplication() (*Application, error) {
 	recipeCollectionRepo := db.NewRecipeCollectionRepository(database)
 	recipeRatingRepo := db.NewRecipeRatingRepository(database)
 	userFollowerRepo := db.NewUserFollowerRepository(database)
+	shoppingListRepo := db.NewShoppingListRepository(database)
+	mealPlanRepo := db.NewMealPlanRepository(database)
+	calendarFeedRepo := db.NewCalendarFeedRepository(database)
+	pantryRepo := db.NewPantryRepository(database)
+	cookLogRepo := db.NewCookLogRepository(database)
+	recipeSimilarityRepo := db.NewRecipeSimilarityRepository(database)
+	feedRepo := db.NewFeedRepository(database)
+	recipeScoreRepo := db.NewRecipeScoreRepository(database)
+	recipeAnalyticsRepo := db.NewRecipeAnalyticsRepository(database)
+	recipeDuplicateRepo := db.NewRecipeDuplicateRepository(database)
+	importJobRepo := db.NewImportJobRepository(database)
+	ingredientPriceRepo := db.NewIngredientPriceRepository(database)
+	recipeNoteRepo := db.NewRecipeNoteRepository(database)
+	ingredientRepo := db.NewIngredientRepository(database)
+	trashRepo := db.NewTrashRepository(database)
 
 	// Initialize Cloudinary service
 	cloudinaryService, err := cloudinary.NewCloudinaryService()
@@ -117,16 +237,37 @@ func NewApplication() (*Application, error) {
 	userService := NewUserService(userRepo, eventBus)
 	emailVerificationHandler := NewEmailVerificationHandler(userRepo, emailService)
 
-	recipeService := NewRecipeService(recipeRepo)
+	duplicateService := NewDuplicateService(recipeDuplicateRepo, recipeRepo)
+	ingredientService := NewIngredientService(ingredientRepo)
+	ingredientParserService := NewIngredientParserService(ingredientService)
+	recipeService := NewRecipeService(recipeRepo, categoryRepo, eventBus, duplicateService, ingredientService)
 	categoryService := NewCategoryService(categoryRepo)
 	collectionService := NewCollectionService(collectionRepo)
 	recipeCollectionService := NewRecipeCollectionService(recipeCollectionRepo, recipeRepo, collectionRepo)
-	recipeRatingService := NewRecipeRatingService(recipeRatingRepo, recipeRepo)
+	recipeRatingService := NewRecipeRatingService(recipeRatingRepo, recipeRepo, cookLogRepo)
 	userFollowerService := NewUserFollowerService(userFollowerRepo, userRepo)
 	imageService := NewImageService(cloudinaryService)
+	recipeImportService := NewRecipeImportService(fetcher.NewHTTPPageFetcher())
+	recipeExportService := NewRecipeExportService(recipeRepo, recipeService, userRepo, categoryRepo)
+	cooklangService := NewCooklangService(recipeService)
+	shoppingListService := NewShoppingListService(shoppingListRepo, recipeService, collectionRepo, recipeCollectionRepo)
+	mealPlanService := NewMealPlanService(mealPlanRepo, recipeService, shoppingListService)
+	calendarFeedService := NewCalendarFeedService(calendarFeedRepo, mealPlanRepo, recipeService)
+	pantryService := NewPantryService(pantryRepo, recipeRepo, recipeService, userRepo, emailService)
+	cookLogService := NewCookLogService(cookLogRepo, recipeRepo, recipeRatingRepo, pantryService)
+	similarRecipeService := NewSimilarRecipeService(recipeSimilarityRepo, recipeRepo, recipeCollectionRepo)
+	feedService := NewFeedService(feedRepo)
+	feedFanoutHandler := NewFeedFanoutHandler(feedRepo)
+	rankingService := NewRankingService(recipeScoreRepo, recipeRepo)
+	analyticsService := NewAnalyticsService(recipeAnalyticsRepo, recipeRepo)
+	bulkImportService := NewBulkImportService(importJobRepo, recipeRepo, recipeService, categoryRepo, imageService, duplicateService, ingredientService)
+	costService := NewCostService(ingredientPriceRepo, recipeRepo, recipeService)
+	recipeNoteService := NewRecipeNoteService(recipeNoteRepo, recipeRepo)
+	trashService := NewTrashService(trashRepo)
 
 	// Subscribe to events
 	eventBus.Subscribe("user.created", emailVerificationHandler)
+	eventBus.Subscribe("recipe.created", feedFanoutHandler)
 
 	// Initialize application
 	app := &Application{
@@ -143,7 +284,37 @@ func NewApplication() (*Application, error) {
 		UserFollowerService:      userFollowerService,
 		CloudinaryService:        cloudinaryService,
 		ImageService:             imageService,
+		RecipeImportService:      recipeImportService,
+		RecipeExportService:      recipeExportService,
+		CooklangService:          cooklangService,
+		ShoppingListService:      shoppingListService,
+		MealPlanService:          mealPlanService,
+		CalendarFeedService:      calendarFeedService,
+		PantryService:            pantryService,
+		CookLogService:           cookLogService,
+		SimilarRecipeService:     similarRecipeService,
+		FeedService:              feedService,
+		RankingService:           rankingService,
+		AnalyticsService:         analyticsService,
+		DuplicateService:         duplicateService,
+		BulkImportService:        bulkImportService,
+		CostService:              costService,
+		RecipeNoteService:        recipeNoteService,
+		IngredientService:        ingredientService,
+		IngredientParserService:  ingredientParserService,
+		TrashService:             trashService,
 		stopRatingCron:           make(chan bool),
+		stopPantryCron:           make(chan bool),
+		stopSimilarityCron:       make(chan bool),
+		stopRankingCron:          make(chan bool),
+		stopAnalyticsCron:        make(chan bool),
+		stopCostCron:             make(chan bool),
+		stopTrashCron:            make(chan bool),
+	}
+
+	// Import jobs run in the background and do not survive a restart
+	if err := bulkImportService.FailInterruptedJobs(context.Background()); err != nil {
+		log.Printf("Error failing interrupted import jobs: %v", err)
 	}
 
 	// Initialize HTTP server
@@ -152,6 +323,27 @@ func NewApplication() (*Application, error) {
 	// Start the rating update cron job
 	go app.startRatingUpdateCron()
 
+	// Start the pantry expiry reminder cron job
+	go app.startPantryExpiryCron()
+
+	// Start the similar recipe precomputation cron job
+	go app.startSimilarityCron()
+
+	// Start the trending and top rated ranking cron job
+	go app.startRankingCron()
+
+	// Start the recipe analytics rollup cron job
+	go app.startAnalyticsCron()
+
+	// Start the recipe cost recomputation cron job
+	go app.startCostCron()
+
+	// Start the trash purge cron job
+	go app.startTrashPurgeCron()
+
+	// Fingerprint recipes saved before duplicate detection or whose check failed
+	go app.fingerprintMissingRecipes()
+
 	return app, nil
 }
 
@@ -171,6 +363,18 @@ func (app *Application) Stop() {
 	log.Println("Stopping application...")
 	// Stop the rating update cron job
 	app.stopRatingCron <- true
+	// Stop the pantry expiry reminder cron job
+	app.stopPantryCron <- true
+	// Stop the similar recipe precomputation cron job
+	app.stopSimilarityCron <- true
+	// Stop the trending and top rated ranking cron job
+	app.stopRankingCron <- true
+	// Stop the recipe analytics rollup cron job
+	app.stopAnalyticsCron <- true
+	// Stop the recipe cost recomputation cron job
+	app.stopCostCron <- true
+	// Stop the trash purge cron job
+	app.stopTrashCron <- true
 }
 
 // startRatingUpdateCron starts a goroutine that periodically updates recipe ratings
@@ -223,3 +427,164 @@ func (app *Application) updateAllRecipeRatings() {
 		}
 	}
 }
+
+// startPantryExpiryCron starts a goroutine that warns users about pantry items expiring soon.
+// Items are only reported once, so the job can run more often than daily.
+func (app *Application) startPantryExpiryCron() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	log.Println("Starting pantry expiry reminder cron job...")
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := app.PantryService.NotifyExpiringItems(context.Background()); err != nil {
+				log.Printf("Error sending pantry expiry reminders: %v", err)
+			}
+		case <-app.stopPantryCron:
+			log.Println("Stopping pantry expiry reminder cron job...")
+			return
+		}
+	}
+}
+
+// startSimilarityCron starts a goroutine that periodically precomputes similar recipes.
+// It runs once at startup so recommendations are available without waiting for the first tick.
+func (app *Application) startSimilarityCron() {
+	ticker := time.NewTicker(12 * time.Hour)
+	defer ticker.Stop()
+
+	log.Println("Starting similar recipe cron job...")
+	app.recomputeSimilarRecipes()
+
+	for {
+		select {
+		case <-ticker.C:
+			app.recomputeSimilarRecipes()
+		case <-app.stopSimilarityCron:
+			log.Println("Stopping similar recipe cron job...")
+			return
+		}
+	}
+}
+
+func (app *Application) recomputeSimilarRecipes() {
+	log.Println("Running similar recipe job...")
+	if err := app.SimilarRecipeService.RecomputeSimilarities(context.Background()); err != nil {
+		log.Printf("Error computing similar recipes: %v", err)
+	}
+}
+
+// startRankingCron starts a goroutine that periodically recomputes the trending and top rated rankings.
+// It runs once at startup so the rankings are available without waiting for the first tick.
+func (app *Application) startRankingCron() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	log.Println("Starting recipe ranking cron job...")
+	app.recomputeRecipeRankings()
+
+	for {
+		select {
+		case <-ticker.C:
+			app.recomputeRecipeRankings()
+		case <-app.stopRankingCron:
+			log.Println("Stopping recipe ranking cron job...")
+			return
+		}
+	}
+}
+
+func (app *Application) recomputeRecipeRankings() {
+	log.Println("Running recipe ranking job...")
+	if err := app.RankingService.RecomputeScores(context.Background()); err != nil {
+		log.Printf("Error computing recipe rankings: %v", err)
+	}
+}
+
+// startAnalyticsCron starts a goroutine that periodically rolls recipe activity up into daily stats
+func (app *Application) startAnalyticsCron() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	log.Println("Starting recipe analytics rollup cron job...")
+
+	for {
+		select {
+		case <-ticker.C:
+			log.Println("Running recipe analytics rollup job...")
+			if err := app.AnalyticsService.RollupDailyStats(context.Background()); err != nil {
+				log.Printf("Error rolling up recipe analytics: %v", err)
+			}
+		case <-app.stopAnalyticsCron:
+			log.Println("Stopping recipe analytics rollup cron job...")
+			return
+		}
+	}
+}
+
+// startCostCron starts a goroutine that periodically recomputes recipe costs from the shared prices.
+// It runs once at startup so recipes can be filtered by cost without waiting for the first tick.
+func (app *Application) startCostCron() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	log.Println("Starting recipe cost cron job...")
+	app.recomputeRecipeCosts()
+
+	for {
+		select {
+		case <-ticker.C:
+			app.recomputeRecipeCosts()
+		case <-app.stopCostCron:
+			log.Println("Stopping recipe cost cron job...")
+			return
+		}
+	}
+}
+
+func (app *Application) recomputeRecipeCosts() {
+	log.Println("Running recipe cost job...")
+	if err := app.CostService.RecomputeCosts(context.Background()); err != nil {
+		log.Printf("Error computing recipe costs: %v", err)
+	}
+}
+
+// startTrashPurgeCron starts a goroutine that periodically deletes trashed content for good once
+// its retention period has passed. It runs once at startup so a restarted server does not skip purges.
+func (app *Application) startTrashPurgeCron() {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	log.Println("Starting trash purge cron job...")
+	app.purgeTrash()
+
+	for {
+		select {
+		case <-ticker.C:
+			app.purgeTrash()
+		case <-app.stopTrashCron:
+			log.Println("Stopping trash purge cron job...")
+			return
+		}
+	}
+}
+
+func (app *Application) purgeTrash() {
+	log.Println("Running trash purge job...")
+	result, err := app.TrashService.PurgeExpired(context.Background())
+	if err != nil {
+		log.Printf("Error purging trash: %v", err)
+		return
+	}
+	log.Printf("Purged %d recipes, %d collections and %d categories from the trash", result.Recipes, result.Collections, result.Categories)
+}
+
+// fingerprintMissingRecipes runs duplicate detection on the recipes that have no fingerprint yet
+func (app *Application) fingerprintMissingRecipes() {
+	log.Println("Fingerprinting recipes for duplicate detection...")
+	if err := app.DuplicateService.FingerprintMissing(context.Background()); err != nil {
+		log.Printf("Error fingerprinting recipes: %v", err)
+	}
+}
@@ -0,0 +1,657 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBulkImportSize = 10 << 20
+	// maxBulkArchiveSize is the size limit of import archives, which may embed photos
+	maxBulkArchiveSize = 50 << 20
+	maxBulkImportRows  = 1000
+	// bulkImportBatchSize is how many recipes are created in one transaction
+	bulkImportBatchSize = 50
+	// bulkExportPageSize is how many recipes are read at a time when exporting
+	bulkExportPageSize = 100
+)
+
+// bulkCSVColumns are the columns of a recipe CSV file. Ingredients, steps and images hold one
+// entry per line and tags are separated by commas.
+var bulkCSVColumns = []string{"title", "description", "time", "category_id", "serving_size", "ingredients", "steps", "tags", "images", "locale"}
+
+// bulkImportRow is a recipe read from an import file, or the reason it could not be read
+type bulkImportRow struct {
+	recipe     interfaces.BulkRecipe
+	categories []string // category names, used when the recipe has no category_id
+	// photos reads the photos embedded in the file. They are read and uploaded one recipe at a
+	// time, once the recipe is stored.
+	photos func() [][]byte
+	err    error
+}
+
+// bulkImportParser reads the recipes of an import file. An error means the file as a whole
+// could not be read; problems with single rows are reported on the row.
+type bulkImportParser func(content []byte) ([]bulkImportRow, error)
+
+type bulkImportFormat struct {
+	parse   bulkImportParser
+	maxSize int
+}
+
+var bulkImportFormats = map[string]bulkImportFormat{
+	interfaces.BulkFormatCSV:     {parse: parseBulkCSV, maxSize: maxBulkImportSize},
+	interfaces.BulkFormatJSON:    {parse: parseBulkJSON, maxSize: maxBulkImportSize},
+	interfaces.BulkFormatPaprika: {parse: parsePaprikaArchive, maxSize: maxBulkArchiveSize},
+	interfaces.BulkFormatMealie:  {parse: parseMealieExport, maxSize: maxBulkImportSize},
+}
+
+// bulkImportCategories remembers the categories an import has looked up or created
+type bulkImportCategories struct {
+	byID   map[uuid.UUID]bool
+	byName map[string]uuid.UUID
+}
+
+type bulkImportService struct {
+	importJobRepo     interfaces.ImportJobRepository
+	recipeRepo        interfaces.RecipeRepository
+	recipeService     interfaces.RecipeService
+	categoryRepo      interfaces.CategoryRepository
+	imageService      interfaces.ImageService
+	duplicateService  interfaces.DuplicateService
+	ingredientService interfaces.IngredientService
+}
+
+// NewBulkImportService creates a new bulk recipe import and export service
+func NewBulkImportService(importJobRepo interfaces.ImportJobRepository, recipeRepo interfaces.RecipeRepository, recipeService interfaces.RecipeService, categoryRepo interfaces.CategoryRepository, imageService interfaces.ImageService, duplicateService interfaces.DuplicateService, ingredientService interfaces.IngredientService) interfaces.BulkImportService {
+	return &bulkImportService{
+		importJobRepo:     importJobRepo,
+		recipeRepo:        recipeRepo,
+		recipeService:     recipeService,
+		categoryRepo:      categoryRepo,
+		imageService:      imageService,
+		duplicateService:  duplicateService,
+		ingredientService: ingredientService,
+	}
+}
+
+// StartImport checks the uploaded file and imports its recipes in the background. The returned
+// job is pending; its progress is read with GetJob.
+func (s *bulkImportService) StartImport(ctx context.Context, input interfaces.BulkImportInput) (*domain.ImportJob, error) {
+	format := strings.ToLower(input.Format)
+	parser, ok := bulkImportFormats[format]
+	if !ok {
+		return nil, interfaces.NewValidationError(fmt.Sprintf("unsupported import format %q", input.Format))
+	}
+	if len(input.Content) == 0 {
+		return nil, interfaces.NewValidationError("import file is empty")
+	}
+	if len(input.Content) > parser.maxSize {
+		return nil, interfaces.NewValidationError("import file too large")
+	}
+
+	job := &domain.ImportJob{
+		UserID:    input.UserID,
+		Format:    format,
+		State:     domain.ImportJobPending,
+		Errors:    []domain.ImportRowError{},
+		RecipeIDs: []uuid.UUID{},
+	}
+	if err := s.importJobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// The import outlives the request, so it runs on its own context
+	input.Format = format
+	go s.runImport(context.Background(), job.ID, input)
+
+	return job, nil
+}
+
+// GetJob gets the progress of one of the user's import jobs
+func (s *bulkImportService) GetJob(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ImportJob, error) {
+	job, err := s.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("import job not found")
+		}
+		return nil, err
+	}
+	if job.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to access this import job")
+	}
+	return job, nil
+}
+
+// FailInterruptedJobs marks the jobs left unfinished by a restart as failed. Their recipes
+// created before the restart are kept.
+func (s *bulkImportService) FailInterruptedJobs(ctx context.Context) error {
+	return s.importJobRepo.FailUnfinished(ctx, "the import was interrupted by a server restart")
+}
+
+func (s *bulkImportService) runImport(ctx context.Context, id uuid.UUID, input interfaces.BulkImportInput) {
+	job, err := s.importJobRepo.GetByID(ctx, id)
+	if err != nil {
+		log.Printf("Failed to load import job %s: %v", id, err)
+		return
+	}
+
+	if err := s.importRows(ctx, job, input); err != nil {
+		job.State = domain.ImportJobFailed
+		job.Error = err.Error()
+	} else {
+		job.State = domain.ImportJobCompleted
+	}
+	finished := time.Now()
+	job.FinishedAt = &finished
+
+	sort.SliceStable(job.Errors, func(i, j int) bool {
+		return job.Errors[i].Row < job.Errors[j].Row
+	})
+	if err := s.importJobRepo.Update(ctx, job); err != nil {
+		log.Printf("Failed to save import job %s: %v", job.ID, err)
+	}
+}
+
+// importRows validates every row of the file and creates the valid ones in batches, saving the
+// job's progress after each batch
+func (s *bulkImportService) importRows(ctx context.Context, job *domain.ImportJob, input interfaces.BulkImportInput) error {
+	started := time.Now()
+	job.State = domain.ImportJobRunning
+	job.StartedAt = &started
+
+	rows, err := bulkImportFormats[input.Format].parse(input.Content)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return errors.New("the import file has no recipes")
+	}
+	if len(rows) > maxBulkImportRows {
+		return fmt.Errorf("the import file has more than %d recipes", maxBulkImportRows)
+	}
+	job.TotalRows = len(rows)
+
+	categories := &bulkImportCategories{
+		byID:   make(map[uuid.UUID]bool),
+		byName: make(map[string]uuid.UUID),
+	}
+	recipes := make([]*domain.Recipe, 0, len(rows))
+	recipeRows := make([]int, 0, len(rows))
+	for i, row := range rows {
+		err := row.err
+		if err == nil {
+			var recipe *domain.Recipe
+			recipe, err = s.validateRow(ctx, row, input, categories)
+			if err == nil {
+				linkRecipeIngredients(ctx, s.ingredientService, recipe)
+				recipes = append(recipes, recipe)
+				recipeRows = append(recipeRows, i+1)
+				continue
+			}
+		}
+		job.FailedRows++
+		job.Errors = append(job.Errors, domain.ImportRowError{Row: i + 1, Title: row.recipe.Title, Message: err.Error()})
+	}
+
+	if err := s.importJobRepo.Update(ctx, job); err != nil {
+		return err
+	}
+
+	for start := 0; start < len(recipes); start += bulkImportBatchSize {
+		end := start + bulkImportBatchSize
+		if end > len(recipes) {
+			end = len(recipes)
+		}
+		batch := recipes[start:end]
+
+		saved := make([]bool, len(batch))
+		if err := s.recipeRepo.CreateRecipes(ctx, batch); err == nil {
+			for i := range saved {
+				saved[i] = true
+			}
+		} else {
+			// Retry the recipes one by one, so only the rows that cannot be saved fail
+			log.Printf("Failed to create a batch of import job %s, retrying row by row: %v", job.ID, err)
+			for i, recipe := range batch {
+				if err := s.recipeRepo.CreateRecipes(ctx, []*domain.Recipe{recipe}); err != nil {
+					log.Printf("Failed to create row %d of import job %s: %v", recipeRows[start+i], job.ID, err)
+					continue
+				}
+				saved[i] = true
+			}
+		}
+
+		for i, recipe := range batch {
+			if !saved[i] {
+				job.FailedRows++
+				job.Errors = append(job.Errors, domain.ImportRowError{
+					Row:     recipeRows[start+i],
+					Title:   recipe.Title,
+					Message: "the recipe could not be saved",
+				})
+				continue
+			}
+			job.ImportedRows++
+			job.RecipeIDs = append(job.RecipeIDs, recipe.ID)
+			if photos := rows[recipeRows[start+i]-1].photos; photos != nil {
+				s.attachPhotos(ctx, recipe, photos())
+			}
+			// Imported recipes are not published to followers' feeds, since an import usually
+			// brings in old recipes all at once. They are still checked for duplicates.
+			if _, err := s.duplicateService.CheckRecipe(ctx, recipe); err != nil {
+				log.Printf("Failed to check recipe %s for duplicates: %v", recipe.ID, err)
+			}
+		}
+
+		if err := s.importJobRepo.Update(ctx, job); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateRow checks a row the way CreateRecipe checks a new recipe and maps it onto a recipe
+func (s *bulkImportService) validateRow(ctx context.Context, bulkRow bulkImportRow, input interfaces.BulkImportInput, categories *bulkImportCategories) (*domain.Recipe, error) {
+	row := bulkRow.recipe
+	title := strings.TrimSpace(row.Title)
+	if title == "" {
+		return nil, interfaces.NewValidationError("title is required")
+	}
+	if len(row.Steps) == 0 {
+		return nil, interfaces.NewValidationError("at least one step is required")
+	}
+	if row.Time < 0 {
+		return nil, interfaces.NewValidationError("time cannot be negative")
+	}
+	if row.ServingSize < 0 {
+		return nil, interfaces.NewValidationError("serving_size cannot be negative")
+	}
+
+	recipe := &domain.Recipe{
+		UserID:      input.UserID,
+		Title:       title,
+		Description: strings.TrimSpace(row.Description),
+		Time:        row.Time,
+		ServingSize: row.ServingSize,
+		Images:      row.Images,
+		Ingredients: row.Ingredients,
+		Steps:       row.Steps,
+		Sections:    row.Sections,
+		Nutrition:   row.Nutrition,
+		Tags:        normalizeTags(row.Tags),
+		Locale:      domain.DefaultLocale,
+	}
+	if recipe.ServingSize == 0 {
+		recipe.ServingSize = 1
+	}
+	if recipe.Ingredients == nil {
+		recipe.Ingredients = domain.Ingredients{}
+	}
+	// Steps without an order are numbered by their position
+	for i := range recipe.Steps {
+		if recipe.Steps[i].Order == 0 {
+			recipe.Steps[i].Order = i + 1
+		}
+	}
+
+	if row.Locale != "" {
+		locale, err := normalizeLocale(row.Locale)
+		if err != nil {
+			return nil, err
+		}
+		recipe.Locale = locale
+	}
+	if err := s.recipeService.ValidateRecipeContent(ctx, recipe); err != nil {
+		return nil, err
+	}
+
+	// The category is resolved and checked last, so invalid rows never create categories
+	categoryID, err := s.rowCategory(ctx, bulkRow, input, categories)
+	if err != nil {
+		return nil, err
+	}
+	recipe.CategoryID = categoryID
+
+	return recipe, nil
+}
+
+// rowCategory picks the category of a row: its category_id, else its first category name, which
+// is created when no category has that name yet, else the category chosen for the import
+func (s *bulkImportService) rowCategory(ctx context.Context, row bulkImportRow, input interfaces.BulkImportInput, categories *bulkImportCategories) (uuid.UUID, error) {
+	if row.recipe.CategoryID == uuid.Nil {
+		for _, name := range row.categories {
+			if name = strings.Join(strings.Fields(name), " "); name != "" {
+				return s.categoryByName(ctx, name, categories)
+			}
+		}
+	}
+
+	categoryID := row.recipe.CategoryID
+	if categoryID == uuid.Nil {
+		categoryID = input.CategoryID
+	}
+	if categoryID == uuid.Nil {
+		return uuid.Nil, interfaces.NewValidationError("category_id is required")
+	}
+
+	exists, checked := categories.byID[categoryID]
+	if !checked {
+		category, err := s.categoryRepo.Get(ctx, categoryID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, err
+		}
+		// Categories in the trash take no new recipes
+		exists = err == nil && category.Status == 1
+		categories.byID[categoryID] = exists
+	}
+	if !exists {
+		return uuid.Nil, interfaces.NewValidationError(fmt.Sprintf("category %s not found", categoryID))
+	}
+	return categoryID, nil
+}
+
+func (s *bulkImportService) categoryByName(ctx context.Context, name string, categories *bulkImportCategories) (uuid.UUID, error) {
+	key := strings.ToLower(name)
+	if id, ok := categories.byName[key]; ok {
+		return id, nil
+	}
+
+	category, err := s.categoryRepo.GetByName(ctx, name)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return uuid.Nil, err
+		}
+		now := time.Now()
+		category = &domain.Category{
+			BaseModel: &common.BaseModel{
+				ID:        uuid.New(),
+				CreatedAt: now,
+				UpdatedAt: now,
+				Status:    1,
+			},
+			Name: name,
+		}
+		if err := s.categoryRepo.Create(ctx, category); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	categories.byName[key] = category.ID
+	categories.byID[category.ID] = true
+	return category.ID, nil
+}
+
+// attachPhotos uploads the photos embedded in an import file and adds them to their stored recipe.
+// Uploading only after the recipe is saved means a recipe that fails to import leaves no uploads
+// behind. A photo that fails to upload is left out rather than failing its recipe.
+func (s *bulkImportService) attachPhotos(ctx context.Context, recipe *domain.Recipe, photos [][]byte) {
+	images := []common.Image{}
+	for _, photo := range photos {
+		image, err := s.imageService.UploadData(ctx, photo, recipe.UserID.String())
+		if err != nil {
+			log.Printf("Failed to upload imported recipe photo: %v", err)
+			continue
+		}
+		images = append(images, *image)
+	}
+	if len(images) == 0 {
+		return
+	}
+
+	recipe.Images = append(recipe.Images, images...)
+	if err := s.recipeRepo.UpdateRecipe(ctx, recipe); err != nil {
+		log.Printf("Failed to add imported photos to recipe %s: %v", recipe.ID, err)
+	}
+}
+
+// parseBulkJSON reads a JSON array of recipes. Each element is decoded on its own, so an element
+// with a wrongly typed field only fails its own row.
+func parseBulkJSON(content []byte) ([]bulkImportRow, error) {
+	var elements []json.RawMessage
+	if err := json.Unmarshal(content, &elements); err != nil {
+		return nil, errors.New("the import file must be a JSON array of recipes")
+	}
+
+	rows := make([]bulkImportRow, len(elements))
+	for i, element := range elements {
+		if err := json.Unmarshal(element, &rows[i].recipe); err != nil {
+			rows[i].err = interfaces.NewValidationError(fmt.Sprintf("invalid recipe: %s", err.Error()))
+		}
+	}
+	return rows, nil
+}
+
+// parseBulkCSV reads a CSV file with a header row naming the columns in bulkCSVColumns.
+// Columns can be in any order, unknown columns are ignored and only title is required.
+func parseBulkCSV(content []byte) ([]bulkImportRow, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.New("the import file has no CSV header row")
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("the CSV header has no title column")
+	}
+
+	rows := []bulkImportRow{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		recipe, err := csvRecordToRecipe(field)
+		rows = append(rows, bulkImportRow{recipe: recipe, err: err})
+	}
+	return rows, nil
+}
+
+func csvRecordToRecipe(field func(name string) string) (interfaces.BulkRecipe, error) {
+	recipe := interfaces.BulkRecipe{
+		Title:       field("title"),
+		Description: field("description"),
+		Locale:      field("locale"),
+	}
+
+	var err error
+	if recipe.Time, err = parseCSVInt(field("time"), "time"); err != nil {
+		return recipe, err
+	}
+	if recipe.ServingSize, err = parseCSVInt(field("serving_size"), "serving_size"); err != nil {
+		return recipe, err
+	}
+	if value := field("category_id"); value != "" {
+		if recipe.CategoryID, err = uuid.Parse(value); err != nil {
+			return recipe, interfaces.NewValidationError("category_id must be a UUID")
+		}
+	}
+
+	for _, line := range csvLines(field("ingredients")) {
+		recipe.Ingredients = append(recipe.Ingredients, parseIngredientLine(line))
+	}
+	for _, line := range csvLines(field("steps")) {
+		recipe.Steps = append(recipe.Steps, domain.Step{Order: len(recipe.Steps) + 1, Content: line})
+	}
+	for _, line := range csvLines(field("images")) {
+		recipe.Images = append(recipe.Images, common.Image{URL: line})
+	}
+	if value := field("tags"); value != "" {
+		recipe.Tags = strings.Split(value, ",")
+	}
+	return recipe, nil
+}
+
+func parseCSVInt(value, name string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, interfaces.NewValidationError(fmt.Sprintf("%s must be a whole number", name))
+	}
+	return n, nil
+}
+
+// csvLines splits a multi-line CSV field into its non-empty lines
+func csvLines(value string) []string {
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// ExportRecipes renders all of the user's recipes, oldest first. The CSV export keeps only what
+// fits the CSV columns; the JSON export also keeps step details, sections and nutrition.
+func (s *bulkImportService) ExportRecipes(ctx context.Context, userID uuid.UUID, format string) (*interfaces.RecipeExport, error) {
+	format = strings.ToLower(format)
+	if format != interfaces.BulkFormatCSV && format != interfaces.BulkFormatJSON {
+		return nil, interfaces.ErrUnsupportedFormat
+	}
+
+	recipes := []interfaces.BulkRecipe{}
+	for offset := 0; ; offset += bulkExportPageSize {
+		page, err := s.recipeRepo.ListRecipesByUser(ctx, userID, offset, bulkExportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, recipe := range page {
+			recipes = append(recipes, toBulkRecipe(recipe))
+		}
+		if len(page) < bulkExportPageSize {
+			break
+		}
+	}
+
+	if format == interfaces.BulkFormatJSON {
+		body, err := json.MarshalIndent(recipes, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.RecipeExport{
+			ContentType: "application/json; charset=utf-8",
+			Filename:    "recipes.json",
+			Body:        body,
+		}, nil
+	}
+
+	body, err := renderBulkCSV(recipes)
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.RecipeExport{
+		ContentType: "text/csv; charset=utf-8",
+		Filename:    "recipes.csv",
+		Body:        body,
+	}, nil
+}
+
+func toBulkRecipe(recipe domain.Recipe) interfaces.BulkRecipe {
+	bulk := interfaces.BulkRecipe{
+		Title:       recipe.Title,
+		Description: recipe.Description,
+		Time:        recipe.Time,
+		CategoryID:  recipe.CategoryID,
+		ServingSize: recipe.ServingSize,
+		Images:      recipe.Images,
+		Ingredients: recipe.Ingredients,
+		Steps:       recipe.Steps,
+		Sections:    recipe.Sections,
+		Nutrition:   recipe.Nutrition,
+		Tags:        recipe.Tags,
+		Locale:      recipe.Locale,
+	}
+	if bulk.Images == nil {
+		bulk.Images = []common.Image{}
+	}
+	if bulk.Ingredients == nil {
+		bulk.Ingredients = []domain.Ingredient{}
+	}
+	if bulk.Steps == nil {
+		bulk.Steps = []domain.Step{}
+	}
+	if bulk.Tags == nil {
+		bulk.Tags = []string{}
+	}
+	return bulk
+}
+
+func renderBulkCSV(recipes []interfaces.BulkRecipe) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(bulkCSVColumns); err != nil {
+		return nil, err
+	}
+
+	for _, recipe := range recipes {
+		ingredients := make([]string, len(recipe.Ingredients))
+		for i, ingredient := range recipe.Ingredients {
+			ingredients[i] = formatIngredientLine(ingredient)
+		}
+		// A step is one line of the steps column, so line breaks inside a step are flattened
+		steps := make([]string, len(recipe.Steps))
+		for i, step := range recipe.Steps {
+			steps[i] = strings.Join(strings.Fields(step.Content), " ")
+		}
+		images := make([]string, len(recipe.Images))
+		for i, image := range recipe.Images {
+			images[i] = image.URL
+		}
+
+		if err := writer.Write([]string{
+			recipe.Title,
+			recipe.Description,
+			strconv.Itoa(recipe.Time),
+			recipe.CategoryID.String(),
+			strconv.Itoa(recipe.ServingSize),
+			strings.Join(ingredients, "\n"),
+			strings.Join(steps, "\n"),
+			strings.Join(recipe.Tags, ","),
+			strings.Join(images, "\n"),
+			recipe.Locale,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Feed window: recent meals stay visible for a week, planned meals are listed eight weeks ahead
+const (
+	calendarFeedPastDays   = 7
+	calendarFeedFutureDays = 56
+
+	// defaultMealDuration is used for recipes without a cooking time
+	defaultMealDuration = 30 * time.Minute
+)
+
+// mealSlotTimes is when each meal is served; events start early enough to cook the recipe
+var mealSlotTimes = map[string]time.Duration{
+	domain.MealSlotBreakfast: 8 * time.Hour,
+	domain.MealSlotLunch:     12*time.Hour + 30*time.Minute,
+	domain.MealSlotSnack:     16 * time.Hour,
+	domain.MealSlotDinner:    19 * time.Hour,
+}
+
+type calendarFeedService struct {
+	calendarFeedRepo interfaces.CalendarFeedRepository
+	mealPlanRepo     interfaces.MealPlanRepository
+	recipeService    interfaces.RecipeService
+	baseURL          string
+}
+
+// NewCalendarFeedService creates a new meal plan calendar feed service
+func NewCalendarFeedService(
+	calendarFeedRepo interfaces.CalendarFeedRepository,
+	mealPlanRepo interfaces.MealPlanRepository,
+	recipeService interfaces.RecipeService) interfaces.CalendarFeedService {
+	baseURL := strings.TrimRight(os.Getenv("APP_BASE_URL"), "/")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+
+	return &calendarFeedService{
+		calendarFeedRepo: calendarFeedRepo,
+		mealPlanRepo:     mealPlanRepo,
+		recipeService:    recipeService,
+		baseURL:          baseURL,
+	}
+}
+
+// GetFeed returns the user's calendar feed, creating it on first use
+func (s *calendarFeedService) GetFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	feed, err := s.calendarFeedRepo.GetByUserID(ctx, userID)
+	if err == nil {
+		feed.URL = s.feedURL(feed.Token)
+		return feed, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	return s.RotateFeed(ctx, userID)
+}
+
+// RotateFeed gives the user's feed a new token; calendars subscribed to the old URL stop updating
+func (s *calendarFeedService) RotateFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &domain.CalendarFeed{UserID: userID, Token: token}
+	if err := s.calendarFeedRepo.Save(ctx, feed); err != nil {
+		return nil, err
+	}
+
+	feed.URL = s.feedURL(feed.Token)
+	return feed, nil
+}
+
+// RevokeFeed removes the user's calendar feed
+func (s *calendarFeedService) RevokeFeed(ctx context.Context, userID uuid.UUID) error {
+	return s.calendarFeedRepo.DeleteByUserID(ctx, userID)
+}
+
+// RenderFeed renders the planned meals of the feed's owner as an iCalendar document
+func (s *calendarFeedService) RenderFeed(ctx context.Context, token string) ([]byte, error) {
+	feed, err := s.calendarFeedRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("calendar feed not found")
+		}
+		return nil, err
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	entries, err := s.mealPlanRepo.GetByUserAndDateRange(ctx, feed.UserID,
+		today.AddDate(0, 0, -calendarFeedPastDays), today.AddDate(0, 0, calendarFeedFutureDays))
+	if err != nil {
+		return nil, err
+	}
+
+	recipes := make(map[uuid.UUID]*domain.Recipe)
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//Cookaholic//Meal Plan//EN")
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.line("X-WR-CALNAME", "Cookaholic meal plan")
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+
+	for _, entry := range entries {
+		recipe, ok := recipes[entry.RecipeID]
+		if !ok {
+			recipe, err = s.recipeService.GetRecipe(ctx, entry.RecipeID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			recipes[entry.RecipeID] = recipe
+		}
+		// Skip meals whose recipe has been deleted
+		if recipe == nil {
+			continue
+		}
+
+		event, err := s.mealEvent(entry, recipe)
+		if err != nil {
+			continue
+		}
+		w.event(event, now)
+	}
+
+	w.line("END", "VCALENDAR")
+	return w.bytes(), nil
+}
+
+// mealEvent turns a planned meal into an event that ends when the meal is served
+// and starts the recipe's cooking time earlier
+func (s *calendarFeedService) mealEvent(entry domain.MealPlanEntry, recipe *domain.Recipe) (icalEvent, error) {
+	date, err := time.ParseInLocation(mealPlanDateLayout, entry.Date, time.Local)
+	if err != nil {
+		return icalEvent{}, err
+	}
+
+	// Build the wall-clock time directly so days with a DST change keep the usual meal time
+	servingTime := mealSlotTimes[entry.Slot]
+	servedAt := time.Date(date.Year(), date.Month(), date.Day(),
+		int(servingTime/time.Hour), int(servingTime%time.Hour/time.Minute), 0, 0, time.Local)
+	duration := time.Duration(recipe.Time) * time.Minute
+	if duration <= 0 {
+		duration = defaultMealDuration
+	}
+
+	servings := entry.Servings
+	if servings <= 0 {
+		servings = recipe.ServingSize
+	}
+
+	recipeURL := fmt.Sprintf("%s/recipes/%s", s.baseURL, recipe.ID)
+	description := fmt.Sprintf("%s for %d. Cooking time: %d minutes.\n%s",
+		mealSlotLabel(entry.Slot), servings, recipe.Time, recipeURL)
+
+	return icalEvent{
+		UID:         entry.ID.String() + "@cookaholic",
+		Start:       servedAt.Add(-duration),
+		End:         servedAt,
+		Summary:     fmt.Sprintf("%s: %s", mealSlotLabel(entry.Slot), recipe.Title),
+		Description: description,
+		URL:         recipeURL,
+	}, nil
+}
+
+// mealSlotLabel capitalizes a meal slot for display, e.g. "dinner" becomes "Dinner"
+func mealSlotLabel(slot string) string {
+	if slot == "" {
+		return slot
+	}
+	return strings.ToUpper(slot[:1]) + slot[1:]
+}
+
+func (s *calendarFeedService) feedURL(token string) string {
+	return fmt.Sprintf("%s/api/calendar/%s.ics", s.baseURL, token)
+}
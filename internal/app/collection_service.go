@@ -5,6 +5,7 @@ import (
 	"cookaholic/internal/common"
 	"cookaholic/internal/domain"
 	"cookaholic/internal/interfaces"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -59,7 +60,7 @@ func (s *collectionService) GetCollectionByUserID(ctx context.Context, userID uu
 	return collections, nil
 }
 
-func (s *collectionService) UpdateCollection(ctx context.Context, id uuid.UUID, input interfaces.UpdateCollectionInput) (*domain.Collection, error) {
+func (s *collectionService) UpdateCollection(ctx context.Context, id uuid.UUID, version int64, input interfaces.UpdateCollectionInput) (*domain.Collection, error) {
 
 	collection, err := s.collectionRepo.GetByID(ctx, id)
 
@@ -67,6 +68,10 @@ func (s *collectionService) UpdateCollection(ctx context.Context, id uuid.UUID,
 		return nil, err
 	}
 
+	if version != interfaces.AnyVersion && collection.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
+
 	if input.Name != "" {
 		collection.Name = input.Name
 	}
@@ -88,6 +93,52 @@ func (s *collectionService) UpdateCollection(ctx context.Context, id uuid.UUID,
 	return collection, nil
 }
 
-func (s *collectionService) DeleteCollection(ctx context.Context, id uuid.UUID) error {
-	return s.collectionRepo.Delete(ctx, id)
+// collectionDocument is the part of a collection a merge patch can change
+type collectionDocument struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Image       common.Image `json:"image"`
+}
+
+func (s *collectionService) PatchCollection(ctx context.Context, id uuid.UUID, version int64, patch []byte) (*domain.Collection, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version != interfaces.AnyVersion && collection.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
+
+	document := collectionDocument{
+		Name:        collection.Name,
+		Description: collection.Description,
+		Image:       collection.Image,
+	}
+	var patched collectionDocument
+	if err := applyMergePatch(document, patch, &patched); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(patched.Name) == "" {
+		return nil, interfaces.NewValidationError("a collection needs a name")
+	}
+
+	collection.Name = patched.Name
+	collection.Description = patched.Description
+	collection.Image = patched.Image
+	if err := s.collectionRepo.Update(ctx, collection); err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (s *collectionService) DeleteCollection(ctx context.Context, id uuid.UUID, version int64) error {
+	if version == interfaces.AnyVersion {
+		collection, err := s.collectionRepo.GetByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		version = collection.Version
+	}
+	return s.collectionRepo.Delete(ctx, id, version)
 }
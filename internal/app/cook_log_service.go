@@ -0,0 +1,205 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxCookLogPhotos = 10
+	// cookLogFutureTolerance allows for clock and time zone differences when logging a cook
+	cookLogFutureTolerance = 24 * time.Hour
+)
+
+type cookLogService struct {
+	cookLogRepo      interfaces.CookLogRepository
+	recipeRepo       interfaces.RecipeRepository
+	recipeRatingRepo interfaces.RecipeRatingRepository
+	pantryService    interfaces.PantryService
+}
+
+// NewCookLogService creates a new cook log service
+func NewCookLogService(
+	cookLogRepo interfaces.CookLogRepository,
+	recipeRepo interfaces.RecipeRepository,
+	recipeRatingRepo interfaces.RecipeRatingRepository,
+	pantryService interfaces.PantryService) interfaces.CookLogService {
+	return &cookLogService{
+		cookLogRepo:      cookLogRepo,
+		recipeRepo:       recipeRepo,
+		recipeRatingRepo: recipeRatingRepo,
+		pantryService:    pantryService,
+	}
+}
+
+// LogCook logs that the user cooked a recipe and marks the user's rating of it as a verified cook
+func (s *cookLogService) LogCook(ctx context.Context, input interfaces.CreateCookLogInput) (*domain.CookLog, error) {
+	recipe, err := s.recipeRepo.GetRecipe(ctx, input.RecipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+
+	if input.Servings < 0 {
+		return nil, interfaces.NewValidationError("servings cannot be negative")
+	}
+	if len(input.Photos) > maxCookLogPhotos {
+		return nil, interfaces.NewValidationError("a cook log can have at most 10 photos")
+	}
+
+	now := time.Now()
+	cookedAt := now
+	if input.CookedAt != nil {
+		if input.CookedAt.After(now.Add(cookLogFutureTolerance)) {
+			return nil, interfaces.NewValidationError("cooked_at cannot be in the future")
+		}
+		cookedAt = *input.CookedAt
+	}
+
+	servings := input.Servings
+	if servings == 0 {
+		servings = recipe.ServingSize
+	}
+
+	photos := input.Photos
+	if photos == nil {
+		photos = []common.Image{}
+	}
+
+	cookLog := &domain.CookLog{
+		BaseModel: &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: now,
+			UpdatedAt: now,
+			Status:    1,
+		},
+		UserID:   input.UserID,
+		RecipeID: input.RecipeID,
+		CookedAt: cookedAt,
+		Notes:    input.Notes,
+		Photos:   photos,
+		Servings: servings,
+	}
+
+	if err := s.createCookLog(ctx, cookLog, input.DeductPantry); err != nil {
+		return nil, err
+	}
+	if err := s.recipeRatingRepo.SetVerifiedCook(ctx, input.UserID, input.RecipeID, true); err != nil {
+		return nil, err
+	}
+
+	recipe.CookCount++
+	cookLog.Recipe = recipe
+	return cookLog, nil
+}
+
+// createCookLog stores the cook log. When the pantry is deducted too, the deduction is planned first and
+// stored together with the log, retrying if another request changed the pantry meanwhile.
+func (s *cookLogService) createCookLog(ctx context.Context, cookLog *domain.CookLog, deductPantry bool) error {
+	if !deductPantry {
+		return s.cookLogRepo.Create(ctx, cookLog)
+	}
+
+	var err error
+	for attempt := 0; attempt < pantryCookAttempts; attempt++ {
+		cookLog.PantryDeduction, err = s.pantryService.PlanCook(ctx, cookLog.UserID, interfaces.CookFromPantryInput{
+			RecipeID: cookLog.RecipeID,
+			Servings: cookLog.Servings,
+		})
+		if err != nil {
+			return err
+		}
+		err = s.cookLogRepo.Create(ctx, cookLog)
+		if !errors.Is(err, interfaces.ErrPantryChanged) {
+			return err
+		}
+	}
+	return err
+}
+
+// GetCookLog gets a cook log owned by the user
+func (s *cookLogService) GetCookLog(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.CookLog, error) {
+	cookLog, err := s.ownedCookLog(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	recipe, err := s.recipeRepo.GetRecipe(ctx, cookLog.RecipeID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	cookLog.Recipe = recipe
+	return cookLog, nil
+}
+
+// GetCookHistory gets the user's cooking history with the recipes that were cooked
+func (s *cookLogService) GetCookHistory(ctx context.Context, userID uuid.UUID, recipeID uuid.UUID, cursor uuid.UUID, limit int) ([]domain.CookLog, uuid.UUID, error) {
+	logs, nextCursor, err := s.cookLogRepo.GetByUserID(ctx, userID, recipeID, cursor, limit)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, uuid.Nil, interfaces.NewNotFoundError("cursor not found")
+		}
+		return nil, uuid.Nil, err
+	}
+
+	// Deleted recipes are left out of the log entries rather than failing the whole history
+	recipes := make(map[uuid.UUID]*domain.Recipe)
+	for i := range logs {
+		recipe, ok := recipes[logs[i].RecipeID]
+		if !ok {
+			recipe, err = s.recipeRepo.GetRecipe(ctx, logs[i].RecipeID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, uuid.Nil, err
+			}
+			recipes[logs[i].RecipeID] = recipe
+		}
+		logs[i].Recipe = recipe
+	}
+
+	return logs, nextCursor, nil
+}
+
+// DeleteCookLog deletes a cook log owned by the user. The user's rating stops being a verified
+// cook once no cook of the recipe is left.
+func (s *cookLogService) DeleteCookLog(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	cookLog, err := s.ownedCookLog(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.cookLogRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	cooked, err := s.cookLogRepo.ExistsForUserAndRecipe(ctx, userID, cookLog.RecipeID)
+	if err != nil {
+		return err
+	}
+	if !cooked {
+		return s.recipeRatingRepo.SetVerifiedCook(ctx, userID, cookLog.RecipeID, false)
+	}
+	return nil
+}
+
+func (s *cookLogService) ownedCookLog(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.CookLog, error) {
+	cookLog, err := s.cookLogRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("cook log not found")
+		}
+		return nil, err
+	}
+	if cookLog.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to access this cook log")
+	}
+	return cookLog, nil
+}
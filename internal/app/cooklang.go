@@ -0,0 +1,316 @@
+package app
+
+import (
+	"cookaholic/internal/domain"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// cooklangRecipe is the result of parsing a Cooklang (.cook) document
+type cooklangRecipe struct {
+	Metadata    map[string]string
+	Ingredients []domain.Ingredient
+	Cookware    []string
+	Steps       []domain.Step
+	TimerTotal  int // sum of all step timers in minutes
+}
+
+var (
+	cooklangBlockCommentRegexp = regexp.MustCompile(`(?s)\[-.*?-\]`)
+	// @name{qty%unit}, #name{qty}, ~name{qty%unit}; without braces the name is a single word
+	cooklangTokenRegexp = regexp.MustCompile(`([@#~])(?:([^@#~{}\n]*?)\{([^}]*)\}|([\p{L}\p{N}_-]+))`)
+)
+
+// parseCooklang parses Cooklang text, turning ingredients, cookware and timers into plain step text.
+// Timers become the step duration and ingredients become the step's ingredient references.
+func parseCooklang(text string) *cooklangRecipe {
+	recipe := &cooklangRecipe{Metadata: make(map[string]string)}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = parseCooklangFrontMatter(text, recipe.Metadata)
+	text = cooklangBlockCommentRegexp.ReplaceAllString(text, "")
+
+	ingredientIndex := make(map[string]int)
+	cookwareSeen := make(map[string]bool)
+
+	var paragraph []string
+	var stepRefs []int
+	var stepSeconds int
+	flush := func() {
+		content := strings.TrimSpace(strings.Join(paragraph, " "))
+		refs, seconds := stepRefs, stepSeconds
+		paragraph, stepRefs, stepSeconds = paragraph[:0], nil, 0
+		if content == "" {
+			return
+		}
+		recipe.Steps = append(recipe.Steps, domain.Step{
+			Order:          len(recipe.Steps) + 1,
+			Content:        content,
+			Duration:       seconds,
+			IngredientRefs: refs,
+		})
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if idx := strings.Index(line, "--"); idx >= 0 {
+			line = line[:idx]
+		}
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ">>") {
+			if key, value, found := strings.Cut(strings.TrimPrefix(trimmed, ">>"), ":"); found {
+				recipe.Metadata[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+			}
+			continue
+		}
+
+		if trimmed == "" {
+			flush()
+			continue
+		}
+
+		// Section headers ("= Dough =") only group steps, they are not steps themselves
+		if strings.HasPrefix(trimmed, "=") {
+			flush()
+			continue
+		}
+
+		rendered := cooklangTokenRegexp.ReplaceAllStringFunc(trimmed, func(token string) string {
+			match := cooklangTokenRegexp.FindStringSubmatch(token)
+			name := strings.TrimSpace(match[2])
+			if match[4] != "" {
+				name = match[4]
+			}
+			quantity, unit := splitCooklangAmount(match[3])
+
+			switch match[1] {
+			case "@":
+				amount, _ := parseQuantity(quantity)
+				key := strings.ToLower(name) + "|" + unit
+				i, ok := ingredientIndex[key]
+				if ok {
+					recipe.Ingredients[i].Amount += amount
+				} else {
+					i = len(recipe.Ingredients)
+					ingredientIndex[key] = i
+					recipe.Ingredients = append(recipe.Ingredients, domain.Ingredient{Name: name, Amount: amount, Unit: unit})
+				}
+				if !containsInt(stepRefs, i) {
+					stepRefs = append(stepRefs, i)
+				}
+				return name
+			case "#":
+				if !cookwareSeen[strings.ToLower(name)] {
+					cookwareSeen[strings.ToLower(name)] = true
+					recipe.Cookware = append(recipe.Cookware, name)
+				}
+				return name
+			default:
+				if value, ok := parseQuantity(quantity); ok {
+					recipe.TimerTotal += timerMinutes(value, unit)
+					stepSeconds += timerSeconds(value, unit)
+				}
+				return strings.TrimSpace(quantity + " " + unit)
+			}
+		})
+		paragraph = append(paragraph, rendered)
+	}
+	flush()
+
+	return recipe
+}
+
+// parseCooklangFrontMatter reads a leading "---" block of "key: value" lines into metadata
+func parseCooklangFrontMatter(text string, metadata map[string]string) string {
+	if !strings.HasPrefix(text, "---\n") {
+		return text
+	}
+	end := strings.Index(text[4:], "\n---")
+	if end < 0 {
+		return text
+	}
+
+	for _, line := range strings.Split(text[4:4+end], "\n") {
+		if key, value, found := strings.Cut(line, ":"); found {
+			metadata[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+
+	rest := text[4+end+4:]
+	return strings.TrimPrefix(rest, "\n")
+}
+
+func splitCooklangAmount(amount string) (string, string) {
+	quantity, unit, _ := strings.Cut(amount, "%")
+	return strings.TrimSpace(quantity), strings.TrimSpace(unit)
+}
+
+// timerMinutes converts a timer value to minutes, treating unknown units as minutes
+func timerMinutes(value float64, unit string) int {
+	return (timerSeconds(value, unit) + 30) / 60
+}
+
+func timerSeconds(value float64, unit string) int {
+	switch strings.ToLower(unit) {
+	case "h", "hr", "hrs", "hour", "hours":
+		return int(value*3600 + 0.5)
+	case "s", "sec", "secs", "second", "seconds":
+		return int(value + 0.5)
+	default:
+		return int(value*60 + 0.5)
+	}
+}
+
+var cooklangTimeRegexp = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(h|hr|hrs|hours?|m|min|mins|minutes?)?`)
+
+// parseCooklangTime reads metadata durations such as "45 minutes" or "1h 30m"
+func parseCooklangTime(value string) int {
+	total := 0
+	for _, match := range cooklangTimeRegexp.FindAllStringSubmatch(value, -1) {
+		number, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			continue
+		}
+		total += timerMinutes(number, match[2])
+	}
+	return total
+}
+
+// cooklangCookware is the cookware vocabulary recognised when annotating steps on export
+var cooklangCookware = []string{
+	"baking dish", "baking sheet", "baking tray", "blender", "bowl", "colander", "dutch oven",
+	"food processor", "frying pan", "grater", "grill", "loaf tin", "mixer", "oven", "pan",
+	"pot", "rolling pin", "saucepan", "sieve", "skillet", "whisk", "wok",
+}
+
+var stepTimerRegexp = regexp.MustCompile(`(?i)\b(\d+(?:\.\d+)?)\s*(seconds?|secs?|minutes?|mins?|hours?|hrs?)\b`)
+
+// serializeCooklang renders a recipe as Cooklang, marking up ingredients, cookware and timers
+// found in the step text. Ingredients are placed in the steps that reference them when possible,
+// and ingredients that no step mentions are listed in a leading step.
+func serializeCooklang(recipe *domain.Recipe) string {
+	var buf strings.Builder
+
+	fmt.Fprintf(&buf, ">> title: %s\n", singleLine(recipe.Title))
+	if recipe.Description != "" {
+		fmt.Fprintf(&buf, ">> description: %s\n", singleLine(recipe.Description))
+	}
+	if recipe.ServingSize > 0 {
+		fmt.Fprintf(&buf, ">> servings: %d\n", recipe.ServingSize)
+	}
+	if recipe.Time > 0 {
+		fmt.Fprintf(&buf, ">> time: %d minutes\n", recipe.Time)
+	}
+	buf.WriteString("\n")
+
+	steps := make([]string, len(recipe.Steps))
+	for i, step := range recipe.Steps {
+		steps[i] = singleLine(step.Content)
+	}
+
+	// Longer names first so "brown sugar" is matched before "sugar"
+	order := make([]int, len(recipe.Ingredients))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(recipe.Ingredients[order[i]].Name) > len(recipe.Ingredients[order[j]].Name)
+	})
+
+	var unreferenced []int
+	for _, index := range order {
+		ingredient := recipe.Ingredients[index]
+		token := cooklangIngredientToken(ingredient)
+		pattern := regexp.MustCompile(`(?i)(^|[^@#~\p{L}])(` + regexp.QuoteMeta(ingredient.Name) + `)([^\p{L}{]|$)`)
+
+		candidates := make([]int, 0, len(steps))
+		for i, step := range recipe.Steps {
+			if containsInt(step.IngredientRefs, index) {
+				candidates = append(candidates, i)
+			}
+		}
+		for i := range steps {
+			if !containsInt(candidates, i) {
+				candidates = append(candidates, i)
+			}
+		}
+
+		placed := false
+		for _, i := range candidates {
+			if loc := pattern.FindStringSubmatchIndex(steps[i]); loc != nil {
+				steps[i] = steps[i][:loc[4]] + token + steps[i][loc[5]:]
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			unreferenced = append(unreferenced, index)
+		}
+	}
+
+	for i, step := range steps {
+		for _, cookware := range cooklangCookware {
+			pattern := regexp.MustCompile(`(?i)(^|[^@#~\p{L}])(` + regexp.QuoteMeta(cookware) + `)([^\p{L}{]|$)`)
+			if loc := pattern.FindStringSubmatchIndex(step); loc != nil {
+				step = step[:loc[4]] + "#" + step[loc[4]:loc[5]] + "{}" + step[loc[5]:]
+			}
+		}
+		if stepTimerRegexp.MatchString(step) {
+			step = stepTimerRegexp.ReplaceAllString(step, "~{$1%$2}")
+		} else if duration := recipe.Steps[i].Duration; duration > 0 {
+			if duration%60 == 0 {
+				step += fmt.Sprintf(" ~{%d%%minutes}", duration/60)
+			} else {
+				step += fmt.Sprintf(" ~{%d%%seconds}", duration)
+			}
+		}
+		steps[i] = step
+	}
+
+	if len(unreferenced) > 0 {
+		// Keep the original ingredient order for the preparation step
+		sort.Ints(unreferenced)
+		tokens := make([]string, len(unreferenced))
+		for i, index := range unreferenced {
+			tokens[i] = cooklangIngredientToken(recipe.Ingredients[index])
+		}
+		steps = append([]string{"Prepare " + strings.Join(tokens, ", ") + "."}, steps...)
+	}
+
+	buf.WriteString(strings.Join(steps, "\n\n"))
+	buf.WriteString("\n")
+
+	return buf.String()
+}
+
+func cooklangIngredientToken(ingredient domain.Ingredient) string {
+	name := ingredient.Name
+	if ingredient.Amount == 0 && ingredient.Unit == "" && !strings.ContainsAny(name, " ") {
+		return "@" + name
+	}
+
+	amount := ""
+	if ingredient.Amount > 0 {
+		amount = formatAmount(ingredient.Amount)
+	}
+	if ingredient.Unit != "" {
+		amount += "%" + ingredient.Unit
+	}
+	return "@" + name + "{" + amount + "}"
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	maxCooklangFileSize     = 1 << 20
+	maxCooklangArchiveFiles = 500
+)
+
+type cooklangService struct {
+	recipeService interfaces.RecipeService
+}
+
+// NewCooklangService creates a new Cooklang import service
+func NewCooklangService(recipeService interfaces.RecipeService) interfaces.CooklangService {
+	return &cooklangService{
+		recipeService: recipeService,
+	}
+}
+
+// ImportRecipe parses a single .cook file and creates the recipe in the caller's account
+func (s *cooklangService) ImportRecipe(ctx context.Context, input interfaces.CooklangImportInput) (*domain.Recipe, error) {
+	if len(input.Content) > maxCooklangFileSize {
+		return nil, errors.New("cooklang file too large")
+	}
+
+	createInput, err := cooklangToRecipeInput(input.Filename, input.Content)
+	if err != nil {
+		return nil, err
+	}
+	createInput.UserID = input.UserID
+	createInput.CategoryID = input.CategoryID
+
+	return s.recipeService.CreateRecipe(ctx, *createInput)
+}
+
+// ImportArchive creates a recipe for every .cook file in a ZIP archive.
+// A file that fails to import is reported and does not stop the remaining files.
+func (s *cooklangService) ImportArchive(ctx context.Context, input interfaces.CooklangArchiveInput) (*interfaces.CooklangArchiveResult, error) {
+	reader, err := zip.NewReader(bytes.NewReader(input.Archive), int64(len(input.Archive)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	result := &interfaces.CooklangArchiveResult{
+		Imported: []interfaces.CooklangImportedFile{},
+		Failed:   []interfaces.CooklangFailedFile{},
+	}
+
+	count := 0
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".cook") ||
+			strings.HasPrefix(file.Name, "__MACOSX/") || strings.HasPrefix(path.Base(file.Name), ".") {
+			continue
+		}
+
+		count++
+		if count > maxCooklangArchiveFiles {
+			result.Failed = append(result.Failed, interfaces.CooklangFailedFile{
+				Filename: file.Name,
+				Error:    fmt.Sprintf("archive contains more than %d recipes", maxCooklangArchiveFiles),
+			})
+			continue
+		}
+
+		content, err := readZipFile(file, maxCooklangFileSize)
+		if err != nil {
+			result.Failed = append(result.Failed, interfaces.CooklangFailedFile{Filename: file.Name, Error: err.Error()})
+			continue
+		}
+
+		recipe, err := s.ImportRecipe(ctx, interfaces.CooklangImportInput{
+			UserID:     input.UserID,
+			CategoryID: input.CategoryID,
+			Filename:   file.Name,
+			Content:    content,
+		})
+		if err != nil {
+			result.Failed = append(result.Failed, interfaces.CooklangFailedFile{Filename: file.Name, Error: err.Error()})
+			continue
+		}
+
+		result.Imported = append(result.Imported, interfaces.CooklangImportedFile{
+			Filename: file.Name,
+			RecipeID: recipe.ID,
+			Title:    recipe.Title,
+		})
+	}
+
+	return result, nil
+}
+
+// cooklangToRecipeInput maps a parsed .cook file onto the recipe creation input
+func cooklangToRecipeInput(filename string, content []byte) (*interfaces.CreateRecipeInput, error) {
+	parsed := parseCooklang(string(content))
+	if len(parsed.Steps) == 0 {
+		return nil, errors.New("cooklang file has no steps")
+	}
+
+	title := parsed.Metadata["title"]
+	if title == "" {
+		title = strings.TrimSuffix(path.Base(filename), path.Ext(filename))
+	}
+
+	servings := parseYield(firstNonEmpty(parsed.Metadata["servings"], parsed.Metadata["serves"], parsed.Metadata["yield"]))
+	if servings == 0 {
+		servings = 1
+	}
+
+	time := parseCooklangTime(firstNonEmpty(parsed.Metadata["time"], parsed.Metadata["duration"], parsed.Metadata["time required"]))
+	if time == 0 {
+		time = parsed.TimerTotal
+	}
+
+	description := parsed.Metadata["description"]
+	if source := firstNonEmpty(parsed.Metadata["source"], parsed.Metadata["source.url"]); source != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Source: " + source
+	}
+
+	ingredients := parsed.Ingredients
+	if ingredients == nil {
+		ingredients = []domain.Ingredient{}
+	}
+
+	return &interfaces.CreateRecipeInput{
+		Title:       title,
+		Description: description,
+		Time:        time,
+		ServingSize: servings,
+		Ingredients: ingredients,
+		Steps:       parsed.Steps,
+	}, nil
+}
+
+func readZipFile(file *zip.File, limit int64) ([]byte, error) {
+	if file.UncompressedSize64 > uint64(limit) {
+		return nil, errors.New("file too large")
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, errors.New("file too large")
+	}
+	return content, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,257 @@
+package app
+
+import (
+	"cookaholic/internal/domain"
+	"reflect"
+	"testing"
+)
+
+func TestParseCooklang(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		metadata    map[string]string
+		ingredients []domain.Ingredient
+		cookware    []string
+		steps       []domain.Step
+		timerTotal  int
+	}{
+		{
+			name:     "ingredients, cookware and timers",
+			text:     ">> servings: 2\n\nPut @flour{200%g} and @eggs{2} in a #bowl.\n\nBake in the #oven{} for ~{25%minutes}.\n",
+			metadata: map[string]string{"servings": "2"},
+			ingredients: []domain.Ingredient{
+				{Name: "flour", Amount: 200, Unit: "g"},
+				{Name: "eggs", Amount: 2},
+			},
+			cookware: []string{"bowl", "oven"},
+			steps: []domain.Step{
+				{Order: 1, Content: "Put flour and eggs in a bowl.", IngredientRefs: []int{0, 1}},
+				{Order: 2, Content: "Bake in the oven for 25 minutes.", Duration: 1500},
+			},
+			timerTotal: 25,
+		},
+		{
+			name: "multi-word names, fractions and repeated ingredients",
+			text: "Melt @unsalted butter{1/2%cup}.\nAdd @salt and more @unsalted butter{1/4%cup}.\n",
+			ingredients: []domain.Ingredient{
+				{Name: "unsalted butter", Amount: 0.75, Unit: "cup"},
+				{Name: "salt"},
+			},
+			steps: []domain.Step{
+				{Order: 1, Content: "Melt unsalted butter. Add salt and more unsalted butter.", IngredientRefs: []int{0, 1}},
+			},
+		},
+		{
+			name:     "front matter, comments and sections",
+			text:     "---\ntitle: \"Toast\"\ntime: 5 min\n---\n= Toast =\nToast @bread{2%slices} -- golden\n[- not a step -]\n\n~rest{30%seconds}\n",
+			metadata: map[string]string{"title": "Toast", "time": "5 min"},
+			ingredients: []domain.Ingredient{
+				{Name: "bread", Amount: 2, Unit: "slices"},
+			},
+			steps: []domain.Step{
+				{Order: 1, Content: "Toast bread", IngredientRefs: []int{0}},
+				{Order: 2, Content: "30 seconds", Duration: 30},
+			},
+			timerTotal: 1,
+		},
+		{
+			name:     "hour timers and metadata only",
+			text:     ">> source: grandma\n\nSimmer for ~{1.5%hours}.",
+			metadata: map[string]string{"source": "grandma"},
+			steps: []domain.Step{
+				{Order: 1, Content: "Simmer for 1.5 hours.", Duration: 5400},
+			},
+			timerTotal: 90,
+		},
+		{
+			name:     "empty document",
+			text:     "",
+			metadata: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseCooklang(tt.text)
+			if tt.metadata == nil {
+				tt.metadata = map[string]string{}
+			}
+			if !reflect.DeepEqual(got.Metadata, tt.metadata) {
+				t.Errorf("Metadata = %v, want %v", got.Metadata, tt.metadata)
+			}
+			if !reflect.DeepEqual(got.Ingredients, tt.ingredients) {
+				t.Errorf("Ingredients = %+v, want %+v", got.Ingredients, tt.ingredients)
+			}
+			if !reflect.DeepEqual(got.Cookware, tt.cookware) {
+				t.Errorf("Cookware = %v, want %v", got.Cookware, tt.cookware)
+			}
+			if !reflect.DeepEqual(got.Steps, tt.steps) {
+				t.Errorf("Steps = %+v, want %+v", got.Steps, tt.steps)
+			}
+			if got.TimerTotal != tt.timerTotal {
+				t.Errorf("TimerTotal = %d, want %d", got.TimerTotal, tt.timerTotal)
+			}
+		})
+	}
+}
+
+func TestCooklangRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		recipe domain.Recipe
+	}{
+		{
+			name: "ingredients referenced by steps",
+			recipe: domain.Recipe{
+				Title:       "Pancakes",
+				Description: "Fluffy\npancakes",
+				ServingSize: 4,
+				Time:        25,
+				Ingredients: []domain.Ingredient{
+					{Name: "flour", Amount: 200, Unit: "g"},
+					{Name: "milk", Amount: 300, Unit: "ml"},
+					{Name: "brown sugar", Amount: 1.5, Unit: "tbsp"},
+					{Name: "sugar", Amount: 1, Unit: "tsp"},
+				},
+				Steps: []domain.Step{
+					{Order: 1, Content: "Whisk the flour, milk, brown sugar and sugar in a bowl.", IngredientRefs: []int{0, 1, 2, 3}},
+					{Order: 2, Content: "Fry in a pan for 3 minutes.", Duration: 180},
+				},
+			},
+		},
+		{
+			name: "unreferenced ingredients and step durations",
+			recipe: domain.Recipe{
+				Title: "Tea",
+				Ingredients: []domain.Ingredient{
+					{Name: "black tea", Amount: 1, Unit: "bag"},
+					{Name: "water", Amount: 250, Unit: "ml"},
+				},
+				Steps: []domain.Step{
+					{Order: 1, Content: "Steep.", Duration: 240},
+					{Order: 2, Content: "Serve hot.", Duration: 45},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed := parseCooklang(serializeCooklang(&tt.recipe))
+
+			if got := parsed.Metadata["title"]; got != tt.recipe.Title {
+				t.Errorf("title = %q, want %q", got, tt.recipe.Title)
+			}
+			if got := parseCooklangTime(parsed.Metadata["time"]); got != tt.recipe.Time {
+				t.Errorf("time = %d, want %d", got, tt.recipe.Time)
+			}
+
+			byName := make(map[string]domain.Ingredient)
+			for _, ingredient := range parsed.Ingredients {
+				byName[ingredient.Name] = ingredient
+			}
+			if len(byName) != len(tt.recipe.Ingredients) {
+				t.Errorf("got ingredients %+v, want %+v", parsed.Ingredients, tt.recipe.Ingredients)
+			}
+			for _, want := range tt.recipe.Ingredients {
+				got, ok := byName[want.Name]
+				if !ok || got.Amount != want.Amount || got.Unit != want.Unit {
+					t.Errorf("ingredient %q = %+v, want %+v", want.Name, got, want)
+				}
+			}
+
+			// Unreferenced ingredients get a leading preparation step
+			steps := parsed.Steps
+			if len(steps) == len(tt.recipe.Steps)+1 {
+				steps = steps[1:]
+			}
+			if len(steps) != len(tt.recipe.Steps) {
+				t.Fatalf("got %d steps, want %d: %+v", len(steps), len(tt.recipe.Steps), parsed.Steps)
+			}
+			for i, want := range tt.recipe.Steps {
+				if steps[i].Duration != want.Duration {
+					t.Errorf("step %d duration = %d, want %d", i+1, steps[i].Duration, want.Duration)
+				}
+				if want.Duration == 0 && steps[i].Content != want.Content {
+					t.Errorf("step %d content = %q, want %q", i+1, steps[i].Content, want.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCooklangTime(t *testing.T) {
+	tests := map[string]int{
+		"45 minutes": 45,
+		"1h 30m":     90,
+		"2 hours":    120,
+		"90":         90,
+		"":           0,
+		"soon":       0,
+	}
+	for value, want := range tests {
+		if got := parseCooklangTime(value); got != want {
+			t.Errorf("parseCooklangTime(%q) = %d, want %d", value, got, want)
+		}
+	}
+}
+
+func TestCooklangToRecipeInput(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		content     string
+		title       string
+		description string
+		time        int
+		servings    int
+		wantErr     bool
+	}{
+		{
+			name:        "metadata",
+			filename:    "recipes/pancakes.cook",
+			content:     ">> title: Best Pancakes\n>> servings: 4 people\n>> time: 1h 5m\n>> source: https://example.com\n\nMix @flour{200%g}.",
+			title:       "Best Pancakes",
+			description: "Source: https://example.com",
+			time:        65,
+			servings:    4,
+		},
+		{
+			name:     "title from file name, time from timers",
+			filename: "soups/Tomato Soup.cook",
+			content:  "Simmer @tomatoes{6} for ~{20%minutes}.\n\nBlend for ~{1%minute}.",
+			title:    "Tomato Soup",
+			time:     21,
+			servings: 1,
+		},
+		{
+			name:     "no steps",
+			filename: "empty.cook",
+			content:  ">> title: Nothing\n-- just a comment",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input, err := cooklangToRecipeInput(tt.filename, []byte(tt.content))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if input.Title != tt.title || input.Description != tt.description || input.Time != tt.time || input.ServingSize != tt.servings {
+				t.Errorf("got title %q, description %q, time %d, servings %d; want %q, %q, %d, %d",
+					input.Title, input.Description, input.Time, input.ServingSize, tt.title, tt.description, tt.time, tt.servings)
+			}
+			if input.Ingredients == nil {
+				t.Error("Ingredients is nil, want an empty slice at least")
+			}
+		})
+	}
+}
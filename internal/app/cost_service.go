@@ -0,0 +1,330 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// costRecipeBatchSize is how many recipes are read at a time when recomputing costs
+const costRecipeBatchSize = 200
+
+type costService struct {
+	ingredientPriceRepo interfaces.IngredientPriceRepository
+	recipeRepo          interfaces.RecipeRepository
+	recipeService       interfaces.RecipeService
+}
+
+// NewCostService creates a new recipe cost estimation service
+func NewCostService(ingredientPriceRepo interfaces.IngredientPriceRepository, recipeRepo interfaces.RecipeRepository, recipeService interfaces.RecipeService) interfaces.CostService {
+	return &costService{
+		ingredientPriceRepo: ingredientPriceRepo,
+		recipeRepo:          recipeRepo,
+		recipeService:       recipeService,
+	}
+}
+
+// GetPrices gets the shared prices and the user's own prices in a currency
+func (s *costService) GetPrices(ctx context.Context, userID uuid.UUID, currency string) ([]domain.IngredientPrice, error) {
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+	return s.ingredientPriceRepo.GetPrices(ctx, userID, currency)
+}
+
+// CreatePrice adds a price to the catalogue. There is one price per ingredient name and currency
+// for each user, and one shared price.
+func (s *costService) CreatePrice(ctx context.Context, input interfaces.CreateIngredientPriceInput) (*domain.IngredientPrice, error) {
+	currency, err := normalizeCurrency(input.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	price := &domain.IngredientPrice{
+		UserID:   input.UserID,
+		Name:     strings.Join(strings.Fields(input.Name), " "),
+		Price:    input.Price,
+		Unit:     normalizeUnit(input.Unit),
+		Currency: currency,
+	}
+	if err := validatePrice(price); err != nil {
+		return nil, err
+	}
+	if err := s.checkDuplicatePrice(ctx, price); err != nil {
+		return nil, err
+	}
+
+	if err := s.ingredientPriceRepo.Create(ctx, price); err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// UpdatePrice updates a price kept by the user, or a shared price when userID is nil
+func (s *costService) UpdatePrice(ctx context.Context, id uuid.UUID, userID *uuid.UUID, input interfaces.UpdateIngredientPriceInput) (*domain.IngredientPrice, error) {
+	price, err := s.ownedPrice(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.Join(strings.Fields(*input.Name), " ")
+		renamed := !strings.EqualFold(name, price.Name)
+		price.Name = name
+		if renamed {
+			if err := s.checkDuplicatePrice(ctx, price); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if input.Price != nil {
+		price.Price = *input.Price
+	}
+	if input.Unit != nil {
+		price.Unit = normalizeUnit(*input.Unit)
+	}
+	if err := validatePrice(price); err != nil {
+		return nil, err
+	}
+
+	if err := s.ingredientPriceRepo.Update(ctx, price); err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// DeletePrice deletes a price kept by the user, or a shared price when userID is nil
+func (s *costService) DeletePrice(ctx context.Context, id uuid.UUID, userID *uuid.UUID) error {
+	if _, err := s.ownedPrice(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.ingredientPriceRepo.Delete(ctx, id)
+}
+
+// EstimateRecipeCost estimates the cost of a recipe, sub-recipes included, from the user's
+// prices and the shared prices
+func (s *costService) EstimateRecipeCost(ctx context.Context, recipeID uuid.UUID, userID uuid.UUID, currency string) (*domain.RecipeCost, error) {
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	recipe, err := s.recipeService.GetRecipe(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+	if err := s.recipeService.ExpandSections(ctx, recipe); err != nil {
+		return nil, err
+	}
+
+	prices, err := s.ingredientPriceRepo.GetPrices(ctx, userID, currency)
+	if err != nil {
+		return nil, err
+	}
+	return estimateRecipeCost(recipe, prices, currency), nil
+}
+
+// RecomputeCosts stores the cost of every recipe in each currency that has shared prices, so
+// recipes can be searched by cost. Recipes without any priced ingredient are left out.
+func (s *costService) RecomputeCosts(ctx context.Context) error {
+	shared, err := s.ingredientPriceRepo.GetSharedPrices(ctx)
+	if err != nil {
+		return err
+	}
+	pricesByCurrency := make(map[string][]domain.IngredientPrice)
+	for _, price := range shared {
+		pricesByCurrency[price.Currency] = append(pricesByCurrency[price.Currency], price)
+	}
+
+	now := time.Now()
+	costs := []domain.RecipeCostSummary{}
+	for offset := 0; len(pricesByCurrency) > 0; offset += costRecipeBatchSize {
+		recipes, err := s.recipeRepo.ListRecipes(ctx, offset, costRecipeBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for i := range recipes {
+			recipe := &recipes[i]
+			if err := s.recipeService.ExpandSections(ctx, recipe); err != nil {
+				return err
+			}
+			for currency, prices := range pricesByCurrency {
+				cost := estimateRecipeCost(recipe, prices, currency)
+				if len(cost.Ingredients) == 0 {
+					continue
+				}
+				costs = append(costs, domain.RecipeCostSummary{
+					RecipeID:       recipe.ID,
+					Currency:       currency,
+					TotalCost:      cost.TotalCost,
+					CostPerServing: cost.CostPerServing,
+					Complete:       cost.Complete,
+					ComputedAt:     now,
+				})
+			}
+		}
+
+		if len(recipes) < costRecipeBatchSize {
+			break
+		}
+	}
+
+	return s.ingredientPriceRepo.ReplaceRecipeCosts(ctx, costs)
+}
+
+func (s *costService) ownedPrice(ctx context.Context, id uuid.UUID, userID *uuid.UUID) (*domain.IngredientPrice, error) {
+	price, err := s.ingredientPriceRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("ingredient price not found")
+		}
+		return nil, err
+	}
+	if (price.UserID == nil) != (userID == nil) || (userID != nil && *price.UserID != *userID) {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to change this ingredient price")
+	}
+	return price, nil
+}
+
+func (s *costService) checkDuplicatePrice(ctx context.Context, price *domain.IngredientPrice) error {
+	_, err := s.ingredientPriceRepo.GetByName(ctx, price.UserID, price.Name, price.Currency)
+	if err == nil {
+		return interfaces.NewValidationError(fmt.Sprintf("a price for %q in %s already exists", price.Name, price.Currency))
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return nil
+}
+
+func validatePrice(price *domain.IngredientPrice) error {
+	if price.Name == "" {
+		return interfaces.NewValidationError("ingredient name is required")
+	}
+	if price.Price < 0 || math.IsNaN(price.Price) || math.IsInf(price.Price, 0) {
+		return interfaces.NewValidationError("price cannot be negative")
+	}
+	return nil
+}
+
+// normalizeCurrency uppercases an ISO 4217 currency code, defaulting to DefaultCurrency
+func normalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return domain.DefaultCurrency, nil
+	}
+	if !isASCIILetters(currency, 3, 3) {
+		return "", interfaces.NewValidationError("currency must be a three-letter code such as USD or EUR")
+	}
+	return currency, nil
+}
+
+// estimateRecipeCost prices every ingredient of a recipe that has an amount, a matching price and
+// a unit that converts into the price's unit. The others are listed as unpriced.
+func estimateRecipeCost(recipe *domain.Recipe, prices []domain.IngredientPrice, currency string) *domain.RecipeCost {
+	cost := &domain.RecipeCost{
+		RecipeID:    recipe.ID,
+		Currency:    currency,
+		ServingSize: recipe.ServingSize,
+		Ingredients: []domain.IngredientCost{},
+		Unpriced:    []domain.UnpricedIngredient{},
+	}
+
+	total := 0.0
+	for _, ingredient := range collectRecipeIngredients(recipe) {
+		price := matchIngredientPrice(ingredient.Name, prices)
+		if price == nil {
+			cost.Unpriced = append(cost.Unpriced, domain.UnpricedIngredient{Name: ingredient.Name, Reason: "no price for this ingredient"})
+			continue
+		}
+		if ingredient.Amount <= 0 {
+			cost.Unpriced = append(cost.Unpriced, domain.UnpricedIngredient{Name: ingredient.Name, Reason: "the ingredient has no amount"})
+			continue
+		}
+		amount, ok := convertPricedAmount(ingredient.Amount, ingredient.Unit, price.Unit)
+		if !ok {
+			cost.Unpriced = append(cost.Unpriced, domain.UnpricedIngredient{
+				Name:   ingredient.Name,
+				Reason: fmt.Sprintf("cannot convert %s into %s", unitLabel(ingredient.Unit), unitLabel(price.Unit)),
+			})
+			continue
+		}
+
+		ingredientCost := amount * price.Price
+		total += ingredientCost
+		cost.Ingredients = append(cost.Ingredients, domain.IngredientCost{
+			Name:      ingredient.Name,
+			Amount:    roundAmount(ingredient.Amount),
+			Unit:      ingredient.Unit,
+			Cost:      roundMoney(ingredientCost),
+			PriceName: price.Name,
+		})
+	}
+
+	cost.TotalCost = roundMoney(total)
+	if recipe.ServingSize > 0 {
+		cost.CostPerServing = roundMoney(total / float64(recipe.ServingSize))
+	}
+	cost.Complete = len(cost.Unpriced) == 0
+	return cost
+}
+
+// matchIngredientPrice finds the price of an ingredient. A price for the exact name wins, otherwise
+// the price whose name has the most words in common with the ingredient, such as "flour" for
+// "all-purpose flour". The user's own prices come first, so they win ties with shared prices.
+func matchIngredientPrice(name string, prices []domain.IngredientPrice) *domain.IngredientPrice {
+	key := ingredientKey(name)
+	var best *domain.IngredientPrice
+	bestScore := 0
+	for i := range prices {
+		priceKey := ingredientKey(prices[i].Name)
+		score := 0
+		if priceKey == key {
+			score = math.MaxInt32
+		} else if pantryItemMatches(name, prices[i].Name) {
+			score = len(strings.Fields(priceKey))
+		}
+		if score > bestScore {
+			best, bestScore = &prices[i], score
+		}
+	}
+	return best
+}
+
+// convertPricedAmount converts an ingredient amount into the unit of its price. Counted
+// ingredients may be written without a unit or as pieces.
+func convertPricedAmount(amount float64, from, to string) (float64, bool) {
+	from, to = normalizeUnit(from), normalizeUnit(to)
+	if from == "piece" {
+		from = ""
+	}
+	if to == "piece" {
+		to = ""
+	}
+	return convertAmount(amount, from, to)
+}
+
+func unitLabel(unit string) string {
+	if unit == "" {
+		return "items"
+	}
+	return unit
+}
+
+// roundMoney rounds an amount of money to cents
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
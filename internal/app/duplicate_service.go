@@ -0,0 +1,352 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// The MinHash signature has a section per kind of feature, so the estimated similarity weighs
+// them by their number of hashes: a quarter for the title and three eighths each for the
+// ingredients and the step text
+const (
+	titleHashes      = 16
+	ingredientHashes = 24
+	stepHashes       = 24
+	signatureLength  = titleHashes + ingredientHashes + stepHashes
+	// rowsPerBand is how many signature values are hashed together for locality-sensitive hashing.
+	// With 4 rows, recipes that are 70% similar share a band over 98% of the time.
+	rowsPerBand = 4
+	// stepShingleSize is how many consecutive words of the steps make one feature
+	stepShingleSize = 3
+	// duplicateSimilarity is the estimated similarity above which recipes are likely duplicates
+	duplicateSimilarity = 0.7
+	// maxDuplicateCandidates caps how many recipes sharing a band are compared
+	maxDuplicateCandidates = 200
+	// fingerprintBatchSize is how many recipes are fingerprinted at a time when catching up
+	fingerprintBatchSize = 100
+	// maxReportedDuplicates caps how many duplicate pairs the moderation report is built from
+	maxReportedDuplicates = 5000
+)
+
+// emptySection marks signature values of a section without features, such as a recipe without steps
+const emptySection = math.MaxUint64
+
+type duplicateService struct {
+	recipeDuplicateRepo interfaces.RecipeDuplicateRepository
+	recipeRepo          interfaces.RecipeRepository
+}
+
+// NewDuplicateService creates a new duplicate recipe detection service
+func NewDuplicateService(recipeDuplicateRepo interfaces.RecipeDuplicateRepository, recipeRepo interfaces.RecipeRepository) interfaces.DuplicateService {
+	return &duplicateService{
+		recipeDuplicateRepo: recipeDuplicateRepo,
+		recipeRepo:          recipeRepo,
+	}
+}
+
+// duplicateWords lowercases text and splits it into words, dropping punctuation
+func duplicateWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// recipeFeatures gets the title words, ingredient names and step shingles of a recipe
+func recipeFeatures(recipe *domain.Recipe) (title, ingredients, steps map[string]bool) {
+	title = make(map[string]bool)
+	for _, word := range duplicateWords(recipe.Title) {
+		title[word] = true
+	}
+
+	ingredients = make(map[string]bool)
+	for _, ingredient := range collectRecipeIngredients(recipe) {
+		if key := ingredientKey(ingredient.Name); key != "" {
+			ingredients[key] = true
+		}
+	}
+
+	var words []string
+	for _, step := range recipe.Steps {
+		words = append(words, duplicateWords(step.Content)...)
+	}
+	steps = make(map[string]bool)
+	if len(words) > 0 && len(words) < stepShingleSize {
+		steps[strings.Join(words, " ")] = true
+	}
+	for i := 0; i+stepShingleSize <= len(words); i++ {
+		steps[strings.Join(words[i:i+stepShingleSize], " ")] = true
+	}
+	return title, ingredients, steps
+}
+
+// splitmix64 scrambles a 64-bit value; it derives the MinHash hash functions from one feature hash
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// minHash fills signature with the minimum of each hash function over the features, using the
+// hash functions numbered from offset
+func minHash(features map[string]bool, signature []uint64, offset int) {
+	for i := range signature {
+		signature[i] = emptySection
+	}
+	for feature := range features {
+		h := fnv.New64a()
+		h.Write([]byte(feature))
+		base := h.Sum64()
+		for i := range signature {
+			if value := splitmix64(base ^ splitmix64(uint64(offset+i))); value < signature[i] {
+				signature[i] = value
+			}
+		}
+	}
+}
+
+// recipeSignature computes the MinHash signature of a recipe
+func recipeSignature(recipe *domain.Recipe) []uint64 {
+	title, ingredients, steps := recipeFeatures(recipe)
+	signature := make([]uint64, signatureLength)
+	minHash(title, signature[:titleHashes], 0)
+	minHash(ingredients, signature[titleHashes:titleHashes+ingredientHashes], titleHashes)
+	minHash(steps, signature[titleHashes+ingredientHashes:], titleHashes+ingredientHashes)
+	return signature
+}
+
+// signatureBands hashes each band of rows of a signature. Bands of an empty section are left out
+// as 0, since every recipe without steps would otherwise share them.
+func signatureBands(signature []uint64) []uint64 {
+	bands := make([]uint64, len(signature)/rowsPerBand)
+	row := make([]byte, 8)
+	for band := range bands {
+		h := fnv.New64a()
+		empty := false
+		for _, value := range signature[band*rowsPerBand : (band+1)*rowsPerBand] {
+			if value == emptySection {
+				empty = true
+				break
+			}
+			binary.BigEndian.PutUint64(row, value)
+			h.Write(row)
+		}
+		if empty {
+			continue
+		}
+		bands[band] = h.Sum64()
+		if bands[band] == 0 {
+			bands[band] = 1
+		}
+	}
+	return bands
+}
+
+// signatureSimilarity estimates the weighted Jaccard similarity of two recipes from their signatures.
+// Sections that are empty in both recipes, such as the steps of two recipes without steps, are left
+// out so they neither count for nor against the recipes being duplicates.
+func signatureSimilarity(a, b []uint64) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	equal, compared := 0, 0
+	for i := range a {
+		if a[i] == emptySection && b[i] == emptySection {
+			continue
+		}
+		compared++
+		if a[i] == b[i] {
+			equal++
+		}
+	}
+	if compared == 0 {
+		return 0
+	}
+	return float64(equal) / float64(compared)
+}
+
+// CheckRecipe fingerprints a recipe and records the recipes it likely duplicates. Copies of other
+// users' recipes are kept for moderators; duplicates of the author's own recipes are returned.
+// A fork is not a duplicate of the recipe it was forked from.
+func (s *duplicateService) CheckRecipe(ctx context.Context, recipe *domain.Recipe) ([]domain.DuplicateWarning, error) {
+	signature := recipeSignature(recipe)
+	bands := signatureBands(signature)
+
+	candidates, err := s.recipeDuplicateRepo.FindCandidates(ctx, recipe.ID, bands, maxDuplicateCandidates)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	duplicates := []domain.RecipeDuplicate{}
+	for _, candidate := range candidates {
+		if recipe.ForkedFromID != nil && candidate.RecipeID == *recipe.ForkedFromID {
+			continue
+		}
+		similarity := signatureSimilarity(signature, candidate.Signature)
+		if similarity < duplicateSimilarity {
+			continue
+		}
+		duplicates = append(duplicates, domain.RecipeDuplicate{
+			RecipeID:      recipe.ID,
+			DuplicateOfID: candidate.RecipeID,
+			Similarity:    roundAmount(similarity),
+			SameAuthor:    candidate.UserID == recipe.UserID,
+			CreatedAt:     now,
+		})
+	}
+
+	if err := s.recipeDuplicateRepo.SaveFingerprint(ctx, domain.RecipeFingerprint{
+		RecipeID:  recipe.ID,
+		UserID:    recipe.UserID,
+		Signature: signature,
+		Bands:     bands,
+		UpdatedAt: now,
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.recipeDuplicateRepo.ReplaceDuplicates(ctx, recipe.ID, duplicates); err != nil {
+		return nil, err
+	}
+
+	warnings := []domain.DuplicateWarning{}
+	for _, duplicate := range duplicates {
+		if !duplicate.SameAuthor {
+			continue
+		}
+		original, err := s.recipeRepo.GetRecipe(ctx, duplicate.DuplicateOfID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		warnings = append(warnings, domain.DuplicateWarning{
+			RecipeID:   original.ID,
+			Title:      original.Title,
+			Similarity: duplicate.Similarity,
+		})
+	}
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return warnings[i].Similarity > warnings[j].Similarity
+	})
+	return warnings, nil
+}
+
+// FingerprintMissing checks every active recipe without a fingerprint, oldest first, so later
+// recipes are the ones recorded as duplicates of earlier ones
+func (s *duplicateService) FingerprintMissing(ctx context.Context) error {
+	for {
+		ids, err := s.recipeDuplicateRepo.GetUnfingerprintedRecipeIDs(ctx, fingerprintBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		for _, id := range ids {
+			recipe, err := s.recipeRepo.GetRecipe(ctx, id)
+			if err != nil {
+				return err
+			}
+			if _, err := s.CheckRecipe(ctx, recipe); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetDuplicateClusters groups the recorded duplicates into clusters of recipes connected by
+// being duplicates of each other, with the flagged and most similar clusters first
+func (s *duplicateService) GetDuplicateClusters(ctx context.Context) ([]domain.DuplicateCluster, error) {
+	duplicates, err := s.recipeDuplicateRepo.GetDuplicates(ctx, maxReportedDuplicates)
+	if err != nil {
+		return nil, err
+	}
+
+	// Union-find over recipe IDs
+	parent := make(map[uuid.UUID]uuid.UUID)
+	var find func(id uuid.UUID) uuid.UUID
+	find = func(id uuid.UUID) uuid.UUID {
+		if _, ok := parent[id]; !ok {
+			parent[id] = id
+		}
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, duplicate := range duplicates {
+		parent[find(duplicate.RecipeID)] = find(duplicate.DuplicateOfID)
+	}
+
+	clusters := make(map[uuid.UUID]*domain.DuplicateCluster)
+	var roots []uuid.UUID
+	for _, duplicate := range duplicates {
+		root := find(duplicate.RecipeID)
+		cluster, ok := clusters[root]
+		if !ok {
+			cluster = &domain.DuplicateCluster{}
+			clusters[root] = cluster
+			roots = append(roots, root)
+		}
+		cluster.MaxSimilarity = math.Max(cluster.MaxSimilarity, duplicate.Similarity)
+	}
+
+	members := make(map[uuid.UUID][]uuid.UUID)
+	for id := range parent {
+		root := find(id)
+		members[root] = append(members[root], id)
+	}
+
+	report := make([]domain.DuplicateCluster, 0, len(roots))
+	for _, root := range roots {
+		cluster := clusters[root]
+		authors := make(map[uuid.UUID]bool)
+		for _, id := range members[root] {
+			recipe, err := s.recipeRepo.GetRecipe(ctx, id)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					continue
+				}
+				return nil, err
+			}
+			authors[recipe.UserID] = true
+			cluster.Recipes = append(cluster.Recipes, domain.DuplicateClusterRecipe{
+				RecipeID:  recipe.ID,
+				UserID:    recipe.UserID,
+				Title:     recipe.Title,
+				CreatedAt: recipe.CreatedAt,
+			})
+		}
+		if len(cluster.Recipes) < 2 {
+			continue
+		}
+		sort.Slice(cluster.Recipes, func(i, j int) bool {
+			return cluster.Recipes[i].CreatedAt.Before(cluster.Recipes[j].CreatedAt)
+		})
+		cluster.Flagged = len(authors) > 1
+		report = append(report, *cluster)
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].Flagged != report[j].Flagged {
+			return report[i].Flagged
+		}
+		return report[i].MaxSimilarity > report[j].MaxSimilarity
+	})
+	return report, nil
+}
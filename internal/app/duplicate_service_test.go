@@ -0,0 +1,43 @@
+package app
+
+import (
+	"cookaholic/internal/domain"
+	"testing"
+)
+
+func TestSignatureSimilarity(t *testing.T) {
+	pancakes := domain.Recipe{
+		Title:       "Fluffy pancakes",
+		Ingredients: []domain.Ingredient{{Name: "flour"}, {Name: "milk"}, {Name: "eggs"}},
+	}
+	withSteps := pancakes
+	withSteps.Steps = []domain.Step{{Order: 1, Content: "Whisk everything together and fry in a hot pan."}}
+	waffles := domain.Recipe{
+		Title:       "Crispy waffles",
+		Ingredients: []domain.Ingredient{{Name: "butter"}, {Name: "sugar"}},
+	}
+
+	tests := []struct {
+		name      string
+		a, b      domain.Recipe
+		duplicate bool
+	}{
+		{"identical recipes without steps", pancakes, pancakes, true},
+		{"identical recipes with steps", withSteps, withSteps, true},
+		{"only one recipe has steps", pancakes, withSteps, false},
+		{"different recipes without steps", pancakes, waffles, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			similarity := signatureSimilarity(recipeSignature(&tt.a), recipeSignature(&tt.b))
+			if got := similarity >= duplicateSimilarity; got != tt.duplicate {
+				t.Errorf("similarity = %.3f, duplicate = %v, want %v", similarity, got, tt.duplicate)
+			}
+		})
+	}
+
+	if got := signatureSimilarity(recipeSignature(&domain.Recipe{}), recipeSignature(&domain.Recipe{})); got != 0 {
+		t.Errorf("similarity of two empty recipes = %v, want 0", got)
+	}
+}
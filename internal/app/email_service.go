@@ -2,9 +2,11 @@ package app
 
 import (
 	"context"
+	"cookaholic/internal/domain"
 	"fmt"
 	"net/smtp"
 	"os"
+	"strings"
 )
 
 type EmailService struct {
@@ -41,6 +43,49 @@ func (s *EmailService) SendOTP(ctx context.Context, email, otp string) error {
 		Cookaholic Team
 	`, otp)
 
+	return s.send(email, subject, body)
+}
+
+// SendPantryExpiryReminder warns a user about pantry items that expire soon and suggests recipes that use them
+func (s *EmailService) SendPantryExpiryReminder(ctx context.Context, email string, items []domain.PantryItem, suggestions []domain.PantrySuggestion) error {
+	subject := "Pantry items expiring soon"
+
+	var list strings.Builder
+	for _, item := range items {
+		list.WriteString("\t\t- " + item.Name)
+		if item.ExpiresAt != nil {
+			list.WriteString(" (expires " + item.ExpiresAt.Format("Mon, Jan 2") + ")")
+		}
+		list.WriteString("\n")
+	}
+
+	var recipes strings.Builder
+	for _, suggestion := range suggestions {
+		recipes.WriteString(fmt.Sprintf("\t\t- %s (uses %s)\n",
+			suggestion.Recipe.Title, strings.Join(suggestion.ExpiringItems, ", ")))
+	}
+	if recipes.Len() == 0 {
+		recipes.WriteString("\t\tNo recipes found for these items yet.\n")
+	}
+
+	body := fmt.Sprintf(`
+		Hello,
+
+		These items in your pantry will expire soon:
+
+%s
+		Recipes that use them:
+
+%s
+		Best regards,
+		Cookaholic Team
+	`, list.String(), recipes.String())
+
+	return s.send(email, subject, body)
+}
+
+// send sends a plain text email
+func (s *EmailService) send(email, subject, body string) error {
 	// Prepare email message
 	message := fmt.Sprintf("Subject: %s\r\n"+
 		"Content-Type: text/plain; charset=UTF-8\r\n"+
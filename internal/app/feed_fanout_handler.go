@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/interfaces"
+)
+
+// FeedFanoutHandler adds newly created recipes to the feeds of the author's followers
+type FeedFanoutHandler struct {
+	feedRepo interfaces.FeedRepository
+}
+
+func NewFeedFanoutHandler(feedRepo interfaces.FeedRepository) *FeedFanoutHandler {
+	return &FeedFanoutHandler{
+		feedRepo: feedRepo,
+	}
+}
+
+func (h *FeedFanoutHandler) Handle(ctx context.Context, event interfaces.Event) error {
+	recipeEvent, ok := event.(interfaces.RecipeCreatedEvent)
+	if !ok {
+		return nil
+	}
+
+	return h.feedRepo.FanOutRecipe(ctx, recipeEvent.RecipeID, recipeEvent.UserID, recipeEvent.CreatedAt)
+}
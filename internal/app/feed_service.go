@@ -0,0 +1,121 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultFeedPageSize = 20
+	maxFeedPageSize     = 50
+	// feedPopularEvery puts a popular recipe in every fourth slot of the feed
+	feedPopularEvery = 4
+	// feedEngagedCategories is how many of the reader's most engaged categories popular recipes come from
+	feedEngagedCategories = 5
+)
+
+type feedService struct {
+	feedRepo interfaces.FeedRepository
+}
+
+// NewFeedService creates a new home feed service
+func NewFeedService(feedRepo interfaces.FeedRepository) interfaces.FeedService {
+	return &feedService{
+		feedRepo: feedRepo,
+	}
+}
+
+// feedCursor is the position of a reader in both sources of the feed. It is handed out as opaque base64.
+type feedCursor struct {
+	Following *domain.FeedPosition `json:"f,omitempty"`
+	Popular   *domain.FeedPosition `json:"p,omitempty"`
+	// Served counts the items shown so far, so popular recipes keep their slots across pages
+	Served int `json:"n"`
+}
+
+func decodeFeedCursor(cursor string) (*feedCursor, error) {
+	decoded := &feedCursor{}
+	if cursor == "" {
+		return decoded, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, interfaces.NewValidationError("invalid cursor")
+	}
+	if err := json.Unmarshal(data, decoded); err != nil || decoded.Served < 0 {
+		return nil, interfaces.NewValidationError("invalid cursor")
+	}
+	return decoded, nil
+}
+
+func (c *feedCursor) encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// GetFeed gets a page of the user's home feed: recipes from followed users, with a popular recipe
+// from the reader's favourite categories in every fourth slot. Either source fills in when the other runs out.
+func (s *feedService) GetFeed(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*domain.FeedPage, error) {
+	if limit <= 0 {
+		limit = defaultFeedPageSize
+	}
+	if limit > maxFeedPageSize {
+		limit = maxFeedPageSize
+	}
+
+	position, err := decodeFeedCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	followed, err := s.feedRepo.GetFollowedRecipes(ctx, userID, position.Following, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Readers who have not engaged with any category yet see popular recipes from all categories
+	categoryIDs, err := s.feedRepo.GetEngagedCategoryIDs(ctx, userID, feedEngagedCategories)
+	if err != nil {
+		return nil, err
+	}
+	popular, err := s.feedRepo.GetPopularRecipes(ctx, userID, categoryIDs, position.Popular, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.FeedItem, 0, limit)
+	next := *position
+	f, p := 0, 0
+	for len(items) < limit {
+		popularSlot := (next.Served+1)%feedPopularEvery == 0
+		var item domain.FeedItem
+		switch {
+		case p < len(popular) && (popularSlot || f >= len(followed)):
+			item = popular[p]
+			p++
+			next.Popular = &domain.FeedPosition{PublishedAt: item.PublishedAt, RecipeID: item.Recipe.ID}
+		case f < len(followed):
+			item = followed[f]
+			f++
+			next.Following = &domain.FeedPosition{PublishedAt: item.PublishedAt, RecipeID: item.Recipe.ID}
+		}
+		if item.Recipe == nil {
+			break
+		}
+		items = append(items, item)
+		next.Served++
+	}
+
+	page := &domain.FeedPage{Items: items}
+	// A source may have more items if it returned a full page or was not used up
+	if f < len(followed) || p < len(popular) || len(followed) == limit || len(popular) == limit {
+		page.NextCursor = next.encode()
+	}
+	return page, nil
+}
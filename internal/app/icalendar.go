@@ -0,0 +1,83 @@
+package app
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar (RFC 5545) date-time layouts; floating times have no zone and follow the viewer's clock
+const (
+	icalUTCLayout      = "20060102T150405Z"
+	icalFloatingLayout = "20060102T150405"
+	icalMaxLineOctets  = 75
+)
+
+// icalEvent is a VEVENT of an iCalendar document
+type icalEvent struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	URL         string
+}
+
+// icalWriter builds an iCalendar document with CRLF line endings and folded content lines
+type icalWriter struct {
+	buf strings.Builder
+}
+
+// line writes a content line, folding it so no physical line exceeds 75 octets
+func (w *icalWriter) line(name, value string) {
+	content := name + ":" + value
+	limit := icalMaxLineOctets
+	for len(content) > limit {
+		// Never split a multi-byte UTF-8 sequence across lines
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		w.buf.WriteString(content[:cut])
+		w.buf.WriteString("\r\n ")
+		content = content[cut:]
+		// Continuation lines start with a space, which counts towards the limit
+		limit = icalMaxLineOctets - 1
+	}
+	w.buf.WriteString(content)
+	w.buf.WriteString("\r\n")
+}
+
+func (w *icalWriter) event(event icalEvent, stamp time.Time) {
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", icalEscape(event.UID))
+	w.line("DTSTAMP", stamp.UTC().Format(icalUTCLayout))
+	w.line("DTSTART", event.Start.Format(icalFloatingLayout))
+	w.line("DTEND", event.End.Format(icalFloatingLayout))
+	w.line("SUMMARY", icalEscape(event.Summary))
+	if event.Description != "" {
+		w.line("DESCRIPTION", icalEscape(event.Description))
+	}
+	if event.URL != "" {
+		// URL values are of type URI and are not escaped
+		w.line("URL", event.URL)
+	}
+	w.line("END", "VEVENT")
+}
+
+func (w *icalWriter) bytes() []byte {
+	return []byte(w.buf.String())
+}
+
+// icalEscape escapes a TEXT value as required by RFC 5545 section 3.3.11
+func icalEscape(value string) string {
+	value = strings.ReplaceAll(value, "\r\n", "\n")
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return replacer.Replace(value)
+}
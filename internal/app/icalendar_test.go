@@ -0,0 +1,189 @@
+package app
+
+import (
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// unfoldICal undoes line folding and splits an iCalendar document into its content lines
+func unfoldICal(t *testing.T, doc string) []string {
+	t.Helper()
+	if !strings.HasSuffix(doc, "\r\n") {
+		t.Fatalf("document does not end with CRLF: %q", doc)
+	}
+	var lines []string
+	for _, physical := range strings.Split(strings.TrimSuffix(doc, "\r\n"), "\r\n") {
+		if len(physical) > icalMaxLineOctets {
+			t.Errorf("line is %d octets long: %q", len(physical), physical)
+		}
+		if strings.HasPrefix(physical, " ") {
+			lines[len(lines)-1] += physical[1:]
+			continue
+		}
+		lines = append(lines, physical)
+	}
+	return lines
+}
+
+// unescapeICal reverses icalEscape
+func unescapeICal(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(value)
+}
+
+func TestICalEscape(t *testing.T) {
+	tests := map[string]string{
+		"Pasta, beans; peas":   `Pasta\, beans\; peas`,
+		`C:\recipes`:           `C:\\recipes`,
+		"line one\r\nline two": `line one\nline two`,
+		"plain":                "plain",
+	}
+	for value, want := range tests {
+		if got := icalEscape(value); got != want {
+			t.Errorf("icalEscape(%q) = %q, want %q", value, got, want)
+		}
+		if got := unescapeICal(icalEscape(value)); got != strings.ReplaceAll(value, "\r\n", "\n") {
+			t.Errorf("round trip of %q = %q", value, got)
+		}
+	}
+}
+
+func TestICalWriterRoundTrip(t *testing.T) {
+	events := []icalEvent{
+		{
+			UID:         "short@cookaholic",
+			Start:       time.Date(2024, 3, 9, 18, 15, 0, 0, time.Local),
+			End:         time.Date(2024, 3, 9, 19, 0, 0, 0, time.Local),
+			Summary:     "Dinner: Chili, mild; with rice",
+			Description: "Dinner for 4.\nhttps://example.com/recipes/1",
+		},
+		{
+			UID:     "long@cookaholic",
+			Start:   time.Date(2024, 12, 31, 7, 30, 0, 0, time.Local),
+			End:     time.Date(2024, 12, 31, 8, 0, 0, 0, time.Local),
+			Summary: "Breakfast: " + strings.Repeat("Crème brûlée French toast ", 8),
+			URL:     "https://example.com/recipes/" + strings.Repeat("a", 100),
+		},
+	}
+	stamp := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	w := &icalWriter{}
+	w.line("BEGIN", "VCALENDAR")
+	for _, event := range events {
+		w.event(event, stamp)
+	}
+	w.line("END", "VCALENDAR")
+
+	lines := unfoldICal(t, string(w.bytes()))
+	var parsed []map[string]string
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			t.Fatalf("content line without a value: %q", line)
+		}
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			parsed = append(parsed, make(map[string]string))
+		case len(parsed) > 0 && name != "END" && name != "BEGIN":
+			parsed[len(parsed)-1][name] = value
+		}
+	}
+
+	if len(parsed) != len(events) {
+		t.Fatalf("parsed %d events, want %d", len(parsed), len(events))
+	}
+	for i, want := range events {
+		got := parsed[i]
+		checks := map[string]string{
+			"UID":     want.UID,
+			"DTSTAMP": "20240301T120000Z",
+			"DTSTART": want.Start.Format(icalFloatingLayout),
+			"DTEND":   want.End.Format(icalFloatingLayout),
+			"SUMMARY": want.Summary,
+		}
+		if want.Description != "" {
+			checks["DESCRIPTION"] = want.Description
+		}
+		if want.URL != "" {
+			checks["URL"] = want.URL
+		}
+		for name, value := range checks {
+			if name == "SUMMARY" || name == "DESCRIPTION" || name == "UID" {
+				got[name] = unescapeICal(got[name])
+			}
+			if got[name] != value {
+				t.Errorf("event %d %s = %q, want %q", i, name, got[name], value)
+			}
+		}
+	}
+}
+
+func TestMealEvent(t *testing.T) {
+	service := &calendarFeedService{baseURL: "https://cook.example"}
+	recipe := &domain.Recipe{
+		BaseModel:   &common.BaseModel{ID: uuid.MustParse("6f1c1d2e-0000-4000-8000-000000000001")},
+		Title:       "Lentil soup",
+		Time:        45,
+		ServingSize: 4,
+	}
+	entryID := uuid.MustParse("6f1c1d2e-0000-4000-8000-000000000002")
+
+	tests := []struct {
+		name   string
+		entry  domain.MealPlanEntry
+		recipe domain.Recipe
+		start  string
+		end    string
+		text   string
+	}{
+		{
+			name:   "dinner ends at the serving time",
+			entry:  domain.MealPlanEntry{Date: "2024-03-09", Slot: domain.MealSlotDinner, Servings: 2},
+			recipe: *recipe,
+			start:  "20240309T181500",
+			end:    "20240309T190000",
+			text:   "Dinner for 2. Cooking time: 45 minutes.",
+		},
+		{
+			name:   "recipes without a time get the default duration",
+			entry:  domain.MealPlanEntry{Date: "2024-03-10", Slot: domain.MealSlotBreakfast},
+			recipe: domain.Recipe{BaseModel: recipe.BaseModel, Title: recipe.Title, ServingSize: 4},
+			start:  "20240310T073000",
+			end:    "20240310T080000",
+			text:   "Breakfast for 4. Cooking time: 0 minutes.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.entry.BaseModel = &common.BaseModel{ID: entryID}
+			event, err := service.mealEvent(tt.entry, &tt.recipe)
+			if err != nil {
+				t.Fatalf("mealEvent() error = %v", err)
+			}
+			if got := event.Start.Format(icalFloatingLayout); got != tt.start {
+				t.Errorf("start = %s, want %s", got, tt.start)
+			}
+			if got := event.End.Format(icalFloatingLayout); got != tt.end {
+				t.Errorf("end = %s, want %s", got, tt.end)
+			}
+			if !strings.HasPrefix(event.Description, tt.text) {
+				t.Errorf("description = %q, want prefix %q", event.Description, tt.text)
+			}
+			if want := "https://cook.example/recipes/" + recipe.ID.String(); event.URL != want {
+				t.Errorf("URL = %q, want %q", event.URL, want)
+			}
+			if event.UID != entryID.String()+"@cookaholic" {
+				t.Errorf("UID = %q", event.UID)
+			}
+		})
+	}
+
+	if _, err := service.mealEvent(domain.MealPlanEntry{BaseModel: &common.BaseModel{}, Date: "09/03/2024"}, recipe); err == nil {
+		t.Error("mealEvent() with a malformed date: want an error")
+	}
+}
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"bytes"
 	"context"
 	"cookaholic/internal/common"
 	"cookaholic/internal/interfaces"
@@ -28,3 +29,8 @@ func (s *ImageService) UploadFile(ctx context.Context, file *multipart.FileHeade
 func (s *ImageService) UploadMultipleFiles(ctx context.Context, files []*multipart.FileHeader, folder string) ([]*common.Image, error) {
 	return s.cloudinaryService.UploadMultipleImages(ctx, files, folder)
 }
+
+// UploadData uploads an image from memory
+func (s *ImageService) UploadData(ctx context.Context, data []byte, folder string) (*common.Image, error) {
+	return s.cloudinaryService.UploadImageData(ctx, bytes.NewReader(data), folder)
+}
@@ -0,0 +1,320 @@
+package app
+
+import (
+	"cookaholic/internal/domain"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// knownUnits maps the unit spellings found in recipe text to the unit stored on an ingredient
+var knownUnits = map[string]string{
+	"g": "g", "gram": "g", "grams": "g",
+	"kg": "kg", "kilogram": "kg", "kilograms": "kg",
+	"mg": "mg",
+	"ml": "ml", "milliliter": "ml", "milliliters": "ml", "millilitre": "ml", "millilitres": "ml",
+	"l": "l", "liter": "l", "liters": "l", "litre": "l", "litres": "l",
+	"tsp": "tsp", "teaspoon": "tsp", "teaspoons": "tsp",
+	"tbsp": "tbsp", "tablespoon": "tbsp", "tablespoons": "tbsp",
+	"cup": "cup", "cups": "cup",
+	"oz": "oz", "ounce": "oz", "ounces": "oz",
+	"lb": "lb", "lbs": "lb", "pound": "lb", "pounds": "lb",
+	"pinch": "pinch", "pinches": "pinch",
+	"clove": "clove", "cloves": "clove",
+	"can": "can", "cans": "can",
+	"slice": "slice", "slices": "slice",
+	"piece": "piece", "pieces": "piece",
+}
+
+// localizedUnits maps unit spellings of other languages, some of them several words long, to the
+// unit stored on an ingredient. Trailing periods are dropped from each word before the lookup.
+var localizedUnits = map[string]string{
+	// German
+	"gramm": "g", "kilogramm": "kg", "liter": "l", "milliliter": "ml",
+	"el": "tbsp", "esslöffel": "tbsp", "tl": "tsp", "teelöffel": "tsp",
+	"tasse": "cup", "tassen": "cup", "prise": "pinch", "prisen": "pinch",
+	"zehe": "clove", "zehen": "clove", "dose": "can", "dosen": "can",
+	"scheibe": "slice", "scheiben": "slice", "stück": "piece", "stk": "piece",
+	// French
+	"gramme": "g", "grammes": "g", "kilo": "kg", "kilos": "kg", "litre": "l", "millilitre": "ml",
+	"c à soupe": "tbsp", "cuillère à soupe": "tbsp", "cuillères à soupe": "tbsp", "càs": "tbsp", "c à s": "tbsp",
+	"c à café": "tsp", "cuillère à café": "tsp", "cuillères à café": "tsp", "càc": "tsp", "c à c": "tsp",
+	"tasses": "cup", "pincée": "pinch", "pincées": "pinch", "gousse": "clove", "gousses": "clove",
+	"boîte": "can", "boîtes": "can", "tranche": "slice", "tranches": "slice", "morceau": "piece", "morceaux": "piece",
+	// Spanish and Portuguese
+	"gramos": "g", "gramas": "g", "litro": "l", "litros": "l", "mililitros": "ml",
+	"cucharada": "tbsp", "cucharadas": "tbsp", "cucharadita": "tsp", "cucharaditas": "tsp",
+	"colher de sopa": "tbsp", "colheres de sopa": "tbsp", "colher de chá": "tsp", "colheres de chá": "tsp",
+	"taza": "cup", "tazas": "cup", "xícara": "cup", "xícaras": "cup",
+	"pizca": "pinch", "pizcas": "pinch", "pitada": "pinch", "pitadas": "pinch",
+	"diente": "clove", "dientes": "clove", "dente": "clove", "dentes": "clove",
+	"lata": "can", "latas": "can", "rebanada": "slice", "rebanadas": "slice", "fatia": "slice", "fatias": "slice",
+	"pieza": "piece", "piezas": "piece", "pedaço": "piece", "pedaços": "piece",
+	// Italian
+	"grammi": "g", "litri": "l", "millilitri": "ml",
+	"cucchiaio": "tbsp", "cucchiai": "tbsp", "cucchiaino": "tsp", "cucchiaini": "tsp",
+	"tazza": "cup", "tazze": "cup", "pizzico": "pinch", "pizzichi": "pinch",
+	"spicchio": "clove", "spicchi": "clove", "scatola": "can", "scatole": "can",
+	"fetta": "slice", "fette": "slice", "pezzo": "piece", "pezzi": "piece",
+	// Dutch
+	"eetlepel": "tbsp", "eetlepels": "tbsp", "theelepel": "tsp", "theelepels": "tsp",
+	"kopje": "cup", "kopjes": "cup", "snufje": "pinch", "teen": "clove", "teentjes": "clove",
+	"blik": "can", "blikken": "can", "plak": "slice", "plakken": "slice", "stuk": "piece", "stuks": "piece",
+}
+
+// maxUnitWords is the length of the longest unit spelling in words, such as "cuillères à soupe"
+const maxUnitWords = 3
+
+// vulgarFractions maps unicode fraction characters to fractions parseQuantity understands
+var vulgarFractions = strings.NewReplacer(
+	"½", " 1/2 ", "⅓", " 1/3 ", "⅔", " 2/3 ", "¼", " 1/4 ", "¾", " 3/4 ",
+	"⅕", " 1/5 ", "⅖", " 2/5 ", "⅗", " 3/5 ", "⅘", " 4/5 ", "⅙", " 1/6 ", "⅚", " 5/6 ",
+	"⅐", " 1/7 ", "⅛", " 1/8 ", "⅜", " 3/8 ", "⅝", " 5/8 ", "⅞", " 7/8 ", "⅑", " 1/9 ", "⅒", " 1/10 ",
+	"⁄", "/", "–", "-", "—", "-",
+)
+
+// rangeWords join the two ends of an amount range, as in "2 to 3"
+var rangeWords = map[string]bool{"-": true, "to": true, "or": true, "bis": true, "à": true, "a": true, "até": true, "tot": true, "o": true}
+
+// unitConnectives may follow a unit before the name, as in "2 cups of flour" or "200 g de farine"
+var unitConnectives = map[string]bool{"of": true, "de": true, "del": true, "di": true, "van": true}
+
+// parseIngredientLine splits a free-text ingredient line such as "2 1/2 cups all-purpose flour, sifted"
+// into its amount, unit, name and note. It understands unicode fractions, ranges such as "2-3",
+// units of several languages, parenthetical sizes such as "1 (14 oz) can" and preparation notes
+// after a comma. Lines that cannot be split keep the whole text as name.
+func parseIngredientLine(line string) domain.Ingredient {
+	text, notes := extractParentheticals(vulgarFractions.Replace(line))
+	fields := splitRanges(splitGluedUnits(strings.Fields(text)))
+	ingredient := domain.Ingredient{}
+
+	amount, i := parseAmount(fields, 0)
+	if i > 0 {
+		ingredient.Amount = amount
+		// A range keeps its lower end as the amount
+		if i < len(fields)-1 && rangeWords[strings.ToLower(fields[i])] {
+			if upper, next := parseAmount(fields, i+1); next > i+1 && upper > amount {
+				ingredient.AmountMax = upper
+				i = next
+			} else if fields[i] == "-" && next > i+1 {
+				// A hyphenated range that does not go up, such as "3-2", cannot be read
+				return domain.Ingredient{Name: strings.TrimSpace(line)}
+			}
+		}
+	} else if len(fields) > 1 && (strings.EqualFold(fields[0], "a") || strings.EqualFold(fields[0], "an")) {
+		// "a pinch of salt" counts as one pinch, but only when a unit follows
+		if _, words := lookupUnit(fields[1:]); words > 0 {
+			ingredient.Amount = 1
+			i = 1
+		}
+	}
+
+	if i > 0 && i < len(fields) {
+		if unit, words := lookupUnit(fields[i:]); words > 0 {
+			ingredient.Unit = unit
+			i += words
+			if i < len(fields)-1 && unitConnectives[strings.ToLower(fields[i])] {
+				i++
+			}
+		}
+	}
+
+	rest := strings.Join(fields[i:], " ")
+	// French and Italian elide the connective into the name, as in "d'huile"
+	for _, prefix := range []string{"d'", "d’"} {
+		if ingredient.Unit != "" && strings.HasPrefix(strings.ToLower(rest), prefix) {
+			rest = rest[len(prefix):]
+		}
+	}
+	name, note, _ := strings.Cut(rest, ",")
+	if note = strings.TrimSpace(note); note != "" {
+		notes = append(notes, note)
+	}
+
+	ingredient.Name = strings.TrimSpace(name)
+	ingredient.Note = strings.Join(notes, "; ")
+	if ingredient.Name == "" {
+		return domain.Ingredient{Name: strings.TrimSpace(line)}
+	}
+
+	return ingredient
+}
+
+// parseAmount reads a quantity such as "2" or "1 1/2" starting at fields[start]. It returns the
+// amount and the index of the first field after it.
+func parseAmount(fields []string, start int) (float64, int) {
+	amount := 0.0
+	i := start
+	for i < len(fields) {
+		value, ok := parseQuantity(fields[i])
+		if !ok {
+			break
+		}
+		amount += value
+		i++
+	}
+	return amount, i
+}
+
+// lookupUnit matches the longest unit spelling at the start of the fields and returns the unit
+// stored on an ingredient and the number of fields it spans
+func lookupUnit(fields []string) (string, int) {
+	for words := maxUnitWords; words > 0; words-- {
+		if words > len(fields) {
+			continue
+		}
+		parts := make([]string, words)
+		for i, field := range fields[:words] {
+			parts[i] = strings.TrimSuffix(strings.ToLower(field), ".")
+		}
+		spelling := strings.Join(parts, " ")
+		if unit, ok := knownUnits[spelling]; ok {
+			return unit, words
+		}
+		if unit, ok := extraUnitSpellings[spelling]; ok {
+			return unit, words
+		}
+		if unit, ok := localizedUnits[spelling]; ok {
+			return unit, words
+		}
+		if _, ok := convertibleUnits[spelling]; ok {
+			return spelling, words
+		}
+	}
+	return "", 0
+}
+
+// extractParentheticals removes the parenthesized parts of a line and returns them as notes
+func extractParentheticals(line string) (string, []string) {
+	notes := []string{}
+	var text, note strings.Builder
+	depth := 0
+	for _, r := range line {
+		switch {
+		case r == '(':
+			if depth > 0 {
+				note.WriteRune(r)
+			}
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+			if depth > 0 {
+				note.WriteRune(r)
+				continue
+			}
+			if value := strings.TrimSpace(note.String()); value != "" {
+				notes = append(notes, value)
+			}
+			note.Reset()
+			text.WriteRune(' ')
+		case depth > 0:
+			note.WriteRune(r)
+		default:
+			text.WriteRune(r)
+		}
+	}
+	// An unclosed parenthesis is kept as text
+	if depth > 0 {
+		text.WriteString(" (" + note.String())
+	}
+	return text.String(), notes
+}
+
+// splitGluedUnits splits amounts written together with their unit, such as "200g" or "2-3tbsp"
+func splitGluedUnits(fields []string) []string {
+	split := make([]string, 0, len(fields))
+	for _, field := range fields {
+		end := strings.IndexFunc(field, func(r rune) bool {
+			return !(r >= '0' && r <= '9') && r != '.' && r != ',' && r != '/' && r != '-'
+		})
+		if end > 0 && isAmountText(field[:end]) {
+			if _, words := lookupUnit([]string{field[end:]}); words > 0 {
+				split = append(split, field[:end], field[end:])
+				continue
+			}
+		}
+		split = append(split, field)
+	}
+	return split
+}
+
+// splitRanges splits hyphenated ranges such as "2-3" into "2", "-" and "3". The upper end of a
+// range starting with a unicode fraction, as in "½-1", arrives as its own field "-1".
+func splitRanges(fields []string) []string {
+	split := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if lower, upper, found := strings.Cut(field, "-"); found && isAmountText(field) {
+			split = append(split, lower, "-", upper)
+			continue
+		}
+		if upper := strings.TrimPrefix(field, "-"); upper != field && isAmountText(upper) {
+			split = append(split, "-", upper)
+			continue
+		}
+		split = append(split, field)
+	}
+	return split
+}
+
+// isAmountText reports whether text is a quantity or a hyphenated range of quantities
+func isAmountText(text string) bool {
+	if lower, upper, found := strings.Cut(text, "-"); found {
+		_, ok1 := parseQuantity(lower)
+		_, ok2 := parseQuantity(upper)
+		return ok1 && ok2
+	}
+	_, ok := parseQuantity(text)
+	return ok
+}
+
+// parseQuantity parses whole numbers, decimals and simple fractions like "1/2". Negative
+// quantities are rejected, a leading hyphen belongs to a range.
+func parseQuantity(s string) (float64, bool) {
+	s = strings.TrimSpace(vulgarFractions.Replace(s))
+	if whole, fraction, found := strings.Cut(s, " "); found {
+		w, ok1 := parseQuantity(whole)
+		f, ok2 := parseQuantity(fraction)
+		return w + f, ok1 && ok2
+	}
+
+	if num, den, found := strings.Cut(s, "/"); found {
+		n, err1 := strconv.ParseFloat(num, 64)
+		d, err2 := strconv.ParseFloat(den, 64)
+		if err1 != nil || err2 != nil || n < 0 || d <= 0 {
+			return 0, false
+		}
+		return n / d, true
+	}
+
+	value, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil || value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, false
+	}
+	return value, true
+}
+
+// formatIngredientLine renders an ingredient back into a line such as "1.5-2 cup flour, sifted"
+func formatIngredientLine(ingredient domain.Ingredient) string {
+	parts := make([]string, 0, 3)
+	if ingredient.Amount > 0 {
+		amount := formatAmount(ingredient.Amount)
+		if ingredient.AmountMax > ingredient.Amount {
+			amount += "-" + formatAmount(ingredient.AmountMax)
+		}
+		parts = append(parts, amount)
+	}
+	if ingredient.Unit != "" {
+		parts = append(parts, ingredient.Unit)
+	}
+	parts = append(parts, ingredient.Name)
+	line := strings.Join(parts, " ")
+	if ingredient.Note != "" {
+		line += ", " + ingredient.Note
+	}
+	return line
+}
+
+// formatAmount prints an amount with at most two decimals and no trailing zeros
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(math.Round(amount*100)/100, 'f', -1, 64)
+}
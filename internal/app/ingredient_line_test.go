@@ -0,0 +1,82 @@
+package app
+
+import (
+	"cookaholic/internal/domain"
+	"testing"
+)
+
+func TestParseIngredientLine(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want domain.Ingredient
+	}{
+		// Amounts and fractions
+		{"whole number without unit", "3 eggs", domain.Ingredient{Name: "eggs", Amount: 3}},
+		{"decimal", "1.5 kg potatoes", domain.Ingredient{Name: "potatoes", Amount: 1.5, Unit: "kg"}},
+		{"decimal comma", "0,5 l Milch", domain.Ingredient{Name: "Milch", Amount: 0.5, Unit: "l"}},
+		{"mixed fraction", "2 1/2 cups all-purpose flour, sifted", domain.Ingredient{Name: "all-purpose flour", Amount: 2.5, Unit: "cup", Note: "sifted"}},
+		{"unit glued to amount", "200g butter", domain.Ingredient{Name: "butter", Amount: 200, Unit: "g"}},
+		{"a with a unit", "a pinch of salt", domain.Ingredient{Name: "salt", Amount: 1, Unit: "pinch"}},
+		{"a without a unit", "an onion", domain.Ingredient{Name: "an onion"}},
+
+		// Unicode vulgar fractions
+		{"vulgar fraction", "½ tsp salt", domain.Ingredient{Name: "salt", Amount: 0.5, Unit: "tsp"}},
+		{"whole and vulgar fraction", "1½ cups milk", domain.Ingredient{Name: "milk", Amount: 1.5, Unit: "cup"}},
+		{"fraction slash", "3⁄4 cup sugar", domain.Ingredient{Name: "sugar", Amount: 0.75, Unit: "cup"}},
+
+		// Ranges
+		{"hyphen range", "2-3 cloves garlic, minced", domain.Ingredient{Name: "garlic", Amount: 2, AmountMax: 3, Unit: "clove", Note: "minced"}},
+		{"word range", "2 to 3 tbsp olive oil", domain.Ingredient{Name: "olive oil", Amount: 2, AmountMax: 3, Unit: "tbsp"}},
+		{"en dash range", "1–2 cups water", domain.Ingredient{Name: "water", Amount: 1, AmountMax: 2, Unit: "cup"}},
+		{"glued range", "2-3tbsp honey", domain.Ingredient{Name: "honey", Amount: 2, AmountMax: 3, Unit: "tbsp"}},
+		{"fraction range", "½-1 tsp chili flakes", domain.Ingredient{Name: "chili flakes", Amount: 0.5, AmountMax: 1, Unit: "tsp"}},
+
+		// Notes
+		{"parenthetical size", "1 (14 oz) can diced tomatoes", domain.Ingredient{Name: "diced tomatoes", Amount: 1, Unit: "can", Note: "14 oz"}},
+		{"parenthetical and comma notes", "2 cups (packed) brown sugar, divided", domain.Ingredient{Name: "brown sugar", Amount: 2, Unit: "cup", Note: "packed; divided"}},
+		{"nested parentheses", "1 cup rice (basmati (or jasmine))", domain.Ingredient{Name: "rice", Amount: 1, Unit: "cup", Note: "basmati (or jasmine)"}},
+
+		// Localized units
+		{"German", "2 EL Olivenöl", domain.Ingredient{Name: "Olivenöl", Amount: 2, Unit: "tbsp"}},
+		{"French connective", "200 g de farine", domain.Ingredient{Name: "farine", Amount: 200, Unit: "g"}},
+		{"French multi-word unit with elision", "1 c. à soupe d'huile", domain.Ingredient{Name: "huile", Amount: 1, Unit: "tbsp"}},
+		{"Spanish", "2 cucharadas de azúcar", domain.Ingredient{Name: "azúcar", Amount: 2, Unit: "tbsp"}},
+		{"Portuguese", "1 colher de chá de sal", domain.Ingredient{Name: "sal", Amount: 1, Unit: "tsp"}},
+		{"Italian with elision", "3 spicchi d'aglio", domain.Ingredient{Name: "aglio", Amount: 3, Unit: "clove"}},
+		{"Dutch", "2 eetlepels suiker", domain.Ingredient{Name: "suiker", Amount: 2, Unit: "tbsp"}},
+
+		// Malformed input keeps the text as name
+		{"no amount", "salt and pepper to taste", domain.Ingredient{Name: "salt and pepper to taste"}},
+		{"amount only", "1 1/2", domain.Ingredient{Name: "1 1/2"}},
+		{"division by zero", "1/0 cup sugar", domain.Ingredient{Name: "1/0 cup sugar"}},
+		{"descending range", "3-2 apples", domain.Ingredient{Name: "3-2 apples"}},
+		{"not a number", "NaN eggs", domain.Ingredient{Name: "NaN eggs"}},
+		{"unclosed parenthesis", "1 cup flour (sifted", domain.Ingredient{Name: "flour (sifted", Amount: 1, Unit: "cup"}},
+		{"empty", "", domain.Ingredient{}},
+		{"blank", "   ", domain.Ingredient{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseIngredientLine(tt.line); got != tt.want {
+				t.Errorf("parseIngredientLine(%q) = %+v, want %+v", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatIngredientLineRoundTrip(t *testing.T) {
+	tests := []domain.Ingredient{
+		{Name: "flour", Amount: 2.5, Unit: "cup", Note: "sifted"},
+		{Name: "garlic", Amount: 2, AmountMax: 3, Unit: "clove"},
+		{Name: "eggs", Amount: 3},
+		{Name: "salt"},
+	}
+	for _, want := range tests {
+		line := formatIngredientLine(want)
+		if got := parseIngredientLine(line); got != want {
+			t.Errorf("parseIngredientLine(%q) = %+v, want %+v", line, got, want)
+		}
+	}
+}
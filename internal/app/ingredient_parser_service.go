@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"fmt"
+	"strings"
+)
+
+// maxParsedIngredientLines limits how many lines are parsed in one request
+const maxParsedIngredientLines = 200
+
+type ingredientParserService struct {
+	ingredientService interfaces.IngredientService
+}
+
+// NewIngredientParserService creates a new ingredient line parser service
+func NewIngredientParserService(ingredientService interfaces.IngredientService) interfaces.IngredientParserService {
+	return &ingredientParserService{
+		ingredientService: ingredientService,
+	}
+}
+
+// ParseIngredients parses each non-blank line into an ingredient and links it to the catalogue.
+// List markers such as "-" or "*" at the start of pasted lines are ignored.
+func (s *ingredientParserService) ParseIngredients(ctx context.Context, input interfaces.ParseIngredientsInput) ([]domain.Ingredient, error) {
+	lines := append([]string{}, input.Lines...)
+	if input.Text != "" {
+		lines = append(lines, strings.Split(input.Text, "\n")...)
+	}
+
+	ingredients := []domain.Ingredient{}
+	for _, line := range lines {
+		line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*•"))
+		if line == "" {
+			continue
+		}
+		if len(ingredients) == maxParsedIngredientLines {
+			return nil, interfaces.NewValidationError(fmt.Sprintf("at most %d ingredient lines can be parsed at once", maxParsedIngredientLines))
+		}
+		ingredients = append(ingredients, parseIngredientLine(line))
+	}
+	if len(ingredients) == 0 {
+		return nil, interfaces.NewValidationError("no ingredient lines to parse")
+	}
+
+	if err := s.ingredientService.LinkIngredients(ctx, ingredients); err != nil {
+		return nil, err
+	}
+	return ingredients, nil
+}
@@ -0,0 +1,271 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultAutocompleteLimit = 10
+	maxAutocompleteLimit     = 50
+	// maxIngredientTermLength keeps lookup terms within the indexed column
+	maxIngredientTermLength = 100
+)
+
+type ingredientService struct {
+	ingredientRepo interfaces.IngredientRepository
+}
+
+// NewIngredientService creates a new ingredient catalogue service
+func NewIngredientService(ingredientRepo interfaces.IngredientRepository) interfaces.IngredientService {
+	return &ingredientService{
+		ingredientRepo: ingredientRepo,
+	}
+}
+
+// Autocomplete suggests catalogue ingredients whose name, plural or synonym starts with the query,
+// or has a word starting with it. Each ingredient is suggested once, by its best matching term.
+func (s *ingredientService) Autocomplete(ctx context.Context, query string, limit int) ([]domain.IngredientSuggestion, error) {
+	if limit <= 0 {
+		limit = defaultAutocompleteLimit
+	}
+	if limit > maxAutocompleteLimit {
+		limit = maxAutocompleteLimit
+	}
+
+	suggestions := []domain.IngredientSuggestion{}
+	key := ingredientKey(query)
+	if key == "" {
+		return suggestions, nil
+	}
+
+	// Several terms of one ingredient can match, so more terms than suggestions are read
+	matches, err := s.ingredientRepo.SearchTerms(ctx, key, limit*3)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	for _, match := range matches {
+		if seen[match.IngredientID] {
+			continue
+		}
+		seen[match.IngredientID] = true
+		suggestions = append(suggestions, match)
+		if len(suggestions) == limit {
+			break
+		}
+	}
+	return suggestions, nil
+}
+
+// GetIngredient gets a catalogue ingredient
+func (s *ingredientService) GetIngredient(ctx context.Context, id uuid.UUID) (*domain.CanonicalIngredient, error) {
+	ingredient, err := s.ingredientRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("ingredient not found")
+		}
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+// CreateIngredient adds an ingredient to the catalogue. Its name, plural and synonyms must not
+// belong to another ingredient.
+func (s *ingredientService) CreateIngredient(ctx context.Context, input interfaces.IngredientInput) (*domain.CanonicalIngredient, error) {
+	ingredient := &domain.CanonicalIngredient{}
+	terms, err := applyIngredientInput(ingredient, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTerms(ctx, uuid.Nil, terms); err != nil {
+		return nil, err
+	}
+
+	if err := s.ingredientRepo.Create(ctx, ingredient, terms); err != nil {
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+// UpdateIngredient replaces a catalogue ingredient. Recipes keep their links; lines are linked
+// again by name the next time their recipe is saved.
+func (s *ingredientService) UpdateIngredient(ctx context.Context, id uuid.UUID, input interfaces.IngredientInput) (*domain.CanonicalIngredient, error) {
+	ingredient, err := s.GetIngredient(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	terms, err := applyIngredientInput(ingredient, input)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkTerms(ctx, id, terms); err != nil {
+		return nil, err
+	}
+
+	if err := s.ingredientRepo.Update(ctx, ingredient, terms); err != nil {
+		return nil, err
+	}
+	return ingredient, nil
+}
+
+// DeleteIngredient removes an ingredient from the catalogue
+func (s *ingredientService) DeleteIngredient(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetIngredient(ctx, id); err != nil {
+		return err
+	}
+	return s.ingredientRepo.Delete(ctx, id)
+}
+
+// LinkIngredients links each ingredient line to the catalogue ingredient with the longest term
+// ending its name, so "all-purpose flour" links to "flour" unless the catalogue has it as well.
+// Lines without a match are left unlinked, replacing any link they had.
+func (s *ingredientService) LinkIngredients(ctx context.Context, ingredients []domain.Ingredient) error {
+	candidates := make([][]string, len(ingredients))
+	var terms []string
+	for i, ingredient := range ingredients {
+		candidates[i] = ingredientTermCandidates(ingredient.Name)
+		terms = append(terms, candidates[i]...)
+	}
+
+	ids, err := s.ingredientRepo.GetIDsByTerms(ctx, terms)
+	if err != nil {
+		return err
+	}
+
+	for i := range ingredients {
+		ingredients[i].IngredientID = nil
+		for _, term := range candidates[i] {
+			if id, ok := ids[term]; ok {
+				ingredients[i].IngredientID = &id
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// checkTerms makes sure none of the terms belongs to an ingredient other than the one with the given ID
+func (s *ingredientService) checkTerms(ctx context.Context, id uuid.UUID, terms map[string]string) error {
+	keys := make([]string, 0, len(terms))
+	for term := range terms {
+		keys = append(keys, term)
+	}
+
+	owners, err := s.ingredientRepo.GetIDsByTerms(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for term, owner := range owners {
+		if owner != id {
+			return interfaces.NewValidationError(fmt.Sprintf("%q already belongs to another ingredient", terms[term]))
+		}
+	}
+	return nil
+}
+
+// applyIngredientInput validates the input, sets it on the ingredient and returns the ingredient's
+// lookup terms, keyed by normalized term
+func applyIngredientInput(ingredient *domain.CanonicalIngredient, input interfaces.IngredientInput) (map[string]string, error) {
+	name := strings.Join(strings.Fields(input.Name), " ")
+	if name == "" {
+		return nil, interfaces.NewValidationError("ingredient name is required")
+	}
+
+	category := strings.ToLower(strings.TrimSpace(input.Category))
+	if category == "" {
+		category = domain.IngredientCategoryOther
+	}
+	if !containsString(domain.IngredientCategories, category) {
+		return nil, interfaces.NewValidationError(fmt.Sprintf("category must be one of %s", strings.Join(domain.IngredientCategories, ", ")))
+	}
+
+	if input.Density != nil && (*input.Density <= 0 || math.IsInf(*input.Density, 0) || math.IsNaN(*input.Density)) {
+		return nil, interfaces.NewValidationError("density must be a positive number of grams per millilitre")
+	}
+	if err := validateNutrition(input.Nutrition); err != nil {
+		return nil, err
+	}
+
+	terms := make(map[string]string)
+	addTerm := func(term string) (bool, error) {
+		term = strings.Join(strings.Fields(term), " ")
+		key := ingredientKey(term)
+		if key == "" {
+			return false, nil
+		}
+		if utf8.RuneCountInString(term) > maxIngredientTermLength {
+			return false, interfaces.NewValidationError(fmt.Sprintf("ingredient names can be at most %d characters", maxIngredientTermLength))
+		}
+		if _, ok := terms[key]; ok {
+			return false, nil
+		}
+		terms[key] = term
+		return true, nil
+	}
+
+	if _, err := addTerm(name); err != nil {
+		return nil, err
+	}
+	plural := strings.Join(strings.Fields(input.Plural), " ")
+	if _, err := addTerm(plural); err != nil {
+		return nil, err
+	}
+	synonyms := []string{}
+	for _, synonym := range input.Synonyms {
+		added, err := addTerm(synonym)
+		if err != nil {
+			return nil, err
+		}
+		if added {
+			synonyms = append(synonyms, strings.Join(strings.Fields(synonym), " "))
+		}
+	}
+
+	ingredient.Name = name
+	ingredient.Plural = plural
+	ingredient.Synonyms = synonyms
+	ingredient.Category = category
+	ingredient.Density = input.Density
+	ingredient.NutrientRef = strings.TrimSpace(input.NutrientRef)
+	ingredient.Nutrition = input.Nutrition
+	return terms, nil
+}
+
+// ingredientTermCandidates lists the terms an ingredient name could be catalogued under, from the
+// whole name down to its last word
+func ingredientTermCandidates(name string) []string {
+	words := strings.Fields(ingredientKey(name))
+	candidates := make([]string, 0, len(words))
+	for i := range words {
+		candidates = append(candidates, strings.Join(words[i:], " "))
+	}
+	return candidates
+}
+
+// linkRecipeIngredients links the ingredient lines of a recipe and its sections to the catalogue.
+// A failed lookup does not fail the save; the lines are linked the next time the recipe is saved.
+func linkRecipeIngredients(ctx context.Context, ingredientService interfaces.IngredientService, recipe *domain.Recipe) {
+	if err := ingredientService.LinkIngredients(ctx, recipe.Ingredients); err != nil {
+		log.Printf("Failed to link the ingredients of recipe %q to the catalogue: %v", recipe.Title, err)
+		return
+	}
+	for i := range recipe.Sections {
+		if err := ingredientService.LinkIngredients(ctx, recipe.Sections[i].Ingredients); err != nil {
+			log.Printf("Failed to link the ingredients of recipe %q to the catalogue: %v", recipe.Title, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,421 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const mealPlanDateLayout = "2006-01-02"
+
+type mealPlanService struct {
+	mealPlanRepo        interfaces.MealPlanRepository
+	recipeService       interfaces.RecipeService
+	shoppingListService interfaces.ShoppingListService
+}
+
+// NewMealPlanService creates a new meal plan service
+func NewMealPlanService(
+	mealPlanRepo interfaces.MealPlanRepository,
+	recipeService interfaces.RecipeService,
+	shoppingListService interfaces.ShoppingListService) interfaces.MealPlanService {
+	return &mealPlanService{
+		mealPlanRepo:        mealPlanRepo,
+		recipeService:       recipeService,
+		shoppingListService: shoppingListService,
+	}
+}
+
+// AddEntry plans a recipe for a date and meal slot
+func (s *mealPlanService) AddEntry(ctx context.Context, input interfaces.CreateMealPlanEntryInput) (*domain.MealPlanEntry, error) {
+	date, err := parsePlanDate(input.Date)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := normalizeMealSlot(input.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	recipe, err := s.recipeService.GetRecipe(ctx, input.RecipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+
+	entry := &domain.MealPlanEntry{
+		BaseModel: &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		},
+		UserID:   input.UserID,
+		RecipeID: input.RecipeID,
+		Date:     date.Format(mealPlanDateLayout),
+		Slot:     slot,
+		Servings: input.Servings,
+	}
+
+	if err := s.mealPlanRepo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	entry.Recipe = recipe
+	return entry, nil
+}
+
+// UpdateEntry moves an entry to another date or slot, or changes its servings
+func (s *mealPlanService) UpdateEntry(ctx context.Context, id uuid.UUID, userID uuid.UUID, input interfaces.UpdateMealPlanEntryInput) (*domain.MealPlanEntry, error) {
+	entry, err := s.ownedEntry(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Date != "" {
+		date, err := parsePlanDate(input.Date)
+		if err != nil {
+			return nil, err
+		}
+		entry.Date = date.Format(mealPlanDateLayout)
+	}
+	if input.Slot != "" {
+		slot, err := normalizeMealSlot(input.Slot)
+		if err != nil {
+			return nil, err
+		}
+		entry.Slot = slot
+	}
+	if input.Servings != nil {
+		entry.Servings = *input.Servings
+	}
+	entry.UpdatedAt = time.Now()
+
+	if err := s.mealPlanRepo.Update(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// DeleteEntry removes an entry from the user's plan
+func (s *mealPlanService) DeleteEntry(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.ownedEntry(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.mealPlanRepo.Delete(ctx, id)
+}
+
+// GetWeek returns the plan of the week containing the given date, with the planned recipes
+func (s *mealPlanService) GetWeek(ctx context.Context, userID uuid.UUID, date string) (*domain.MealPlanWeek, error) {
+	start, end, err := planWeek(date)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.mealPlanRepo.GetByUserAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	recipes, err := s.loadRecipes(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+	for i := range entries {
+		entries[i].Recipe = recipes[entries[i].RecipeID]
+	}
+
+	return buildMealPlanWeek(start, end, entries), nil
+}
+
+// CopyWeek copies the entries of the week containing input.From into the week containing date,
+// keeping each entry on the same weekday and slot
+func (s *mealPlanService) CopyWeek(ctx context.Context, userID uuid.UUID, date string, input interfaces.CopyMealPlanWeekInput) (*domain.MealPlanWeek, error) {
+	targetStart, targetEnd, err := planWeek(date)
+	if err != nil {
+		return nil, err
+	}
+	sourceStart, sourceEnd, err := planWeek(input.From)
+	if err != nil {
+		return nil, err
+	}
+	if sourceStart.Equal(targetStart) {
+		return nil, interfaces.NewValidationError("cannot copy a week onto itself")
+	}
+
+	source, err := s.mealPlanRepo.GetByUserAndDateRange(ctx, userID, sourceStart, sourceEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]bool)
+	if input.Replace {
+		if err := s.mealPlanRepo.DeleteByUserAndDateRange(ctx, userID, targetStart, targetEnd); err != nil {
+			return nil, err
+		}
+	} else {
+		current, err := s.mealPlanRepo.GetByUserAndDateRange(ctx, userID, targetStart, targetEnd)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range current {
+			existing[entry.Date+"|"+entry.Slot+"|"+entry.RecipeID.String()] = true
+		}
+	}
+
+	offset := int(targetStart.Sub(sourceStart).Hours()/24 + 0.5)
+	copies := make([]domain.MealPlanEntry, 0, len(source))
+	for _, entry := range source {
+		sourceDate, err := time.ParseInLocation(mealPlanDateLayout, entry.Date, time.Local)
+		if err != nil {
+			continue
+		}
+		newDate := sourceDate.AddDate(0, 0, offset).Format(mealPlanDateLayout)
+
+		// Skip entries that are already planned, so copying twice does not duplicate meals
+		key := newDate + "|" + entry.Slot + "|" + entry.RecipeID.String()
+		if existing[key] {
+			continue
+		}
+		existing[key] = true
+
+		copies = append(copies, domain.MealPlanEntry{
+			BaseModel: &common.BaseModel{
+				ID:        uuid.New(),
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+				Status:    1,
+			},
+			UserID:   userID,
+			RecipeID: entry.RecipeID,
+			Date:     newDate,
+			Slot:     entry.Slot,
+			Servings: entry.Servings,
+		})
+	}
+
+	if err := s.mealPlanRepo.CreateEntries(ctx, copies); err != nil {
+		return nil, err
+	}
+
+	return s.GetWeek(ctx, userID, date)
+}
+
+// GenerateShoppingList creates a shopping list with everything needed for the week's plan
+func (s *mealPlanService) GenerateShoppingList(ctx context.Context, userID uuid.UUID, date string, name string) (*domain.ShoppingList, error) {
+	start, end, err := planWeek(date)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.mealPlanRepo.GetByUserAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, interfaces.NewValidationError("no meals are planned for this week")
+	}
+
+	recipes, err := s.loadRecipes(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	input := interfaces.GenerateShoppingListInput{
+		UserID: userID,
+		Name:   name,
+	}
+	for _, entry := range entries {
+		// Recipes deleted after they were planned are left off the list
+		if recipes[entry.RecipeID] == nil {
+			continue
+		}
+		input.Recipes = append(input.Recipes, interfaces.ShoppingListRecipeInput{
+			RecipeID: entry.RecipeID,
+			Servings: entry.Servings,
+		})
+	}
+	if len(input.Recipes) == 0 {
+		return nil, interfaces.NewValidationError("no meals are planned for this week")
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		input.Name = fmt.Sprintf("Week of %s", start.Format("Jan 2, 2006"))
+	}
+
+	return s.shoppingListService.GenerateShoppingList(ctx, input)
+}
+
+// GetWeekSummary totals the cooking time and nutrition of the week's plan.
+// Nutrition is counted per planned serving; recipes without nutrition facts are listed separately.
+func (s *mealPlanService) GetWeekSummary(ctx context.Context, userID uuid.UUID, date string) (*domain.MealPlanSummary, error) {
+	start, end, err := planWeek(date)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.mealPlanRepo.GetByUserAndDateRange(ctx, userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	recipes, err := s.loadRecipes(ctx, entries)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.MealPlanSummary{
+		WeekStart:        start.Format(mealPlanDateLayout),
+		WeekEnd:          end.Format(mealPlanDateLayout),
+		DailyNutrition:   []domain.DailyNutrition{},
+		MissingNutrition: []uuid.UUID{},
+	}
+
+	daily := make(map[string]domain.Nutrition)
+	for _, entry := range entries {
+		recipe := recipes[entry.RecipeID]
+		if recipe == nil {
+			continue
+		}
+
+		summary.MealCount++
+		summary.TotalTime += recipe.Time
+
+		if recipe.Nutrition == nil {
+			if !containsUUID(summary.MissingNutrition, recipe.ID) {
+				summary.MissingNutrition = append(summary.MissingNutrition, recipe.ID)
+			}
+			continue
+		}
+
+		servings := entry.Servings
+		if servings <= 0 {
+			servings = recipe.ServingSize
+		}
+		if servings <= 0 {
+			servings = 1
+		}
+
+		nutrition := recipe.Nutrition.Scale(float64(servings))
+		summary.Nutrition = summary.Nutrition.Add(nutrition)
+		daily[entry.Date] = daily[entry.Date].Add(nutrition)
+	}
+
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format(mealPlanDateLayout)
+		summary.DailyNutrition = append(summary.DailyNutrition, domain.DailyNutrition{Date: key, Nutrition: daily[key]})
+	}
+
+	return summary, nil
+}
+
+// loadRecipes fetches the recipes of the entries once each; deleted recipes map to nil
+func (s *mealPlanService) loadRecipes(ctx context.Context, entries []domain.MealPlanEntry) (map[uuid.UUID]*domain.Recipe, error) {
+	recipes := make(map[uuid.UUID]*domain.Recipe)
+	for _, entry := range entries {
+		if _, ok := recipes[entry.RecipeID]; ok {
+			continue
+		}
+
+		recipe, err := s.recipeService.GetRecipe(ctx, entry.RecipeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				recipes[entry.RecipeID] = nil
+				continue
+			}
+			return nil, err
+		}
+		recipes[entry.RecipeID] = recipe
+	}
+	return recipes, nil
+}
+
+func (s *mealPlanService) ownedEntry(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.MealPlanEntry, error) {
+	entry, err := s.mealPlanRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("meal plan entry not found")
+		}
+		return nil, err
+	}
+	if entry.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to change this meal plan entry")
+	}
+	return entry, nil
+}
+
+// buildMealPlanWeek lays the entries out over the seven days of the week, ordered by slot
+func buildMealPlanWeek(start, end time.Time, entries []domain.MealPlanEntry) *domain.MealPlanWeek {
+	byDate := make(map[string][]domain.MealPlanEntry)
+	for _, entry := range entries {
+		byDate[entry.Date] = append(byDate[entry.Date], entry)
+	}
+
+	week := &domain.MealPlanWeek{
+		WeekStart: start.Format(mealPlanDateLayout),
+		WeekEnd:   end.Format(mealPlanDateLayout),
+		Days:      make([]domain.MealPlanDay, 0, 7),
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format(mealPlanDateLayout)
+		dayEntries := byDate[key]
+		if dayEntries == nil {
+			dayEntries = []domain.MealPlanEntry{}
+		}
+		sort.SliceStable(dayEntries, func(i, j int) bool {
+			return mealSlotRank(dayEntries[i].Slot) < mealSlotRank(dayEntries[j].Slot)
+		})
+		week.Days = append(week.Days, domain.MealPlanDay{Date: key, Entries: dayEntries})
+	}
+	return week
+}
+
+// planWeek returns the Monday and Sunday of the week containing the date
+func planWeek(date string) (time.Time, time.Time, error) {
+	day, err := parsePlanDate(date)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	// Weeks start on Monday
+	offset := (int(day.Weekday()) + 6) % 7
+	start := day.AddDate(0, 0, -offset)
+	return start, start.AddDate(0, 0, 6), nil
+}
+
+func parsePlanDate(date string) (time.Time, error) {
+	day, err := time.ParseInLocation(mealPlanDateLayout, strings.TrimSpace(date), time.Local)
+	if err != nil {
+		return time.Time{}, interfaces.NewValidationError(fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", date))
+	}
+	return day, nil
+}
+
+func normalizeMealSlot(slot string) (string, error) {
+	slot = strings.ToLower(strings.TrimSpace(slot))
+	for _, known := range domain.MealSlots {
+		if slot == known {
+			return slot, nil
+		}
+	}
+	return "", interfaces.NewValidationError("slot must be one of breakfast, lunch, dinner or snack")
+}
+
+func mealSlotRank(slot string) int {
+	for i, known := range domain.MealSlots {
+		if slot == known {
+			return i
+		}
+	}
+	return len(domain.MealSlots)
+}
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// parseMealieExport reads recipes exported from Mealie: a recipe object, an array of them, or a
+// page of the Mealie API with the recipes under "items"
+func parseMealieExport(content []byte) ([]bulkImportRow, error) {
+	var data interface{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, errors.New("the import file must be a Mealie JSON export")
+	}
+
+	var elements []interface{}
+	switch v := data.(type) {
+	case []interface{}:
+		elements = v
+	case map[string]interface{}:
+		if items, ok := v["items"].([]interface{}); ok {
+			elements = items
+		} else if recipes, ok := v["recipes"].([]interface{}); ok {
+			elements = recipes
+		} else {
+			elements = []interface{}{v}
+		}
+	default:
+		return nil, errors.New("the import file must be a Mealie JSON export")
+	}
+
+	rows := make([]bulkImportRow, len(elements))
+	for i, element := range elements {
+		recipe, ok := element.(map[string]interface{})
+		if !ok {
+			rows[i].err = interfaces.NewValidationError("invalid recipe: not a JSON object")
+			continue
+		}
+		rows[i] = mealieToRow(recipe)
+	}
+	return rows, nil
+}
+
+// mealieToRow maps a Mealie recipe onto an import row. Its first category becomes the recipe's
+// category and its notes are kept in the description. Mealie JSON exports do not embed photos: an
+// image given as an http(s) URL is kept, while Mealie's own media keys cannot be resolved without
+// the Mealie server and are dropped.
+func mealieToRow(recipe map[string]interface{}) bulkImportRow {
+	description := strings.TrimSpace(jsonString(recipe["description"]))
+	if notes, ok := recipe["notes"].([]interface{}); ok {
+		for _, note := range notes {
+			text := strings.TrimSpace(jsonString(note))
+			if text == "" {
+				continue
+			}
+			if description != "" {
+				description += "\n\n"
+			}
+			description += text
+		}
+	}
+	if source := strings.TrimSpace(jsonString(recipe["orgURL"])); source != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Source: " + source
+	}
+
+	time := parseImportedDuration(jsonString(recipe["totalTime"]))
+	if time == 0 {
+		time = parseImportedDuration(jsonString(recipe["prepTime"])) +
+			parseImportedDuration(firstNonEmpty(jsonString(recipe["performTime"]), jsonString(recipe["cookTime"])))
+	}
+
+	servings := int(mealieNumber(recipe["recipeServings"]))
+	if servings == 0 {
+		servings = parseYield(jsonString(recipe["recipeYield"]))
+	}
+
+	row := bulkImportRow{
+		recipe: interfaces.BulkRecipe{
+			Title:       strings.TrimSpace(jsonString(recipe["name"])),
+			Description: description,
+			Time:        time,
+			ServingSize: servings,
+			Tags:        mealieNames(recipe["tags"]),
+			Nutrition:   mealieNutrition(recipe["nutrition"]),
+		},
+		categories: mealieNames(recipe["recipeCategory"]),
+	}
+
+	if ingredients, ok := recipe["recipeIngredient"].([]interface{}); ok {
+		for _, value := range ingredients {
+			if ingredient, ok := mealieIngredient(value); ok {
+				row.recipe.Ingredients = append(row.recipe.Ingredients, ingredient)
+			}
+		}
+	}
+	for _, text := range jsonInstructions(recipe["recipeInstructions"]) {
+		row.recipe.Steps = append(row.recipe.Steps, domain.Step{Order: len(row.recipe.Steps) + 1, Content: text})
+	}
+	if image := strings.TrimSpace(jsonString(recipe["image"])); strings.HasPrefix(image, "https://") || strings.HasPrefix(image, "http://") {
+		row.recipe.Images = append(row.recipe.Images, common.Image{URL: image})
+	}
+
+	return row
+}
+
+// mealieIngredient maps a structured Mealie ingredient with a food, quantity and unit. Older
+// exports and ingredients without a food only have text, which is parsed as a free-text line.
+func mealieIngredient(value interface{}) (domain.Ingredient, bool) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		line := strings.TrimSpace(jsonString(value))
+		return parseIngredientLine(line), line != ""
+	}
+
+	food := strings.TrimSpace(mealieName(fields["food"]))
+	note := strings.TrimSpace(jsonString(fields["note"]))
+	if food == "" {
+		line := strings.TrimSpace(firstNonEmpty(jsonString(fields["originalText"]), jsonString(fields["display"]), note))
+		return parseIngredientLine(line), line != ""
+	}
+
+	ingredient := domain.Ingredient{Name: food, Amount: mealieNumber(fields["quantity"])}
+	if note != "" {
+		ingredient.Name += ", " + note
+	}
+	if unit := strings.TrimSpace(mealieName(fields["unit"])); unit != "" {
+		if canonical, ok := knownUnits[strings.ToLower(unit)]; ok {
+			unit = canonical
+		}
+		ingredient.Unit = unit
+	}
+	return ingredient, true
+}
+
+// mealieName reads the name of a Mealie food, unit, tag or category, which older exports
+// store as a plain string
+func mealieName(value interface{}) string {
+	if fields, ok := value.(map[string]interface{}); ok {
+		return jsonString(fields["name"])
+	}
+	return jsonString(value)
+}
+
+func mealieNames(value interface{}) []string {
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if name := strings.TrimSpace(mealieName(item)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+var mealieNumberRegexp = regexp.MustCompile(`\d+(?:\.\d+)?`)
+
+// mealieNumber reads a number that Mealie may store as text with a unit, such as "200 kcal"
+func mealieNumber(value interface{}) float64 {
+	if number, ok := value.(float64); ok {
+		return number
+	}
+	match := mealieNumberRegexp.FindString(jsonString(value))
+	if match == "" {
+		return 0
+	}
+	number, _ := strconv.ParseFloat(match, 64)
+	return number
+}
+
+// mealieNutrition maps Mealie's schema.org style nutrition, leaving it out when no value is set
+func mealieNutrition(value interface{}) *domain.Nutrition {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	nutrition := domain.Nutrition{
+		Calories:      mealieNumber(fields["calories"]),
+		Protein:       mealieNumber(fields["proteinContent"]),
+		Carbohydrates: mealieNumber(fields["carbohydrateContent"]),
+		Fat:           mealieNumber(fields["fatContent"]),
+		Fiber:         mealieNumber(fields["fiberContent"]),
+		Sugar:         mealieNumber(fields["sugarContent"]),
+		Sodium:        mealieNumber(fields["sodiumContent"]),
+	}
+	if nutrition == (domain.Nutrition{}) {
+		return nil
+	}
+	return &nutrition
+}
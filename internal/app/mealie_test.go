@@ -0,0 +1,20 @@
+package app
+
+import "testing"
+
+func TestMealieImages(t *testing.T) {
+	tests := map[string]int{
+		`{"name":"Soup","image":"https://mealie.example.com/soup.webp"}`: 1,
+		`{"name":"Soup","image":"Vz8k"}`:                                 0,
+		`{"name":"Soup"}`:                                                0,
+	}
+	for content, want := range tests {
+		rows, err := parseMealieExport([]byte(content))
+		if err != nil {
+			t.Fatalf("parseMealieExport(%s) error = %v", content, err)
+		}
+		if got := len(rows[0].recipe.Images); got != want {
+			t.Errorf("parseMealieExport(%s) kept %d images, want %d", content, got, want)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"bytes"
+	"cookaholic/internal/interfaces"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// applyMergePatch applies an RFC 7396 JSON Merge Patch to the JSON form of document and decodes
+// the result into patched. A member absent from the patch keeps its value, a null member removes
+// it so it decodes to its zero value, and any other member replaces it; objects are patched
+// recursively while arrays are replaced whole. Members patched doesn't have are rejected, so
+// read-only fields such as the ID or owner can't be patched.
+func applyMergePatch(document interface{}, patch []byte, patched interface{}) error {
+	var patchValue interface{}
+	if err := decodeJSON(patch, &patchValue); err != nil {
+		return interfaces.NewValidationError("merge patch is not valid JSON")
+	}
+	if _, ok := patchValue.(map[string]interface{}); !ok {
+		return interfaces.NewValidationError("merge patch must be a JSON object")
+	}
+
+	current, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	var target interface{}
+	if err := decodeJSON(current, &target); err != nil {
+		return err
+	}
+
+	merged, err := json.Marshal(mergePatch(target, patchValue))
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(merged))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(patched); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return interfaces.NewValidationError(fmt.Sprintf("invalid value for %s", typeErr.Field))
+		}
+		if field := strings.TrimPrefix(err.Error(), "json: unknown field "); field != err.Error() {
+			return interfaces.NewValidationError(fmt.Sprintf("%s cannot be patched", field))
+		}
+		return interfaces.NewValidationError(err.Error())
+	}
+	return nil
+}
+
+// mergePatch is the MergePatch function of RFC 7396
+func mergePatch(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = mergePatch(targetObject[name], value)
+	}
+	return targetObject
+}
+
+// patchRemoves tells whether a merge patch sets the member to null
+func patchRemoves(patch []byte, member string) bool {
+	var patchObject map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &patchObject); err != nil {
+		return false
+	}
+	value, ok := patchObject[member]
+	return ok && string(bytes.TrimSpace(value)) == "null"
+}
+
+// decodeJSON decodes JSON keeping numbers as json.Number, so large integers survive a round trip
+func decodeJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.More() {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
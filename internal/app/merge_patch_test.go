@@ -0,0 +1,137 @@
+package app
+
+import (
+	"cookaholic/internal/interfaces"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// parseJSON decodes a JSON test fixture
+func parseJSON(t *testing.T, raw string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(raw), &v); err != nil {
+		t.Fatalf("invalid test JSON %s: %v", raw, err)
+	}
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		patch  string
+		want   string
+	}{
+		{
+			name:   "absent members keep their value",
+			target: `{"title":"Soup","time":30}`,
+			patch:  `{"time":45}`,
+			want:   `{"title":"Soup","time":45}`,
+		},
+		{
+			name:   "null removes a member",
+			target: `{"title":"Soup","description":"Hot"}`,
+			patch:  `{"description":null}`,
+			want:   `{"title":"Soup"}`,
+		},
+		{
+			name:   "null for a missing member is a no-op",
+			target: `{"title":"Soup"}`,
+			patch:  `{"description":null}`,
+			want:   `{"title":"Soup"}`,
+		},
+		{
+			name:   "objects merge recursively",
+			target: `{"nutrition":{"calories":300,"protein":12}}`,
+			patch:  `{"nutrition":{"protein":15,"fat":null}}`,
+			want:   `{"nutrition":{"calories":300,"protein":15}}`,
+		},
+		{
+			name:   "arrays are replaced whole",
+			target: `{"tags":["vegan","quick"]}`,
+			patch:  `{"tags":["spicy"]}`,
+			want:   `{"tags":["spicy"]}`,
+		},
+		{
+			name:   "an object replaces a scalar",
+			target: `{"nutrition":"unknown"}`,
+			patch:  `{"nutrition":{"calories":200}}`,
+			want:   `{"nutrition":{"calories":200}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergePatch(parseJSON(t, tt.target), parseJSON(t, tt.patch))
+			if want := parseJSON(t, tt.want); !reflect.DeepEqual(got, want) {
+				t.Errorf("mergePatch() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+	document := interfaces.UpdateRecipeInput{
+		Title:       "Soup",
+		Description: "Hot",
+		Time:        30,
+		ServingSize: 4,
+		Tags:        []string{"vegan", "quick"},
+	}
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    func(*interfaces.UpdateRecipeInput)
+		wantErr bool
+	}{
+		{
+			name:  "patched fields change and the rest stay",
+			patch: `{"title":"Stew","tags":["hearty"]}`,
+			want: func(r *interfaces.UpdateRecipeInput) {
+				r.Title = "Stew"
+				r.Tags = []string{"hearty"}
+			},
+		},
+		{
+			name:  "null clears a field",
+			patch: `{"description":null,"tags":null}`,
+			want: func(r *interfaces.UpdateRecipeInput) {
+				r.Description = ""
+				r.Tags = nil
+			},
+		},
+		{name: "unknown fields are rejected", patch: `{"user_id":"someone"}`, wantErr: true},
+		{name: "wrong types are rejected", patch: `{"time":"long"}`, wantErr: true},
+		{name: "trailing data is rejected", patch: `{"title":"Stew"} {}`, wantErr: true},
+		{name: "a patch must be an object", patch: `["title"]`, wantErr: true},
+		{name: "invalid JSON is rejected", patch: `{"title":`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var patched interfaces.UpdateRecipeInput
+			err := applyMergePatch(document, []byte(tt.patch), &patched)
+			if tt.wantErr {
+				var validationErr *interfaces.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("applyMergePatch() error = %v, want a ValidationError", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyMergePatch() error = %v", err)
+			}
+
+			want := document
+			want.Tags = append([]string(nil), document.Tags...)
+			tt.want(&want)
+			if !reflect.DeepEqual(patched, want) {
+				t.Errorf("applyMergePatch() = %+v, want %+v", patched, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,443 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// pantryExpiryWarning is how long before its expiry date an item counts as expiring soon
+	pantryExpiryWarning = 3 * 24 * time.Hour
+
+	defaultPantrySuggestions = 10
+	maxPantrySuggestions     = 50
+	// pantrySuggestionsPerEmail is how many recipes an expiry reminder suggests
+	pantrySuggestionsPerEmail = 5
+	// pantryCandidateRecipes caps how many recipes are scored for suggestions
+	pantryCandidateRecipes = 200
+)
+
+type pantryService struct {
+	pantryRepo    interfaces.PantryRepository
+	recipeRepo    interfaces.RecipeRepository
+	recipeService interfaces.RecipeService
+	userRepo      interfaces.UserRepository
+	emailService  interfaces.EmailService
+}
+
+// NewPantryService creates a new pantry service
+func NewPantryService(
+	pantryRepo interfaces.PantryRepository,
+	recipeRepo interfaces.RecipeRepository,
+	recipeService interfaces.RecipeService,
+	userRepo interfaces.UserRepository,
+	emailService interfaces.EmailService) interfaces.PantryService {
+	return &pantryService{
+		pantryRepo:    pantryRepo,
+		recipeRepo:    recipeRepo,
+		recipeService: recipeService,
+		userRepo:      userRepo,
+		emailService:  emailService,
+	}
+}
+
+// AddItem adds an item to the user's pantry
+func (s *pantryService) AddItem(ctx context.Context, input interfaces.CreatePantryItemInput) (*domain.PantryItem, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, interfaces.NewValidationError("item name is required")
+	}
+	if input.Amount < 0 {
+		return nil, interfaces.NewValidationError("amount cannot be negative")
+	}
+
+	item := &domain.PantryItem{
+		BaseModel: &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		},
+		UserID:    input.UserID,
+		Name:      name,
+		Amount:    input.Amount,
+		Unit:      normalizeUnit(input.Unit),
+		ExpiresAt: input.ExpiresAt,
+	}
+
+	if err := s.pantryRepo.Create(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem updates a pantry item owned by the user
+func (s *pantryService) UpdateItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, input interfaces.UpdatePantryItemInput) (*domain.PantryItem, error) {
+	item, err := s.ownedItem(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, interfaces.NewValidationError("item name is required")
+		}
+		item.Name = name
+	}
+	if input.Amount != nil {
+		if *input.Amount < 0 {
+			return nil, interfaces.NewValidationError("amount cannot be negative")
+		}
+		item.Amount = *input.Amount
+	}
+	if input.Unit != nil {
+		item.Unit = normalizeUnit(*input.Unit)
+	}
+	// A new expiry date deserves a new reminder
+	if input.ClearExpiry {
+		item.ExpiresAt = nil
+		item.ExpiryNotifiedAt = nil
+	} else if input.ExpiresAt != nil {
+		item.ExpiresAt = input.ExpiresAt
+		item.ExpiryNotifiedAt = nil
+	}
+	item.UpdatedAt = time.Now()
+
+	if err := s.pantryRepo.Update(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem deletes a pantry item owned by the user
+func (s *pantryService) DeleteItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.ownedItem(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.pantryRepo.Delete(ctx, id)
+}
+
+// GetItems gets the user's pantry, soonest to expire first
+func (s *pantryService) GetItems(ctx context.Context, userID uuid.UUID) ([]domain.PantryItem, error) {
+	return s.pantryRepo.GetByUserID(ctx, userID)
+}
+
+// SuggestRecipes suggests recipes that use the user's pantry items
+func (s *pantryService) SuggestRecipes(ctx context.Context, userID uuid.UUID, limit int) ([]domain.PantrySuggestion, error) {
+	if limit <= 0 {
+		limit = defaultPantrySuggestions
+	}
+	if limit > maxPantrySuggestions {
+		limit = maxPantrySuggestions
+	}
+
+	items, err := s.pantryRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.suggestForItems(ctx, items, limit, time.Now())
+}
+
+// suggestForItems scores recipes by how many expiring pantry items they use, then by how much
+// of the recipe the pantry covers
+func (s *pantryService) suggestForItems(ctx context.Context, items []domain.PantryItem, limit int, now time.Time) ([]domain.PantrySuggestion, error) {
+	suggestions := []domain.PantrySuggestion{}
+	if len(items) == 0 {
+		return suggestions, nil
+	}
+
+	names := make([]string, 0, len(items))
+	seen := make(map[string]bool)
+	for _, item := range items {
+		key := ingredientKey(item.Name)
+		if key != "" && !seen[key] {
+			seen[key] = true
+			names = append(names, key)
+		}
+	}
+
+	recipes, err := s.recipeRepo.FindRecipesByIngredientNames(ctx, names, pantryCandidateRecipes)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range recipes {
+		ingredients := collectRecipeIngredients(&recipes[i])
+		if len(ingredients) == 0 {
+			continue
+		}
+
+		var matched, expiring []string
+		covered := 0
+		for _, ingredient := range ingredients {
+			found := false
+			for _, item := range items {
+				if !pantryItemMatches(ingredient.Name, item.Name) {
+					continue
+				}
+				found = true
+				if !containsString(matched, item.Name) {
+					matched = append(matched, item.Name)
+				}
+				if expiresSoon(item, now) && !containsString(expiring, item.Name) {
+					expiring = append(expiring, item.Name)
+				}
+			}
+			if found {
+				covered++
+			}
+		}
+		// The database match is a substring search, so it can return recipes without a real match
+		if covered == 0 {
+			continue
+		}
+
+		suggestions = append(suggestions, domain.PantrySuggestion{
+			Recipe:        &recipes[i],
+			MatchedItems:  matched,
+			ExpiringItems: expiring,
+			Coverage:      roundAmount(float64(covered) / float64(len(ingredients))),
+		})
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		if len(a.ExpiringItems) != len(b.ExpiringItems) {
+			return len(a.ExpiringItems) > len(b.ExpiringItems)
+		}
+		if a.Coverage != b.Coverage {
+			return a.Coverage > b.Coverage
+		}
+		return len(a.MatchedItems) > len(b.MatchedItems)
+	})
+
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// pantryCookAttempts is how often cooking is retried when another request changes the pantry meanwhile
+const pantryCookAttempts = 3
+
+// CookRecipe deducts the ingredients of a recipe from the user's pantry. The deductions are planned
+// from the current pantry and applied in one transaction, which is retried if the pantry changed meanwhile.
+func (s *pantryService) CookRecipe(ctx context.Context, userID uuid.UUID, input interfaces.CookFromPantryInput) (*domain.PantryCookResult, error) {
+	var err error
+	for attempt := 0; attempt < pantryCookAttempts; attempt++ {
+		var result *domain.PantryCookResult
+		result, err = s.PlanCook(ctx, userID, input)
+		if err != nil {
+			return nil, err
+		}
+		err = s.pantryRepo.Deduct(ctx, userID, result.Deducted)
+		if err == nil {
+			return result, nil
+		}
+		if !errors.Is(err, interfaces.ErrPantryChanged) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// PlanCook works out what cooking a recipe takes from the user's pantry. Items expiring first are
+// used first; ingredients that are not in the pantry, or not in a convertible unit, are reported as missing.
+func (s *pantryService) PlanCook(ctx context.Context, userID uuid.UUID, input interfaces.CookFromPantryInput) (*domain.PantryCookResult, error) {
+	if input.Servings < 0 {
+		return nil, interfaces.NewValidationError("servings cannot be negative")
+	}
+
+	recipe, err := s.recipeService.GetRecipe(ctx, input.RecipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+
+	ingredients, err := expandScaledIngredients(ctx, s.recipeService, recipe, input.Servings)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.pantryRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.PantryCookResult{
+		Deducted: []domain.PantryDeduction{},
+		Missing:  []domain.Ingredient{},
+	}
+	used := make(map[uuid.UUID]float64)
+
+	for _, ingredient := range ingredients {
+		needed := ingredient.Amount
+		found := false
+		for i := range items {
+			item := &items[i]
+			if !pantryItemMatches(ingredient.Name, item.Name) {
+				continue
+			}
+			found = true
+			// Ingredients without an amount, such as "salt to taste", are used without deducting anything
+			if needed <= 0 {
+				break
+			}
+			if item.Amount <= 0 {
+				continue
+			}
+
+			neededInItemUnit, ok := convertAmount(needed, ingredient.Unit, item.Unit)
+			if !ok {
+				continue
+			}
+			take := neededInItemUnit
+			if take > item.Amount {
+				take = item.Amount
+			}
+			item.Amount = roundAmount(item.Amount - take)
+			used[item.ID] += take
+
+			takenInIngredientUnit, _ := convertAmount(take, item.Unit, ingredient.Unit)
+			needed -= takenInIngredientUnit
+			if needed <= 1e-9 {
+				needed = 0
+				break
+			}
+		}
+
+		if !found || needed > 0 {
+			result.Missing = append(result.Missing, domain.Ingredient{
+				Name:   ingredient.Name,
+				Amount: roundAmount(needed),
+				Unit:   ingredient.Unit,
+			})
+		}
+	}
+
+	for i := range items {
+		item := &items[i]
+		amount, ok := used[item.ID]
+		if !ok {
+			continue
+		}
+
+		if item.Amount < 0 {
+			item.Amount = 0
+		}
+
+		result.Deducted = append(result.Deducted, domain.PantryDeduction{
+			ItemID:    item.ID,
+			Name:      item.Name,
+			Amount:    roundAmount(amount),
+			Unit:      item.Unit,
+			Remaining: item.Amount,
+		})
+	}
+
+	return result, nil
+}
+
+// NotifyExpiringItems emails users about pantry items that expire soon. Each item is only
+// reported once; a failed email is retried on the next run.
+func (s *pantryService) NotifyExpiringItems(ctx context.Context) error {
+	now := time.Now()
+	expiring, err := s.pantryRepo.GetUnnotifiedExpiringBefore(ctx, now.Add(pantryExpiryWarning))
+	if err != nil {
+		return err
+	}
+
+	byUser := make(map[uuid.UUID][]domain.PantryItem)
+	var userIDs []uuid.UUID
+	for _, item := range expiring {
+		if _, ok := byUser[item.UserID]; !ok {
+			userIDs = append(userIDs, item.UserID)
+		}
+		byUser[item.UserID] = append(byUser[item.UserID], item)
+	}
+
+	for _, userID := range userIDs {
+		items := byUser[userID]
+		if err := s.notifyUser(ctx, userID, items, now); err != nil {
+			log.Printf("Error sending pantry expiry reminder to user %s: %v", userID, err)
+			continue
+		}
+
+		ids := make([]uuid.UUID, len(items))
+		for i, item := range items {
+			ids[i] = item.ID
+		}
+		if err := s.pantryRepo.MarkExpiryNotified(ctx, ids, now); err != nil {
+			log.Printf("Error marking pantry items of user %s as notified: %v", userID, err)
+		}
+	}
+	return nil
+}
+
+func (s *pantryService) notifyUser(ctx context.Context, userID uuid.UUID, items []domain.PantryItem, now time.Time) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return interfaces.ErrUserNotFound
+	}
+
+	// Only suggest recipes that use one of the items being reported
+	suggestions, err := s.suggestForItems(ctx, items, pantrySuggestionsPerEmail, now)
+	if err != nil {
+		return err
+	}
+
+	return s.emailService.SendPantryExpiryReminder(ctx, user.Email, items, suggestions)
+}
+
+func (s *pantryService) ownedItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.PantryItem, error) {
+	item, err := s.pantryRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("pantry item not found")
+		}
+		return nil, err
+	}
+	if item.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to access this pantry item")
+	}
+	return item, nil
+}
+
+// pantryItemMatches reports whether a pantry item can stand in for a recipe ingredient. The item's
+// words must appear in the ingredient name, so "chicken" matches "chicken breast" but not the reverse.
+func pantryItemMatches(ingredientName, itemName string) bool {
+	ingredient, item := ingredientKey(ingredientName), ingredientKey(itemName)
+	if ingredient == "" || item == "" {
+		return false
+	}
+	return strings.Contains(" "+ingredient+" ", " "+item+" ")
+}
+
+func expiresSoon(item domain.PantryItem, now time.Time) bool {
+	return item.ExpiresAt != nil && item.ExpiresAt.Before(now.Add(pantryExpiryWarning))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+const (
+	// maxPaprikaEntrySize limits a single recipe of a Paprika archive, photos included
+	maxPaprikaEntrySize = 20 << 20
+	// maxPaprikaExpandedSize limits what all entries of a Paprika archive decompress to together,
+	// so a small archive cannot expand into gigabytes
+	maxPaprikaExpandedSize = 200 << 20
+)
+
+var errPaprikaArchiveTooLarge = fmt.Errorf("the archive decompresses to more than %d MiB", maxPaprikaExpandedSize>>20)
+
+// paprikaRecipe is a recipe as exported by Paprika. The photos are left out, they are read with
+// paprikaPhotos when the recipe is imported.
+type paprikaRecipe struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Ingredients string   `json:"ingredients"` // one ingredient per line
+	Directions  string   `json:"directions"`  // one step per line
+	Notes       string   `json:"notes"`
+	Servings    string   `json:"servings"`
+	PrepTime    string   `json:"prep_time"`
+	CookTime    string   `json:"cook_time"`
+	TotalTime   string   `json:"total_time"`
+	Categories  []string `json:"categories"`
+	Source      string   `json:"source"`
+	SourceURL   string   `json:"source_url"`
+}
+
+// paprikaPhotos are the photos embedded in a Paprika recipe
+type paprikaPhotos struct {
+	PhotoData string         `json:"photo_data"` // base64 main photo
+	Photos    []paprikaPhoto `json:"photos"`
+}
+
+type paprikaPhoto struct {
+	Filename string `json:"filename"`
+	Data     string `json:"data"` // base64
+}
+
+// parsePaprikaArchive reads a .paprikarecipes export, a ZIP archive with a gzipped JSON
+// .paprikarecipe entry per recipe
+func parsePaprikaArchive(content []byte) ([]bulkImportRow, error) {
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, errors.New("the import file must be a .paprikarecipes archive")
+	}
+
+	rows := []bulkImportRow{}
+	archive := &paprikaArchive{}
+	for _, file := range reader.File {
+		if file.FileInfo().IsDir() || !strings.EqualFold(path.Ext(file.Name), ".paprikarecipe") {
+			continue
+		}
+		// One row more than allowed is enough for the import to report the archive as too large
+		if len(rows) > maxBulkImportRows {
+			break
+		}
+
+		var recipe paprikaRecipe
+		err := archive.readEntry(file, &recipe)
+		if errors.Is(err, errPaprikaArchiveTooLarge) {
+			return nil, err
+		}
+		if err != nil {
+			rows = append(rows, bulkImportRow{
+				recipe: interfaces.BulkRecipe{Title: strings.TrimSuffix(path.Base(file.Name), path.Ext(file.Name))},
+				err:    interfaces.NewValidationError(fmt.Sprintf("unreadable Paprika recipe: %s", err.Error())),
+			})
+			continue
+		}
+		row := paprikaToRow(&recipe)
+		row.photos = paprikaPhotoLoader(file)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// paprikaArchive reads the entries of a Paprika archive and counts what they decompress to
+type paprikaArchive struct {
+	expanded int64
+}
+
+// readEntry decompresses an entry and decodes its JSON into v
+func (a *paprikaArchive) readEntry(file *zip.File, v interface{}) error {
+	rc, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	content, err := a.read(rc)
+	if err != nil {
+		return err
+	}
+
+	// Entries are gzipped, but plain JSON is accepted too
+	if bytes.HasPrefix(content, []byte{0x1f, 0x8b}) {
+		gz, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+
+		if content, err = a.read(gz); err != nil {
+			return err
+		}
+	}
+
+	return json.Unmarshal(content, v)
+}
+
+// read reads at most one entry's worth of data, failing when the archive as a whole expands too much
+func (a *paprikaArchive) read(r io.Reader) ([]byte, error) {
+	limit := int64(maxPaprikaEntrySize)
+	if remaining := maxPaprikaExpandedSize - a.expanded; remaining < limit {
+		limit = remaining
+	}
+
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	a.expanded += int64(len(content))
+	if err != nil {
+		return nil, err
+	}
+	if a.expanded > maxPaprikaExpandedSize {
+		return nil, errPaprikaArchiveTooLarge
+	}
+	if len(content) > maxPaprikaEntrySize {
+		return nil, errors.New("file too large")
+	}
+	return content, nil
+}
+
+// paprikaPhotoLoader reads the photos of an archive entry again when its recipe is imported, so
+// only one recipe's photos are held in memory at a time
+func paprikaPhotoLoader(file *zip.File) func() [][]byte {
+	return func() [][]byte {
+		var recipe paprikaPhotos
+		if err := (&paprikaArchive{}).readEntry(file, &recipe); err != nil {
+			return nil
+		}
+
+		encoded := []string{recipe.PhotoData}
+		for _, photo := range recipe.Photos {
+			encoded = append(encoded, photo.Data)
+		}
+		var photos [][]byte
+		for _, photo := range encoded {
+			if photo == "" {
+				continue
+			}
+			if data, err := base64.StdEncoding.DecodeString(photo); err == nil && len(data) > 0 {
+				photos = append(photos, data)
+			}
+		}
+		return photos
+	}
+}
+
+// paprikaToRow maps a Paprika recipe onto an import row. Notes and the source are kept in the
+// description, and the Paprika categories become the recipe's category.
+func paprikaToRow(recipe *paprikaRecipe) bulkImportRow {
+	description := strings.TrimSpace(recipe.Description)
+	if notes := strings.TrimSpace(recipe.Notes); notes != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += notes
+	}
+	if source := firstNonEmpty(strings.TrimSpace(recipe.SourceURL), strings.TrimSpace(recipe.Source)); source != "" {
+		if description != "" {
+			description += "\n\n"
+		}
+		description += "Source: " + source
+	}
+
+	time := parseImportedDuration(recipe.TotalTime)
+	if time == 0 {
+		time = parseImportedDuration(recipe.PrepTime) + parseImportedDuration(recipe.CookTime)
+	}
+
+	row := bulkImportRow{
+		recipe: interfaces.BulkRecipe{
+			Title:       strings.TrimSpace(recipe.Name),
+			Description: description,
+			Time:        time,
+			ServingSize: parseYield(recipe.Servings),
+		},
+		categories: recipe.Categories,
+	}
+	for _, line := range csvLines(recipe.Ingredients) {
+		row.recipe.Ingredients = append(row.recipe.Ingredients, parseIngredientLine(line))
+	}
+	for _, line := range csvLines(recipe.Directions) {
+		row.recipe.Steps = append(row.recipe.Steps, domain.Step{Order: len(row.recipe.Steps) + 1, Content: line})
+	}
+	return row
+}
+
+// parseImportedDuration reads durations written by other recipe managers, either ISO 8601 such as
+// "PT1H30M" or text such as "1 hr 30 mins". Plain numbers are minutes.
+func parseImportedDuration(value string) int {
+	if minutes, err := parseISODuration(value); err == nil {
+		return minutes
+	}
+	return parseCooklangTime(value)
+}
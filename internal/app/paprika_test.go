@@ -0,0 +1,98 @@
+package app
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// paprikaArchiveOf builds a .paprikarecipes archive with one gzipped entry per recipe JSON
+func paprikaArchiveOf(t *testing.T, gzipped bool, recipes ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for i, recipe := range recipes {
+		w, err := archive.Create(strings.Repeat("r", i+1) + ".paprikarecipe")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !gzipped {
+			w.Write([]byte(recipe))
+			continue
+		}
+		gz := gzip.NewWriter(w)
+		gz.Write([]byte(recipe))
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParsePaprikaArchive(t *testing.T) {
+	photo := base64.StdEncoding.EncodeToString([]byte("jpeg bytes"))
+	content := paprikaArchiveOf(t, true,
+		`{"name":"Pancakes","ingredients":"200 g flour\n2 eggs","directions":"Whisk.\nFry.","servings":"4",
+		  "total_time":"25 mins","categories":["Breakfast"],"source_url":"https://example.com",
+		  "photo_data":"`+photo+`","photos":[{"filename":"b.jpg","data":"`+photo+`"}]}`,
+		`not json`,
+	)
+
+	rows, err := parsePaprikaArchive(content)
+	if err != nil {
+		t.Fatalf("parsePaprikaArchive() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+
+	row := rows[0]
+	if row.err != nil {
+		t.Fatalf("row error = %v", row.err)
+	}
+	recipe := row.recipe
+	if recipe.Title != "Pancakes" || recipe.Time != 25 || recipe.ServingSize != 4 || recipe.Description != "Source: https://example.com" {
+		t.Errorf("recipe = %+v", recipe)
+	}
+	if len(recipe.Ingredients) != 2 || len(recipe.Steps) != 2 || len(row.categories) != 1 {
+		t.Errorf("got %d ingredients, %d steps and categories %v", len(recipe.Ingredients), len(recipe.Steps), row.categories)
+	}
+	photos := row.photos()
+	if len(photos) != 2 || string(photos[0]) != "jpeg bytes" {
+		t.Errorf("photos = %q, want two decoded photos", photos)
+	}
+
+	if rows[1].err == nil {
+		t.Error("unreadable entry: want a row error")
+	}
+}
+
+func TestParsePaprikaArchiveLimitsExpansion(t *testing.T) {
+	// Each entry stays under the entry limit, but together they expand past the archive limit
+	entry := strings.Repeat(" ", maxPaprikaEntrySize-64) + `{"name":"Padding","directions":"Wait."}`
+	entries := make([]string, maxPaprikaExpandedSize/maxPaprikaEntrySize+1)
+	for i := range entries {
+		entries[i] = entry
+	}
+
+	_, err := parsePaprikaArchive(paprikaArchiveOf(t, false, entries...))
+	if !errors.Is(err, errPaprikaArchiveTooLarge) {
+		t.Fatalf("parsePaprikaArchive() error = %v, want errPaprikaArchiveTooLarge", err)
+	}
+
+	oversized := strings.Repeat(" ", maxPaprikaEntrySize+1) + `{}`
+	rows, err := parsePaprikaArchive(paprikaArchiveOf(t, true, oversized))
+	if err != nil {
+		t.Fatalf("parsePaprikaArchive() error = %v", err)
+	}
+	if len(rows) != 1 || rows[0].err == nil {
+		t.Errorf("oversized entry: want a row error, got %+v", rows)
+	}
+}
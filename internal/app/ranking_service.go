@@ -0,0 +1,174 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Weights of each kind of engagement in the trending score
+const (
+	trendingRatingWeight = 3.0
+	trendingSaveWeight   = 2.0
+	trendingCookWeight   = 4.0
+	trendingViewWeight   = 0.25
+)
+
+const (
+	// bayesianPriorVotes is how many votes of the average rating every recipe starts with, so a
+	// few 5-star votes do not put a recipe above one with many good ratings
+	bayesianPriorVotes = 5
+	defaultRankingSize = 20
+	maxRankingSize     = 100
+)
+
+// rankingWindow is the period a ranking covers and how fast engagement within it loses weight
+type rankingWindow struct {
+	period   time.Duration // 0 covers all time
+	halfLife time.Duration
+}
+
+var rankingWindows = map[string]rankingWindow{
+	domain.RankingWindowDaily:   {period: 24 * time.Hour, halfLife: 6 * time.Hour},
+	domain.RankingWindowWeekly:  {period: 7 * 24 * time.Hour, halfLife: 2 * 24 * time.Hour},
+	domain.RankingWindowAllTime: {period: 0, halfLife: 30 * 24 * time.Hour},
+}
+
+type rankingService struct {
+	recipeScoreRepo interfaces.RecipeScoreRepository
+	recipeRepo      interfaces.RecipeRepository
+}
+
+// NewRankingService creates a new trending and top rated ranking service
+func NewRankingService(recipeScoreRepo interfaces.RecipeScoreRepository, recipeRepo interfaces.RecipeRepository) interfaces.RankingService {
+	return &rankingService{
+		recipeScoreRepo: recipeScoreRepo,
+		recipeRepo:      recipeRepo,
+	}
+}
+
+// GetTrendingRecipes gets the recipes with the most recent engagement in a ranking window
+func (s *rankingService) GetTrendingRecipes(ctx context.Context, window string, offset, limit int) ([]domain.RankedRecipe, error) {
+	offset, limit, err := validateRankingPage(window, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.recipeScoreRepo.GetTrending(ctx, window, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.rankedRecipes(ctx, scores, offset, func(score domain.RecipeScore) float64 {
+		return score.TrendingScore
+	})
+}
+
+// GetTopRecipes gets the recipes with the highest Bayesian average rating in a ranking window
+func (s *rankingService) GetTopRecipes(ctx context.Context, window string, offset, limit int) ([]domain.RankedRecipe, error) {
+	offset, limit, err := validateRankingPage(window, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, err := s.recipeScoreRepo.GetTop(ctx, window, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return s.rankedRecipes(ctx, scores, offset, func(score domain.RecipeScore) float64 {
+		return score.TopScore
+	})
+}
+
+func validateRankingPage(window string, offset, limit int) (int, int, error) {
+	if _, ok := rankingWindows[window]; !ok {
+		return 0, 0, interfaces.NewValidationError("window must be one of daily, weekly or all_time")
+	}
+	if offset < 0 {
+		return 0, 0, interfaces.NewValidationError("offset cannot be negative")
+	}
+	if limit <= 0 {
+		limit = defaultRankingSize
+	}
+	if limit > maxRankingSize {
+		limit = maxRankingSize
+	}
+	return offset, limit, nil
+}
+
+// rankedRecipes loads the recipes of a ranking. Recipes deleted since the scores were computed are skipped.
+func (s *rankingService) rankedRecipes(ctx context.Context, scores []domain.RecipeScore, offset int, scoreOf func(domain.RecipeScore) float64) ([]domain.RankedRecipe, error) {
+	ranked := make([]domain.RankedRecipe, 0, len(scores))
+	for i, score := range scores {
+		recipe, err := s.recipeRepo.GetRecipe(ctx, score.RecipeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		ranked = append(ranked, domain.RankedRecipe{
+			Recipe: recipe,
+			Rank:   offset + i + 1,
+			Score:  roundAmount(scoreOf(score)),
+		})
+	}
+	return ranked, nil
+}
+
+// RecomputeScores recomputes the trending and top rated scores of every ranking window
+func (s *rankingService) RecomputeScores(ctx context.Context) error {
+	now := time.Now()
+	for name, window := range rankingWindows {
+		var since time.Time
+		if window.period > 0 {
+			since = now.Add(-window.period)
+		}
+
+		engagement, err := s.recipeScoreRepo.GetRecipeEngagement(ctx, since, now, window.halfLife)
+		if err != nil {
+			return err
+		}
+		if err := s.recipeScoreRepo.ReplaceScores(ctx, name, rankingScores(name, engagement, now)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rankingScores turns the engagement within a window into scores. The top score is the Bayesian
+// average (C*m + sum) / (C + n), which pulls recipes with few ratings towards the mean rating m.
+func rankingScores(window string, engagement []domain.RecipeEngagement, now time.Time) []domain.RecipeScore {
+	ratingCount, ratingSum := 0, 0
+	for _, recipe := range engagement {
+		ratingCount += recipe.RatingCount
+		ratingSum += recipe.RatingSum
+	}
+	meanRating := 0.0
+	if ratingCount > 0 {
+		meanRating = float64(ratingSum) / float64(ratingCount)
+	}
+
+	scores := make([]domain.RecipeScore, len(engagement))
+	for i, recipe := range engagement {
+		score := domain.RecipeScore{
+			RecipeID: recipe.RecipeID,
+			Window:   window,
+			TrendingScore: trendingRatingWeight*recipe.DecayedRating +
+				trendingSaveWeight*recipe.DecayedSaves +
+				trendingCookWeight*recipe.DecayedCooks +
+				trendingViewWeight*recipe.DecayedViews,
+			RatingCount: recipe.RatingCount,
+			ComputedAt:  now,
+		}
+		if recipe.RatingCount > 0 {
+			score.TopScore = (bayesianPriorVotes*meanRating + float64(recipe.RatingSum)) /
+				float64(bayesianPriorVotes+recipe.RatingCount)
+		}
+		scores[i] = score
+	}
+	return scores
+}
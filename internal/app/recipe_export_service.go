@@ -0,0 +1,409 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type recipeExportService struct {
+	recipeRepo    interfaces.RecipeRepository
+	recipeService interfaces.RecipeService
+	userRepo      interfaces.UserRepository
+	categoryRepo  interfaces.CategoryRepository
+}
+
+// NewRecipeExportService creates a new recipe export service
+func NewRecipeExportService(
+	recipeRepo interfaces.RecipeRepository,
+	recipeService interfaces.RecipeService,
+	userRepo interfaces.UserRepository,
+	categoryRepo interfaces.CategoryRepository) interfaces.RecipeExportService {
+	return &recipeExportService{
+		recipeRepo:    recipeRepo,
+		recipeService: recipeService,
+		userRepo:      userRepo,
+		categoryRepo:  categoryRepo,
+	}
+}
+
+// exportContext is the recipe together with the related data shown in exported documents
+type exportContext struct {
+	Recipe       *domain.Recipe
+	AuthorName   string
+	CategoryName string
+	Sections     []exportSection
+}
+
+// exportSection is a recipe section with any referenced sub-recipe resolved and scaled
+type exportSection struct {
+	Name        string
+	Ingredients domain.Ingredients
+	Steps       domain.Steps
+	SourceTitle string // title of the referenced sub-recipe, if any
+}
+
+// ExportRecipe renders the recipe with the given ID in the requested format
+func (s *recipeExportService) ExportRecipe(ctx context.Context, id uuid.UUID, format string) (*interfaces.RecipeExport, error) {
+	recipe, err := s.recipeRepo.GetRecipe(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.buildContext(ctx, recipe)
+	if err != nil {
+		return nil, err
+	}
+	filename := slugify(recipe.Title)
+
+	switch format {
+	case interfaces.ExportFormatJSONLD:
+		body, err := renderJSONLD(data)
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.RecipeExport{ContentType: "application/ld+json", Filename: filename + ".jsonld", Body: body}, nil
+	case interfaces.ExportFormatMarkdown:
+		return &interfaces.RecipeExport{ContentType: "text/markdown; charset=utf-8", Filename: filename + ".md", Body: renderMarkdown(data)}, nil
+	case interfaces.ExportFormatHTML:
+		body, err := renderPrintableHTML(data)
+		if err != nil {
+			return nil, err
+		}
+		return &interfaces.RecipeExport{ContentType: "text/html; charset=utf-8", Filename: filename + ".html", Body: body}, nil
+	case interfaces.ExportFormatCooklang:
+		return &interfaces.RecipeExport{ContentType: "text/plain; charset=utf-8", Filename: filename + ".cook", Body: []byte(serializeCooklang(recipe))}, nil
+	default:
+		return nil, interfaces.ErrUnsupportedFormat
+	}
+}
+
+// buildContext looks up the author and category names and expands the sub-recipes; missing author
+// and category records are not fatal for an export
+func (s *recipeExportService) buildContext(ctx context.Context, recipe *domain.Recipe) (*exportContext, error) {
+	data := &exportContext{Recipe: recipe}
+
+	if user, err := s.userRepo.FindByID(ctx, recipe.UserID); err == nil && user != nil {
+		data.AuthorName = user.FullName
+		if data.AuthorName == "" {
+			data.AuthorName = user.Username
+		}
+	}
+
+	if category, err := s.categoryRepo.Get(ctx, recipe.CategoryID); err == nil && category != nil {
+		data.CategoryName = category.Name
+	}
+
+	if err := s.recipeService.ExpandSections(ctx, recipe); err != nil {
+		return nil, err
+	}
+	data.Sections = exportSections(recipe.Sections, "")
+
+	return data, nil
+}
+
+// exportSections flattens the sections and the sections of expanded sub-recipes, whose amounts
+// ExpandSections has already scaled. Nested section names are prefixed with their parent's name.
+func exportSections(sections domain.Sections, prefix string) []exportSection {
+	var exported []exportSection
+	for _, section := range sections {
+		name := prefix + section.Name
+		item := exportSection{Name: name, Ingredients: section.Ingredients, Steps: section.Steps}
+		if section.Recipe != nil {
+			item.SourceTitle = section.Recipe.Title
+			item.Ingredients = section.Recipe.Ingredients
+			item.Steps = section.Recipe.Steps
+		}
+		exported = append(exported, item)
+		if section.Recipe != nil {
+			exported = append(exported, exportSections(section.Recipe.Sections, name+" / ")...)
+		}
+	}
+	return exported
+}
+
+func renderJSONLD(data *exportContext) ([]byte, error) {
+	recipe := data.Recipe
+
+	doc := map[string]interface{}{
+		"@context":    "https://schema.org",
+		"@type":       "Recipe",
+		"name":        recipe.Title,
+		"description": recipe.Description,
+		"totalTime":   fmt.Sprintf("PT%dM", recipe.Time),
+		"recipeYield": fmt.Sprintf("%d", recipe.ServingSize),
+	}
+
+	if recipe.BaseModel != nil {
+		doc["identifier"] = recipe.ID.String()
+		doc["datePublished"] = recipe.CreatedAt.Format(time.RFC3339)
+		doc["dateModified"] = recipe.UpdatedAt.Format(time.RFC3339)
+	}
+
+	if data.AuthorName != "" {
+		doc["author"] = map[string]interface{}{"@type": "Person", "name": data.AuthorName}
+	}
+	if data.CategoryName != "" {
+		doc["recipeCategory"] = data.CategoryName
+	}
+	if len(recipe.Tags) > 0 {
+		doc["keywords"] = strings.Join(recipe.Tags, ", ")
+	}
+
+	if len(recipe.Images) > 0 {
+		images := make([]interface{}, 0, len(recipe.Images))
+		for _, image := range recipe.Images {
+			images = append(images, map[string]interface{}{
+				"@type":  "ImageObject",
+				"url":    image.URL,
+				"width":  image.Width,
+				"height": image.Height,
+			})
+		}
+		doc["image"] = images
+	}
+
+	ingredients := make([]string, 0, len(recipe.Ingredients))
+	for _, ingredient := range recipe.Ingredients {
+		ingredients = append(ingredients, formatIngredientLine(ingredient))
+	}
+	for _, section := range data.Sections {
+		for _, ingredient := range section.Ingredients {
+			ingredients = append(ingredients, formatIngredientLine(ingredient))
+		}
+	}
+	doc["recipeIngredient"] = ingredients
+
+	steps := howToSteps(recipe.Steps)
+	// Sections become HowToSection entries after the top-level steps
+	for _, section := range data.Sections {
+		steps = append(steps, map[string]interface{}{
+			"@type":           "HowToSection",
+			"name":            section.Name,
+			"itemListElement": howToSteps(section.Steps),
+		})
+	}
+	doc["recipeInstructions"] = steps
+
+	if n := recipe.Nutrition; n != nil {
+		doc["nutrition"] = map[string]interface{}{
+			"@type":               "NutritionInformation",
+			"servingSize":         "1 serving",
+			"calories":            formatAmount(n.Calories) + " kcal",
+			"proteinContent":      formatAmount(n.Protein) + " g",
+			"carbohydrateContent": formatAmount(n.Carbohydrates) + " g",
+			"fatContent":          formatAmount(n.Fat) + " g",
+			"fiberContent":        formatAmount(n.Fiber) + " g",
+			"sugarContent":        formatAmount(n.Sugar) + " g",
+			"sodiumContent":       formatAmount(n.Sodium) + " mg",
+		}
+	}
+
+	if recipe.RatingCount > 0 {
+		doc["aggregateRating"] = map[string]interface{}{
+			"@type":       "AggregateRating",
+			"ratingValue": recipe.AvgRating,
+			"ratingCount": recipe.RatingCount,
+			"bestRating":  5,
+			"worstRating": 1,
+		}
+	}
+
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func howToSteps(steps domain.Steps) []interface{} {
+	result := make([]interface{}, 0, len(steps))
+	for i, step := range steps {
+		howToStep := map[string]interface{}{
+			"@type":    "HowToStep",
+			"position": i + 1,
+			"text":     step.Content,
+		}
+		if step.Duration > 0 {
+			howToStep["timeRequired"] = fmt.Sprintf("PT%dS", step.Duration)
+		}
+		if len(step.Images) > 0 {
+			urls := make([]string, 0, len(step.Images))
+			for _, image := range step.Images {
+				urls = append(urls, image.URL)
+			}
+			howToStep["image"] = urls
+		}
+		result = append(result, howToStep)
+	}
+	return result
+}
+
+func renderMarkdown(data *exportContext) []byte {
+	recipe := data.Recipe
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "# %s\n\n", recipe.Title)
+	if recipe.Description != "" {
+		fmt.Fprintf(&buf, "%s\n\n", recipe.Description)
+	}
+
+	for _, image := range recipe.Images {
+		fmt.Fprintf(&buf, "![%s](%s)\n\n", recipe.Title, image.URL)
+	}
+
+	if data.AuthorName != "" {
+		fmt.Fprintf(&buf, "- **Author:** %s\n", data.AuthorName)
+	}
+	if data.CategoryName != "" {
+		fmt.Fprintf(&buf, "- **Category:** %s\n", data.CategoryName)
+	}
+	fmt.Fprintf(&buf, "- **Time:** %d minutes\n", recipe.Time)
+	fmt.Fprintf(&buf, "- **Servings:** %d\n", recipe.ServingSize)
+	if recipe.RatingCount > 0 {
+		fmt.Fprintf(&buf, "- **Rating:** %.1f/5 (%d ratings)\n", recipe.AvgRating, recipe.RatingCount)
+	}
+
+	buf.WriteString("\n## Ingredients\n\n")
+	for _, ingredient := range recipe.Ingredients {
+		fmt.Fprintf(&buf, "- %s\n", formatIngredientLine(ingredient))
+	}
+
+	buf.WriteString("\n## Steps\n\n")
+	writeMarkdownSteps(&buf, recipe.Steps)
+
+	for _, section := range data.Sections {
+		fmt.Fprintf(&buf, "\n## %s\n\n", section.Name)
+		if section.SourceTitle != "" {
+			fmt.Fprintf(&buf, "_From %s_\n\n", section.SourceTitle)
+		}
+		for _, ingredient := range section.Ingredients {
+			fmt.Fprintf(&buf, "- %s\n", formatIngredientLine(ingredient))
+		}
+		if len(section.Ingredients) > 0 && len(section.Steps) > 0 {
+			buf.WriteString("\n")
+		}
+		writeMarkdownSteps(&buf, section.Steps)
+	}
+
+	return buf.Bytes()
+}
+
+func writeMarkdownSteps(buf *bytes.Buffer, steps domain.Steps) {
+	for i, step := range steps {
+		fmt.Fprintf(buf, "%d. %s", i+1, step.Content)
+		if details := stepDetails(step); details != "" {
+			fmt.Fprintf(buf, " _(%s)_", details)
+		}
+		buf.WriteString("\n")
+		for _, image := range step.Images {
+			fmt.Fprintf(buf, "   ![Step %d](%s)\n", i+1, image.URL)
+		}
+	}
+}
+
+var printableTemplate = template.Must(template.New("recipe").Funcs(template.FuncMap{
+	"ingredient": formatIngredientLine,
+	"details":    stepDetails,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Recipe.Title}}</title>
+<style>
+  body { font-family: Georgia, serif; max-width: 48rem; margin: 2rem auto; color: #222; line-height: 1.5; }
+  h1 { margin-bottom: 0.25rem; }
+  .meta { color: #555; margin-bottom: 1.5rem; }
+  .meta span { margin-right: 1.5rem; }
+  .images img { max-width: 100%; max-height: 20rem; margin-bottom: 1rem; }
+  .ingredients li, .steps li { margin-bottom: 0.4rem; }
+  @media print {
+    body { margin: 0; font-size: 11pt; }
+    .images img { max-height: 8cm; }
+    h2 { page-break-after: avoid; }
+    li { page-break-inside: avoid; }
+  }
+</style>
+</head>
+<body>
+<article>
+  <h1>{{.Recipe.Title}}</h1>
+  <div class="meta">
+    {{if .AuthorName}}<span>By {{.AuthorName}}</span>{{end}}
+    {{if .CategoryName}}<span>{{.CategoryName}}</span>{{end}}
+    <span>{{.Recipe.Time}} minutes</span>
+    <span>Serves {{.Recipe.ServingSize}}</span>
+  </div>
+  {{if .Recipe.Images}}<div class="images">{{range .Recipe.Images}}<img src="{{.URL}}" alt="{{$.Recipe.Title}}">{{end}}</div>{{end}}
+  {{if .Recipe.Description}}<p>{{.Recipe.Description}}</p>{{end}}
+  <h2>Ingredients</h2>
+  <ul class="ingredients">
+    {{range .Recipe.Ingredients}}<li>{{ingredient .}}</li>
+    {{end}}
+  </ul>
+  <h2>Steps</h2>
+  <ol class="steps">
+    {{range .Recipe.Steps}}<li>{{.Content}}{{with details .}} <em>({{.}})</em>{{end}}{{range .Images}}<div class="images"><img src="{{.URL}}" alt="{{$.Recipe.Title}}"></div>{{end}}</li>
+    {{end}}
+  </ol>
+  {{range .Sections}}<section>
+    <h2>{{.Name}}</h2>
+    {{if .SourceTitle}}<p class="meta">From {{.SourceTitle}}</p>{{end}}
+    {{if .Ingredients}}<ul class="ingredients">
+      {{range .Ingredients}}<li>{{ingredient .}}</li>
+      {{end}}
+    </ul>{{end}}
+    {{if .Steps}}<ol class="steps">
+      {{range .Steps}}<li>{{.Content}}{{with details .}} <em>({{.}})</em>{{end}}{{range .Images}}<div class="images"><img src="{{.URL}}" alt="{{$.Recipe.Title}}"></div>{{end}}</li>
+      {{end}}
+    </ol>{{end}}
+  </section>
+  {{end}}
+</article>
+</body>
+</html>
+`))
+
+func renderPrintableHTML(data *exportContext) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := printableTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// stepDetails summarizes the timer and temperature of a step, e.g. "5 min, 180 °C"
+func stepDetails(step domain.Step) string {
+	var details []string
+	if step.Duration > 0 {
+		minutes, seconds := step.Duration/60, step.Duration%60
+		switch {
+		case minutes > 0 && seconds > 0:
+			details = append(details, fmt.Sprintf("%d min %d s", minutes, seconds))
+		case minutes > 0:
+			details = append(details, fmt.Sprintf("%d min", minutes))
+		default:
+			details = append(details, fmt.Sprintf("%d s", seconds))
+		}
+	}
+	if step.Temperature != nil {
+		details = append(details, fmt.Sprintf("%s °%s", formatAmount(step.Temperature.Value), step.Temperature.Unit))
+	}
+	return strings.Join(details, ", ")
+}
+
+var nonSlugRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify turns a recipe title into a file name friendly string
+func slugify(title string) string {
+	slug := strings.Trim(nonSlugRegexp.ReplaceAllString(strings.ToLower(title), "-"), "-")
+	if slug == "" {
+		return "recipe"
+	}
+	return slug
+}
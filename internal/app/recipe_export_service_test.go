@@ -0,0 +1,189 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"strings"
+	"testing"
+)
+
+func exportTestRecipe() *domain.Recipe {
+	return &domain.Recipe{
+		Title:       "Lasagne",
+		Description: "Layers & layers",
+		Time:        90,
+		ServingSize: 6,
+		Tags:        []string{"pasta", "oven"},
+		Ingredients: []domain.Ingredient{
+			{Name: "lasagne sheets", Amount: 250, Unit: "g"},
+			{Name: "parmesan", Amount: 50, Unit: "g", Note: "grated"},
+			{Name: "salt"},
+		},
+		Steps: []domain.Step{
+			{Order: 1, Content: "Layer sheets and sauce.", Duration: 300},
+			{Order: 2, Content: "Bake until golden.", Duration: 2700, Temperature: &domain.Temperature{Value: 180, Unit: "C"}},
+		},
+	}
+}
+
+func exportTestContext() *exportContext {
+	return &exportContext{
+		Recipe:       exportTestRecipe(),
+		AuthorName:   "Ada",
+		CategoryName: "Mains",
+		Sections: []exportSection{{
+			Name:        "Ragù",
+			SourceTitle: "Ragù alla bolognese",
+			Ingredients: domain.Ingredients{{Name: "minced beef", Amount: 500, Unit: "g"}},
+			Steps:       domain.Steps{{Order: 1, Content: "Simmer the ragù."}},
+		}},
+	}
+}
+
+// TestJSONLDRoundTrip exports a recipe as JSON-LD and imports it again with the schema.org importer
+func TestJSONLDRoundTrip(t *testing.T) {
+	body, err := renderJSONLD(exportTestContext())
+	if err != nil {
+		t.Fatalf("renderJSONLD() error = %v", err)
+	}
+	page := `<html><head><script type="application/ld+json">` + string(body) + `</script></head></html>`
+
+	preview, err := NewRecipeImportService(stubFetcher{}).PreviewFromHTML(context.Background(), []byte(page), "")
+	if err != nil {
+		t.Fatalf("PreviewFromHTML() error = %v", err)
+	}
+
+	recipe := preview.Recipe
+	if recipe.Title != "Lasagne" || recipe.Description != "Layers & layers" {
+		t.Errorf("title/description = %q/%q", recipe.Title, recipe.Description)
+	}
+	if recipe.Time != 90 || recipe.ServingSize != 6 {
+		t.Errorf("time/servings = %d/%d, want 90/6", recipe.Time, recipe.ServingSize)
+	}
+
+	wantIngredients := []domain.Ingredient{
+		{Name: "lasagne sheets", Amount: 250, Unit: "g"},
+		{Name: "parmesan", Amount: 50, Unit: "g", Note: "grated"},
+		{Name: "salt"},
+		{Name: "minced beef", Amount: 500, Unit: "g"},
+	}
+	if len(recipe.Ingredients) != len(wantIngredients) {
+		t.Fatalf("Ingredients = %+v, want %+v", recipe.Ingredients, wantIngredients)
+	}
+	for i, want := range wantIngredients {
+		got := recipe.Ingredients[i]
+		if got.Name != want.Name || got.Amount != want.Amount || got.Unit != want.Unit || got.Note != want.Note {
+			t.Errorf("ingredient %d = %+v, want %+v", i, got, want)
+		}
+	}
+
+	wantSteps := []string{"Layer sheets and sauce.", "Bake until golden.", "Simmer the ragù."}
+	if len(recipe.Steps) != len(wantSteps) {
+		t.Fatalf("Steps = %+v, want %v", recipe.Steps, wantSteps)
+	}
+	for i, want := range wantSteps {
+		if recipe.Steps[i].Content != want {
+			t.Errorf("step %d = %q, want %q", i+1, recipe.Steps[i].Content, want)
+		}
+	}
+}
+
+func TestRenderTextFormats(t *testing.T) {
+	html, err := renderPrintableHTML(exportTestContext())
+	if err != nil {
+		t.Fatalf("renderPrintableHTML() error = %v", err)
+	}
+
+	tests := []struct {
+		format  string
+		body    string
+		want    []string
+		notWant []string
+	}{
+		{
+			format: "markdown",
+			body:   string(renderMarkdown(exportTestContext())),
+			want: []string{
+				"# Lasagne\n",
+				"- **Author:** Ada\n",
+				"- **Category:** Mains\n",
+				"- **Time:** 90 minutes\n",
+				"- 50 g parmesan, grated\n",
+				"2. Bake until golden. _(45 min, 180 °C)_\n",
+				"## Ragù\n\n_From Ragù alla bolognese_\n\n- 500 g minced beef\n\n1. Simmer the ragù.\n",
+			},
+		},
+		{
+			format: "html",
+			body:   string(html),
+			want: []string{
+				"<title>Lasagne</title>",
+				"<p>Layers &amp; layers</p>",
+				"<li>250 g lasagne sheets</li>",
+				"<li>Layer sheets and sauce. <em>(5 min)</em>",
+				"<h2>Ragù</h2>",
+			},
+			notWant: []string{"Layers & layers"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			for _, want := range tt.want {
+				if !strings.Contains(tt.body, want) {
+					t.Errorf("output is missing %q:\n%s", want, tt.body)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(tt.body, notWant) {
+					t.Errorf("output contains %q", notWant)
+				}
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := map[string]string{
+		"Spaghetti Carbonara": "spaghetti-carbonara",
+		"  Mom's #1 Pie!  ":   "mom-s-1-pie",
+		"Crème brûlée":        "cr-me-br-l-e",
+		"":                    "recipe",
+		"!!!":                 "recipe",
+	}
+	for title, want := range tests {
+		if got := slugify(title); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", title, got, want)
+		}
+	}
+}
+
+func TestExportSectionsFlattensSubRecipes(t *testing.T) {
+	sauce := &domain.Recipe{
+		Title:       "Tomato sauce",
+		Ingredients: domain.Ingredients{{Name: "tomatoes", Amount: 800, Unit: "g"}},
+		Sections: domain.Sections{{
+			Name:   "Stock",
+			Recipe: &domain.Recipe{Title: "Vegetable stock", Ingredients: domain.Ingredients{{Name: "water", Amount: 1, Unit: "l"}}},
+		}},
+	}
+	sections := domain.Sections{
+		{Name: "Dough", Ingredients: domain.Ingredients{{Name: "flour", Amount: 500, Unit: "g"}}},
+		{Name: "Sauce", Recipe: sauce},
+	}
+
+	got := exportSections(sections, "")
+	want := []struct{ name, source, ingredient string }{
+		{"Dough", "", "flour"},
+		{"Sauce", "Tomato sauce", "tomatoes"},
+		{"Sauce / Stock", "Vegetable stock", "water"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d sections, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		if got[i].Name != w.name || got[i].SourceTitle != w.source || len(got[i].Ingredients) != 1 || got[i].Ingredients[0].Name != w.ingredient {
+			t.Errorf("section %d = %+v, want %s from %q with %s", i, got[i], w.name, w.source, w.ingredient)
+		}
+	}
+}
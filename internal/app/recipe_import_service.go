@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"fmt"
+	"path"
+	"strings"
+)
+
+type recipeImportService struct {
+	fetcher interfaces.PageFetcher
+}
+
+// NewRecipeImportService creates a new recipe import service using the given page fetcher
+func NewRecipeImportService(fetcher interfaces.PageFetcher) interfaces.RecipeImportService {
+	return &recipeImportService{
+		fetcher: fetcher,
+	}
+}
+
+// PreviewFromURL fetches a page and extracts the schema.org recipe it contains
+func (s *recipeImportService) PreviewFromURL(ctx context.Context, input interfaces.ImportRecipeInput) (*interfaces.RecipeImportPreview, error) {
+	page, err := s.fetcher.Fetch(ctx, input.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+
+	return s.PreviewFromHTML(ctx, page, input.URL)
+}
+
+// PreviewFromHTML extracts the schema.org recipe from an already fetched page
+func (s *recipeImportService) PreviewFromHTML(ctx context.Context, page []byte, sourceURL string) (*interfaces.RecipeImportPreview, error) {
+	extracted, err := extractSchemaRecipe(page)
+	if err != nil {
+		return nil, err
+	}
+	if extracted == nil {
+		return nil, interfaces.ErrNoRecipeFound
+	}
+
+	preview := &interfaces.RecipeImportPreview{SourceURL: sourceURL}
+	input := &preview.Recipe
+
+	input.Title = extracted.Name
+	if input.Title == "" {
+		preview.Warnings = append(preview.Warnings, "recipe has no title")
+	}
+
+	input.Description = extracted.Description
+	if sourceURL != "" {
+		if input.Description != "" {
+			input.Description += "\n\n"
+		}
+		input.Description += "Source: " + sourceURL
+	}
+
+	input.Time = s.cookingTime(extracted, preview)
+
+	input.ServingSize = parseYield(extracted.Yield)
+	if input.ServingSize == 0 {
+		preview.Warnings = append(preview.Warnings, "could not determine the number of servings")
+	}
+
+	for _, url := range extracted.Images {
+		input.Images = append(input.Images, common.Image{
+			URL:       url,
+			Extension: strings.TrimPrefix(strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0])), "."),
+		})
+	}
+
+	input.Ingredients = make([]domain.Ingredient, 0, len(extracted.Ingredients))
+	for _, line := range extracted.Ingredients {
+		input.Ingredients = append(input.Ingredients, parseIngredientLine(line))
+	}
+	if len(input.Ingredients) == 0 {
+		preview.Warnings = append(preview.Warnings, "recipe has no ingredients")
+	}
+
+	input.Steps = make([]domain.Step, 0, len(extracted.Instructions))
+	for i, text := range extracted.Instructions {
+		input.Steps = append(input.Steps, domain.Step{Order: i + 1, Content: text})
+	}
+	if len(input.Steps) == 0 {
+		preview.Warnings = append(preview.Warnings, "recipe has no instructions")
+	}
+
+	preview.Warnings = append(preview.Warnings, "choose a category before saving the recipe")
+
+	return preview, nil
+}
+
+// cookingTime prefers the total time and falls back to the sum of preparation and cooking time
+func (s *recipeImportService) cookingTime(extracted *schemaRecipe, preview *interfaces.RecipeImportPreview) int {
+	if extracted.TotalTime != "" {
+		if minutes, err := parseISODuration(extracted.TotalTime); err == nil && minutes > 0 {
+			return minutes
+		}
+	}
+
+	total := 0
+	for _, value := range []string{extracted.PrepTime, extracted.CookTime} {
+		if value == "" {
+			continue
+		}
+		if minutes, err := parseISODuration(value); err == nil {
+			total += minutes
+		}
+	}
+
+	if total == 0 {
+		preview.Warnings = append(preview.Warnings, "could not determine the cooking time")
+	}
+	return total
+}
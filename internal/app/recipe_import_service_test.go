@@ -0,0 +1,89 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"testing"
+)
+
+// stubFetcher serves fixed pages by URL
+type stubFetcher map[string]string
+
+func (f stubFetcher) Fetch(ctx context.Context, url string) ([]byte, error) {
+	page, ok := f[url]
+	if !ok {
+		return nil, errors.New("page not found")
+	}
+	return []byte(page), nil
+}
+
+const jsonLDPage = `<html><head>
+<script type="application/ld+json">
+{"@context": "https://schema.org", "@graph": [
+  {"@type": "WebPage", "name": "Pancakes | Example"},
+  {"@type": "Recipe",
+   "name": "Pancakes",
+   "description": "Fluffy pancakes.",
+   "image": ["https://example.com/pancakes.jpg?w=800"],
+   "recipeYield": "4 servings",
+   "prepTime": "PT10M",
+   "cookTime": "PT15M",
+   "recipeIngredient": ["200 g flour", "2 eggs", "300 ml milk"],
+   "recipeInstructions": [
+     {"@type": "HowToStep", "text": "Whisk everything together."},
+     {"@type": "HowToStep", "text": "Fry in a hot pan."}
+   ]}
+]}
+</script></head><body></body></html>`
+
+func TestPreviewFromURL(t *testing.T) {
+	const url = "https://example.com/pancakes"
+	service := NewRecipeImportService(stubFetcher{url: jsonLDPage})
+
+	preview, err := service.PreviewFromURL(context.Background(), interfaces.ImportRecipeInput{URL: url})
+	if err != nil {
+		t.Fatalf("PreviewFromURL() error = %v", err)
+	}
+
+	recipe := preview.Recipe
+	if recipe.Title != "Pancakes" {
+		t.Errorf("Title = %q, want Pancakes", recipe.Title)
+	}
+	if want := "Fluffy pancakes.\n\nSource: " + url; recipe.Description != want {
+		t.Errorf("Description = %q, want %q", recipe.Description, want)
+	}
+	if recipe.Time != 25 {
+		t.Errorf("Time = %d, want 25", recipe.Time)
+	}
+	if recipe.ServingSize != 4 {
+		t.Errorf("ServingSize = %d, want 4", recipe.ServingSize)
+	}
+	if len(recipe.Images) != 1 || recipe.Images[0].Extension != "jpg" {
+		t.Errorf("Images = %+v, want one jpg", recipe.Images)
+	}
+	if len(recipe.Ingredients) != 3 {
+		t.Fatalf("got %d ingredients, want 3", len(recipe.Ingredients))
+	}
+	if got := recipe.Ingredients[0]; got.Name != "flour" || got.Amount != 200 || got.Unit != "g" {
+		t.Errorf("first ingredient = %+v, want 200 g flour", got)
+	}
+	if len(recipe.Steps) != 2 || recipe.Steps[1].Order != 2 || recipe.Steps[1].Content != "Fry in a hot pan." {
+		t.Errorf("Steps = %+v", recipe.Steps)
+	}
+}
+
+func TestPreviewFromURLErrors(t *testing.T) {
+	service := NewRecipeImportService(stubFetcher{
+		"https://example.com/blog": `<html><body><p>No recipe here</p></body></html>`,
+	})
+
+	_, err := service.PreviewFromURL(context.Background(), interfaces.ImportRecipeInput{URL: "https://example.com/blog"})
+	if !errors.Is(err, interfaces.ErrNoRecipeFound) {
+		t.Errorf("page without a recipe: error = %v, want ErrNoRecipeFound", err)
+	}
+
+	if _, err := service.PreviewFromURL(context.Background(), interfaces.ImportRecipeInput{URL: "https://example.com/gone"}); err == nil {
+		t.Error("failed fetch: want an error")
+	}
+}
@@ -0,0 +1,146 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	maxRecipeNoteLength = 5000
+	maxStepNoteLength   = 1000
+)
+
+type recipeNoteService struct {
+	recipeNoteRepo interfaces.RecipeNoteRepository
+	recipeRepo     interfaces.RecipeRepository
+}
+
+// NewRecipeNoteService creates a new private recipe note service
+func NewRecipeNoteService(recipeNoteRepo interfaces.RecipeNoteRepository, recipeRepo interfaces.RecipeRepository) interfaces.RecipeNoteService {
+	return &recipeNoteService{
+		recipeNoteRepo: recipeNoteRepo,
+		recipeRepo:     recipeRepo,
+	}
+}
+
+// GetNote gets the user's note on a recipe, or nil when the user has not written one
+func (s *recipeNoteService) GetNote(ctx context.Context, recipeID, userID uuid.UUID) (*domain.RecipeNote, error) {
+	note, err := s.recipeNoteRepo.GetByUserAndRecipe(ctx, userID, recipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return note, nil
+}
+
+// GetNotes gets the user's notes on all recipes, most recently updated first
+func (s *recipeNoteService) GetNotes(ctx context.Context, userID uuid.UUID, offset, limit int) ([]domain.RecipeNote, error) {
+	return s.recipeNoteRepo.GetByUserID(ctx, userID, offset, limit)
+}
+
+// SaveNote creates the user's note on a recipe or replaces the existing one
+func (s *recipeNoteService) SaveNote(ctx context.Context, recipeID, userID uuid.UUID, input interfaces.SaveRecipeNoteInput) (*domain.RecipeNote, error) {
+	recipe, err := s.recipeRepo.GetRecipe(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+
+	content := strings.TrimSpace(input.Content)
+	if utf8.RuneCountInString(content) > maxRecipeNoteLength {
+		return nil, interfaces.NewValidationError(fmt.Sprintf("a note can be at most %d characters", maxRecipeNoteLength))
+	}
+	stepNotes, err := validateStepNotes(input.StepNotes, recipe)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" && len(stepNotes) == 0 {
+		return nil, interfaces.NewValidationError("note is empty; delete it instead")
+	}
+
+	note, err := s.GetNote(ctx, recipeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if note == nil {
+		note = &domain.RecipeNote{
+			UserID:    userID,
+			RecipeID:  recipeID,
+			Content:   content,
+			StepNotes: stepNotes,
+		}
+		if err := s.recipeNoteRepo.Create(ctx, note); err != nil {
+			return nil, err
+		}
+		return note, nil
+	}
+
+	note.Content = content
+	note.StepNotes = stepNotes
+	if err := s.recipeNoteRepo.Update(ctx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// DeleteNote deletes the user's note on a recipe
+func (s *recipeNoteService) DeleteNote(ctx context.Context, recipeID, userID uuid.UUID) error {
+	note, err := s.GetNote(ctx, recipeID, userID)
+	if err != nil {
+		return err
+	}
+	if note == nil {
+		return interfaces.NewNotFoundError("note not found")
+	}
+	return s.recipeNoteRepo.Delete(ctx, note.ID)
+}
+
+// validateStepNotes checks that every step note annotates an existing step once, drops empty
+// ones and orders them by step
+func validateStepNotes(stepNotes []domain.StepNote, recipe *domain.Recipe) ([]domain.StepNote, error) {
+	steps := make(map[int]bool)
+	for _, step := range recipe.Steps {
+		steps[step.Order] = true
+	}
+	for _, section := range recipe.Sections {
+		for _, step := range section.Steps {
+			steps[step.Order] = true
+		}
+	}
+
+	valid := []domain.StepNote{}
+	seen := make(map[int]bool)
+	for _, stepNote := range stepNotes {
+		content := strings.TrimSpace(stepNote.Content)
+		if content == "" {
+			continue
+		}
+		if !steps[stepNote.Step] {
+			return nil, interfaces.NewValidationError(fmt.Sprintf("recipe has no step %d", stepNote.Step))
+		}
+		if seen[stepNote.Step] {
+			return nil, interfaces.NewValidationError(fmt.Sprintf("step %d has more than one note", stepNote.Step))
+		}
+		if utf8.RuneCountInString(content) > maxStepNoteLength {
+			return nil, interfaces.NewValidationError(fmt.Sprintf("a step note can be at most %d characters", maxStepNoteLength))
+		}
+		seen[stepNote.Step] = true
+		valid = append(valid, domain.StepNote{Step: stepNote.Step, Content: content})
+	}
+
+	sort.Slice(valid, func(i, j int) bool { return valid[i].Step < valid[j].Step })
+	return valid, nil
+}
@@ -15,6 +15,7 @@ import (
 type RecipeRatingService struct {
 	recipeRatingRepo interfaces.RecipeRatingRepository
 	recipeRepo       interfaces.RecipeRepository
+	cookLogRepo      interfaces.CookLogRepository
 }
 
 // RateRecipe creates a new rating for a recipe
@@ -28,12 +29,19 @@ func (s *RecipeRatingService) RateRecipe(ctx context.Context, input interfaces.C
 		return nil, err
 	}
 
+	// A rating is a verified cook when the user has logged cooking the recipe
+	verifiedCook, err := s.cookLogRepo.ExistsForUserAndRecipe(ctx, input.UserID, input.RecipeID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if the user already rated this recipe
 	existingRating, err := s.recipeRatingRepo.GetRatingByUserAndRecipeID(ctx, input.UserID, input.RecipeID)
 	if err == nil && existingRating != nil {
 		// User already rated this recipe, update the existing rating
 		existingRating.Rating = input.Rating
 		existingRating.Comment = input.Comment
+		existingRating.VerifiedCook = verifiedCook
 		existingRating.UpdatedAt = time.Now()
 
 		if err := s.recipeRatingRepo.UpdateRating(ctx, existingRating); err != nil {
@@ -54,10 +62,11 @@ func (s *RecipeRatingService) RateRecipe(ctx context.Context, input interfaces.C
 			UpdatedAt: time.Now(),
 			Status:    1,
 		},
-		RecipeID: input.RecipeID,
-		UserID:   input.UserID,
-		Rating:   input.Rating,
-		Comment:  input.Comment,
+		RecipeID:     input.RecipeID,
+		UserID:       input.UserID,
+		Rating:       input.Rating,
+		Comment:      input.Comment,
+		VerifiedCook: verifiedCook,
 	}
 
 	if err := s.recipeRatingRepo.CreateRating(ctx, rating); err != nil {
@@ -172,9 +181,10 @@ func (s *RecipeRatingService) GetRatingsWithUserByRecipeID(ctx context.Context,
 }
 
 // NewRecipeRatingService creates a new recipe rating service
-func NewRecipeRatingService(recipeRatingRepo interfaces.RecipeRatingRepository, recipeRepo interfaces.RecipeRepository) interfaces.RecipeRatingService {
+func NewRecipeRatingService(recipeRatingRepo interfaces.RecipeRatingRepository, recipeRepo interfaces.RecipeRepository, cookLogRepo interfaces.CookLogRepository) interfaces.RecipeRatingService {
 	return &RecipeRatingService{
 		recipeRatingRepo: recipeRatingRepo,
 		recipeRepo:       recipeRepo,
+		cookLogRepo:      cookLogRepo,
 	}
 }
@@ -5,17 +5,30 @@ import (
 	"cookaholic/internal/domain"
 	"cookaholic/internal/interfaces"
 	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type recipeService struct {
-	recipeRepo interfaces.RecipeRepository
+	recipeRepo        interfaces.RecipeRepository
+	categoryRepo      interfaces.CategoryRepository
+	eventBus          interfaces.EventBus
+	duplicateService  interfaces.DuplicateService
+	ingredientService interfaces.IngredientService
 }
 
-func NewRecipeService(recipeRepo interfaces.RecipeRepository) *recipeService {
+func NewRecipeService(recipeRepo interfaces.RecipeRepository, categoryRepo interfaces.CategoryRepository, eventBus interfaces.EventBus, duplicateService interfaces.DuplicateService, ingredientService interfaces.IngredientService) *recipeService {
 	return &recipeService{
-		recipeRepo: recipeRepo,
+		recipeRepo:        recipeRepo,
+		categoryRepo:      categoryRepo,
+		eventBus:          eventBus,
+		duplicateService:  duplicateService,
+		ingredientService: ingredientService,
 	}
 }
 
@@ -31,26 +44,164 @@ func (s *recipeService) CreateRecipe(ctx context.Context, input interfaces.Creat
 		Images:      input.Images,
 		Ingredients: input.Ingredients,
 		Steps:       input.Steps,
+		Sections:    input.Sections,
+		Nutrition:   input.Nutrition,
+		Tags:        normalizeTags(input.Tags),
+		Locale:      domain.DefaultLocale,
 	}
 
+	if input.Locale != "" {
+		locale, err := normalizeLocale(input.Locale)
+		if err != nil {
+			return nil, err
+		}
+		recipe.Locale = locale
+	}
+
+	if err := s.ValidateRecipe(ctx, recipe); err != nil {
+		return nil, err
+	}
+	linkRecipeIngredients(ctx, s.ingredientService, recipe)
+
 	err := s.recipeRepo.CreateRecipe(ctx, recipe)
 	if err != nil {
 		return nil, err
 	}
 
+	s.checkDuplicates(ctx, recipe)
+	s.publishRecipeCreated(ctx, recipe)
+
 	return recipe, nil
 }
 
+// ValidateRecipe checks a recipe about to be created or updated, its category included
+func (s *recipeService) ValidateRecipe(ctx context.Context, recipe *domain.Recipe) error {
+	if err := s.ValidateRecipeContent(ctx, recipe); err != nil {
+		return err
+	}
+	return s.validateCategory(ctx, recipe.CategoryID)
+}
+
+// ValidateRecipeContent checks the title, time, serving size, steps, nutrition, tags and sections of a recipe
+func (s *recipeService) ValidateRecipeContent(ctx context.Context, recipe *domain.Recipe) error {
+	if strings.TrimSpace(recipe.Title) == "" {
+		return interfaces.NewValidationError("title is required")
+	}
+	if recipe.Time < 0 {
+		return interfaces.NewValidationError("time cannot be negative")
+	}
+	if recipe.ServingSize <= 0 {
+		return interfaces.NewValidationError("serving size must be positive")
+	}
+	if err := validateSteps(recipe.Ingredients, recipe.Steps); err != nil {
+		return err
+	}
+	if err := validateNutrition(recipe.Nutrition); err != nil {
+		return err
+	}
+	if err := validateTags(recipe.Tags); err != nil {
+		return err
+	}
+	// A new recipe cannot be referenced yet, so only the depth of its sub-recipes needs checking
+	recipeID := uuid.Nil
+	if recipe.BaseModel != nil {
+		recipeID = recipe.ID
+	}
+	return s.validateSections(ctx, recipeID, recipe.Sections)
+}
+
+// validateCategory checks a recipe is put in a category that exists and isn't in the trash
+func (s *recipeService) validateCategory(ctx context.Context, categoryID uuid.UUID) error {
+	if categoryID == uuid.Nil {
+		return interfaces.NewValidationError("category_id is required")
+	}
+	category, err := s.categoryRepo.Get(ctx, categoryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.NewValidationError(fmt.Sprintf("category %s not found", categoryID))
+		}
+		return err
+	}
+	if category.Status == 0 {
+		return interfaces.NewValidationError(fmt.Sprintf("category %s not found", categoryID))
+	}
+	return nil
+}
+
+// ForkRecipe copies another recipe into the user's own recipes. The copy starts without ratings
+// or cooks and keeps a link to the original, so the original's author can see how often it was forked.
+func (s *recipeService) ForkRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Recipe, error) {
+	original, err := s.recipeRepo.GetRecipe(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+
+	fork := &domain.Recipe{
+		UserID:       userID,
+		Title:        original.Title,
+		Description:  original.Description,
+		Time:         original.Time,
+		CategoryID:   original.CategoryID,
+		ServingSize:  original.ServingSize,
+		Images:       original.Images,
+		Ingredients:  original.Ingredients,
+		Steps:        original.Steps,
+		Sections:     original.Sections,
+		Nutrition:    original.Nutrition,
+		Tags:         original.Tags,
+		Locale:       original.Locale,
+		Translations: original.Translations,
+		ForkedFromID: &original.ID,
+	}
+
+	if err := s.recipeRepo.CreateRecipe(ctx, fork); err != nil {
+		return nil, err
+	}
+
+	s.checkDuplicates(ctx, fork)
+	s.publishRecipeCreated(ctx, fork)
+
+	return fork, nil
+}
+
+// checkDuplicates warns the author about their own recipes the saved recipe duplicates. A failed
+// check does not fail the save; recipes left without a fingerprint are checked at the next startup.
+func (s *recipeService) checkDuplicates(ctx context.Context, recipe *domain.Recipe) {
+	warnings, err := s.duplicateService.CheckRecipe(ctx, recipe)
+	if err != nil {
+		log.Printf("Failed to check recipe %s for duplicates: %v", recipe.ID, err)
+		return
+	}
+	recipe.PossibleDuplicates = warnings
+}
+
+func (s *recipeService) publishRecipeCreated(ctx context.Context, recipe *domain.Recipe) {
+	event := interfaces.RecipeCreatedEvent{
+		RecipeID:  recipe.ID,
+		UserID:    recipe.UserID,
+		CreatedAt: recipe.CreatedAt,
+	}
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		log.Printf("Failed to publish recipe created event: %v", err)
+	}
+}
+
 func (s *recipeService) GetRecipe(ctx context.Context, id uuid.UUID) (*domain.Recipe, error) {
 	return s.recipeRepo.GetRecipe(ctx, id)
 }
 
-func (s *recipeService) UpdateRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID, input interfaces.UpdateRecipeInput) (*domain.Recipe, error) {
+func (s *recipeService) UpdateRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, input interfaces.UpdateRecipeInput) (*domain.Recipe, error) {
 	// First get the existing recipe
 	existingRecipe, err := s.recipeRepo.GetRecipe(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if version != interfaces.AnyVersion && existingRecipe.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
 
 	// Update the fields that are provided in the input
 	if input.Title != "" {
@@ -77,20 +228,118 @@ func (s *recipeService) UpdateRecipe(ctx context.Context, id uuid.UUID, userID u
 	if input.Steps != nil {
 		existingRecipe.Steps = input.Steps
 	}
+	if input.Sections != nil {
+		existingRecipe.Sections = input.Sections
+	}
+	if input.Nutrition != nil {
+		existingRecipe.Nutrition = input.Nutrition
+	}
+	if input.Tags != nil {
+		existingRecipe.Tags = normalizeTags(input.Tags)
+	}
+
+	if input.Locale != "" {
+		locale, err := normalizeLocale(input.Locale)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := existingRecipe.Translations[locale]; ok {
+			return nil, interfaces.NewValidationError("the recipe already has a translation in this locale")
+		}
+		existingRecipe.Locale = locale
+	}
 
 	// Ensure we're using the correct ID and UserID
 	existingRecipe.ID = id
 	existingRecipe.UserID = userID
+	if err := s.ValidateRecipe(ctx, existingRecipe); err != nil {
+		return nil, err
+	}
+	linkRecipeIngredients(ctx, s.ingredientService, existingRecipe)
 
 	err = s.recipeRepo.UpdateRecipe(ctx, existingRecipe)
 	if err != nil {
 		return nil, err
 	}
 
+	s.checkDuplicates(ctx, existingRecipe)
+
 	return existingRecipe, nil
 }
 
-func (s *recipeService) DeleteRecipe(ctx context.Context, id uuid.UUID) error {
+func (s *recipeService) PatchRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, patch []byte) (*domain.Recipe, error) {
+	existingRecipe, err := s.recipeRepo.GetRecipe(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+	if existingRecipe.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to update this recipe")
+	}
+	if version != interfaces.AnyVersion && existingRecipe.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
+
+	document := interfaces.UpdateRecipeInput{
+		Title:       existingRecipe.Title,
+		Description: existingRecipe.Description,
+		Time:        existingRecipe.Time,
+		CategoryID:  existingRecipe.CategoryID,
+		ServingSize: existingRecipe.ServingSize,
+		Images:      existingRecipe.Images,
+		Ingredients: existingRecipe.Ingredients,
+		Steps:       existingRecipe.Steps,
+		Sections:    existingRecipe.Sections,
+		Nutrition:   existingRecipe.Nutrition,
+		Tags:        existingRecipe.Tags,
+		Locale:      existingRecipe.Locale,
+	}
+	var patched interfaces.UpdateRecipeInput
+	if err := applyMergePatch(document, patch, &patched); err != nil {
+		return nil, err
+	}
+
+	// Removing the locale resets it to the default one
+	locale := domain.DefaultLocale
+	if patched.Locale != "" {
+		if locale, err = normalizeLocale(patched.Locale); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := existingRecipe.Translations[locale]; ok && locale != existingRecipe.Locale {
+		return nil, interfaces.NewValidationError("the recipe already has a translation in this locale")
+	}
+
+	existingRecipe.Title = patched.Title
+	existingRecipe.Description = patched.Description
+	existingRecipe.Time = patched.Time
+	existingRecipe.CategoryID = patched.CategoryID
+	existingRecipe.ServingSize = patched.ServingSize
+	existingRecipe.Images = patched.Images
+	existingRecipe.Ingredients = patched.Ingredients
+	existingRecipe.Steps = patched.Steps
+	existingRecipe.Sections = patched.Sections
+	existingRecipe.Nutrition = patched.Nutrition
+	existingRecipe.Tags = normalizeTags(patched.Tags)
+	existingRecipe.Locale = locale
+	// The patched recipe must still be one that could be created
+	if err := s.ValidateRecipe(ctx, existingRecipe); err != nil {
+		return nil, err
+	}
+	linkRecipeIngredients(ctx, s.ingredientService, existingRecipe)
+
+	if err := s.recipeRepo.UpdateRecipe(ctx, existingRecipe); err != nil {
+		return nil, err
+	}
+
+	s.checkDuplicates(ctx, existingRecipe)
+
+	return existingRecipe, nil
+}
+
+func (s *recipeService) DeleteRecipe(ctx context.Context, id uuid.UUID, version int64) error {
 	recipe, err := s.GetRecipe(ctx, id)
 	if err != nil {
 		return err
@@ -99,10 +348,219 @@ func (s *recipeService) DeleteRecipe(ctx context.Context, id uuid.UUID) error {
 	if recipe.Status == 0 {
 		return errors.New("recipe not found")
 	}
+	if version != interfaces.AnyVersion && recipe.Version != version {
+		return interfaces.ErrVersionConflict
+	}
 
-	return s.recipeRepo.DeleteRecipe(ctx, id)
+	return s.recipeRepo.DeleteRecipe(ctx, id, recipe.Version)
 }
 
 func (s *recipeService) FilterRecipesByCondition(ctx context.Context, conditions map[string]interface{}, cursor uuid.UUID, limit int) ([]domain.Recipe, uuid.UUID, error) {
 	return s.recipeRepo.FilterRecipesByCondition(ctx, conditions, cursor, limit)
 }
+
+// maxSectionDepth limits how deeply sub-recipes can be nested inside each other
+const maxSectionDepth = 5
+
+// ExpandSections fills in the sub-recipes referenced by the recipe's sections.
+// Ingredient amounts of each sub-recipe, including those of its own sections, are scaled by the section multiplier.
+func (s *recipeService) ExpandSections(ctx context.Context, recipe *domain.Recipe) error {
+	return s.expandSections(ctx, recipe, 1, 1)
+}
+
+func (s *recipeService) expandSections(ctx context.Context, recipe *domain.Recipe, scale float64, depth int) error {
+	for i := range recipe.Sections {
+		section := &recipe.Sections[i]
+		if section.RecipeID == nil {
+			section.Ingredients = scaleIngredients(section.Ingredients, scale)
+			continue
+		}
+		// Recursion is rejected on write, the depth limit only guards against data written before that
+		if depth > maxSectionDepth {
+			return interfaces.NewValidationError(fmt.Sprintf("sub-recipes are nested more than %d levels deep", maxSectionDepth))
+		}
+
+		subRecipe, err := s.recipeRepo.GetRecipe(ctx, *section.RecipeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				// The referenced recipe was deleted; keep the section without its contents
+				continue
+			}
+			return err
+		}
+
+		multiplier := scale * sectionMultiplier(*section)
+		subRecipe.Ingredients = scaleIngredients(subRecipe.Ingredients, multiplier)
+
+		if err := s.expandSections(ctx, subRecipe, multiplier, depth+1); err != nil {
+			return err
+		}
+		section.Recipe = subRecipe
+	}
+
+	return nil
+}
+
+// scaleIngredients returns a copy of the ingredients with their amounts multiplied by scale
+func scaleIngredients(ingredients domain.Ingredients, scale float64) domain.Ingredients {
+	if scale == 1 {
+		return ingredients
+	}
+	scaled := make(domain.Ingredients, len(ingredients))
+	for i, ingredient := range ingredients {
+		ingredient.Amount *= scale
+		ingredient.AmountMax *= scale
+		scaled[i] = ingredient
+	}
+	return scaled
+}
+
+// validateSections checks the recipe's sections and rejects sub-recipe references that would
+// make the recipe include itself, directly or through other recipes
+func (s *recipeService) validateSections(ctx context.Context, recipeID uuid.UUID, sections domain.Sections) error {
+	for i := range sections {
+		section := &sections[i]
+		section.Name = strings.TrimSpace(section.Name)
+		// Expanded sub-recipes are a read-only view and are never stored
+		section.Recipe = nil
+
+		if section.Name == "" {
+			return interfaces.NewValidationError(fmt.Sprintf("section %d: name is required", i+1))
+		}
+
+		if section.RecipeID == nil {
+			if section.Multiplier != 0 {
+				return interfaces.NewValidationError(fmt.Sprintf("section %q: multiplier requires a recipe_id", section.Name))
+			}
+			if err := validateSteps(section.Ingredients, section.Steps); err != nil {
+				return interfaces.NewValidationError(fmt.Sprintf("section %q: %s", section.Name, err.Error()))
+			}
+			continue
+		}
+
+		if len(section.Ingredients) > 0 || len(section.Steps) > 0 {
+			return interfaces.NewValidationError(fmt.Sprintf("section %q: a section referencing a recipe cannot have its own ingredients or steps", section.Name))
+		}
+		if section.Multiplier < 0 {
+			return interfaces.NewValidationError(fmt.Sprintf("section %q: multiplier must be positive", section.Name))
+		}
+		if section.Multiplier == 0 {
+			section.Multiplier = 1
+		}
+
+		if err := s.checkSectionReference(ctx, recipeID, *section.RecipeID, 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkSectionReference walks the sub-recipes of refID looking for rootID
+func (s *recipeService) checkSectionReference(ctx context.Context, rootID uuid.UUID, refID uuid.UUID, depth int) error {
+	if refID == rootID {
+		return interfaces.NewValidationError("a recipe cannot include itself as a section")
+	}
+	if depth > maxSectionDepth {
+		return interfaces.NewValidationError(fmt.Sprintf("sub-recipes cannot be nested more than %d levels deep", maxSectionDepth))
+	}
+
+	referenced, err := s.recipeRepo.GetRecipe(ctx, refID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return interfaces.NewValidationError(fmt.Sprintf("referenced recipe %s not found", refID))
+		}
+		return err
+	}
+
+	for _, section := range referenced.Sections {
+		if section.RecipeID == nil {
+			continue
+		}
+		if err := s.checkSectionReference(ctx, rootID, *section.RecipeID, depth+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sectionMultiplier(section domain.RecipeSection) float64 {
+	if section.Multiplier <= 0 {
+		return 1
+	}
+	return section.Multiplier
+}
+
+// maxRecipeTags and maxTagLength limit how many tags a recipe has and how long each one is
+const (
+	maxRecipeTags = 20
+	maxTagLength  = 32
+)
+
+// normalizeTags lowercases and trims tags and drops empty and duplicate ones
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), " "))
+		if tag != "" && !containsString(normalized, tag) {
+			normalized = append(normalized, tag)
+		}
+	}
+	return normalized
+}
+
+// validateTags rejects too many tags and tags that are too long
+func validateTags(tags []string) error {
+	if len(tags) > maxRecipeTags {
+		return interfaces.NewValidationError(fmt.Sprintf("a recipe can have at most %d tags", maxRecipeTags))
+	}
+	for _, tag := range tags {
+		if utf8.RuneCountInString(tag) > maxTagLength {
+			return interfaces.NewValidationError(fmt.Sprintf("tag %q is longer than %d characters", tag, maxTagLength))
+		}
+	}
+	return nil
+}
+
+// validateNutrition rejects negative nutrition values
+func validateNutrition(nutrition *domain.Nutrition) error {
+	if nutrition == nil {
+		return nil
+	}
+	n := nutrition
+	if n.Calories < 0 || n.Protein < 0 || n.Carbohydrates < 0 || n.Fat < 0 || n.Fiber < 0 || n.Sugar < 0 || n.Sodium < 0 {
+		return interfaces.NewValidationError("nutrition values cannot be negative")
+	}
+	return nil
+}
+
+// validateSteps checks the structured step fields and normalizes temperature units
+func validateSteps(ingredients domain.Ingredients, steps domain.Steps) error {
+	for i := range steps {
+		step := &steps[i]
+
+		if step.Duration < 0 {
+			return interfaces.NewValidationError(fmt.Sprintf("step %d: duration cannot be negative", step.Order))
+		}
+
+		if step.Temperature != nil {
+			switch strings.ToUpper(strings.TrimPrefix(strings.TrimSpace(step.Temperature.Unit), "°")) {
+			case "C", "CELSIUS":
+				step.Temperature.Unit = domain.TemperatureCelsius
+			case "F", "FAHRENHEIT":
+				step.Temperature.Unit = domain.TemperatureFahrenheit
+			default:
+				return interfaces.NewValidationError(fmt.Sprintf("step %d: temperature unit must be C or F", step.Order))
+			}
+		}
+
+		for _, ref := range step.IngredientRefs {
+			if ref < 0 || ref >= len(ingredients) {
+				return interfaces.NewValidationError(fmt.Sprintf("step %d: ingredient reference %d is out of range", step.Order, ref))
+			}
+		}
+	}
+
+	return nil
+}
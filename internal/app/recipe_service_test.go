@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// memoryRecipeRepo serves recipes from memory, handing out copies as the database would
+type memoryRecipeRepo struct {
+	interfaces.RecipeRepository
+	recipes map[uuid.UUID]domain.Recipe
+}
+
+func (r *memoryRecipeRepo) GetRecipe(ctx context.Context, id uuid.UUID) (*domain.Recipe, error) {
+	recipe, ok := r.recipes[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	recipe.Sections = append(domain.Sections(nil), recipe.Sections...)
+	return &recipe, nil
+}
+
+// memoryCategoryRepo serves categories from memory
+type memoryCategoryRepo struct {
+	interfaces.CategoryRepository
+	categories map[uuid.UUID]domain.Category
+}
+
+func (r *memoryCategoryRepo) Get(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
+	category, ok := r.categories[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &category, nil
+}
+
+func TestValidateRecipe(t *testing.T) {
+	activeID := uuid.MustParse("6f1c1d2e-0000-4000-8000-000000000020")
+	trashedID := uuid.MustParse("6f1c1d2e-0000-4000-8000-000000000021")
+	service := &recipeService{categoryRepo: &memoryCategoryRepo{categories: map[uuid.UUID]domain.Category{
+		activeID:  {BaseModel: &common.BaseModel{ID: activeID, Status: 1}, Name: "Soups"},
+		trashedID: {BaseModel: &common.BaseModel{ID: trashedID, Status: 0}, Name: "Old"},
+	}}}
+
+	tests := []struct {
+		name    string
+		edit    func(*domain.Recipe)
+		wantErr bool
+	}{
+		{name: "valid recipe", edit: func(r *domain.Recipe) {}},
+		{name: "blank title", edit: func(r *domain.Recipe) { r.Title = "  " }, wantErr: true},
+		{name: "negative time", edit: func(r *domain.Recipe) { r.Time = -5 }, wantErr: true},
+		{name: "no servings", edit: func(r *domain.Recipe) { r.ServingSize = 0 }, wantErr: true},
+		{name: "no category", edit: func(r *domain.Recipe) { r.CategoryID = uuid.Nil }, wantErr: true},
+		{name: "unknown category", edit: func(r *domain.Recipe) { r.CategoryID = uuid.New() }, wantErr: true},
+		{name: "trashed category", edit: func(r *domain.Recipe) { r.CategoryID = trashedID }, wantErr: true},
+		{name: "negative nutrition", edit: func(r *domain.Recipe) { r.Nutrition = &domain.Nutrition{Fat: -1} }, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipe := &domain.Recipe{Title: "Soup", Time: 30, ServingSize: 4, CategoryID: activeID}
+			tt.edit(recipe)
+
+			err := service.ValidateRecipe(context.Background(), recipe)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("ValidateRecipe() error = %v", err)
+				}
+				return
+			}
+			var validationErr *interfaces.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("ValidateRecipe() error = %v, want a ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestExpandSectionsScalesSubRecipeSections(t *testing.T) {
+	stockID := uuid.MustParse("6f1c1d2e-0000-4000-8000-000000000010")
+	repo := &memoryRecipeRepo{recipes: map[uuid.UUID]domain.Recipe{
+		stockID: {
+			BaseModel:   &common.BaseModel{ID: stockID},
+			Title:       "Stock",
+			Ingredients: domain.Ingredients{{Name: "water", Amount: 1, Unit: "l"}},
+			Sections: domain.Sections{{
+				Name:        "Aromatics",
+				Ingredients: domain.Ingredients{{Name: "onion", Amount: 1}, {Name: "bay leaves", Amount: 2, AmountMax: 3}},
+			}},
+		},
+	}}
+	service := &recipeService{recipeRepo: repo}
+
+	recipe := &domain.Recipe{
+		Title: "Soup",
+		Sections: domain.Sections{
+			{Name: "Stock", RecipeID: &stockID, Multiplier: 2},
+			{Name: "Garnish", Ingredients: domain.Ingredients{{Name: "parsley", Amount: 1}}},
+		},
+	}
+	if err := service.ExpandSections(context.Background(), recipe); err != nil {
+		t.Fatalf("ExpandSections() error = %v", err)
+	}
+
+	stock := recipe.Sections[0].Recipe
+	if stock == nil {
+		t.Fatal("sub-recipe was not expanded")
+	}
+	if got := stock.Ingredients[0].Amount; got != 2 {
+		t.Errorf("water = %v, want 2", got)
+	}
+	aromatics := stock.Sections[0].Ingredients
+	if aromatics[0].Amount != 2 || aromatics[1].Amount != 4 || aromatics[1].AmountMax != 6 {
+		t.Errorf("aromatics = %+v, want onion 2 and bay leaves 4-6", aromatics)
+	}
+	if got := recipe.Sections[1].Ingredients[0].Amount; got != 1 {
+		t.Errorf("garnish of the recipe itself = %v, want 1", got)
+	}
+	if got := repo.recipes[stockID].Sections[0].Ingredients[0].Amount; got != 1 {
+		t.Errorf("stored sub-recipe was changed to %v", got)
+	}
+}
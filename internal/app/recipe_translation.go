@@ -0,0 +1,293 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// normalizeLocale checks that a locale is a language code with an optional region, such as "en"
+// or "pt-BR", and returns it with the language in lowercase and the region in uppercase
+func normalizeLocale(locale string) (string, error) {
+	language, region, hasRegion := strings.Cut(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"), "-")
+	if !isASCIILetters(language, 2, 3) || (hasRegion && !isASCIILetters(region, 2, 2)) {
+		return "", interfaces.NewValidationError("locale must be a language code with an optional region, such as en or pt-BR")
+	}
+
+	normalized := strings.ToLower(language)
+	if hasRegion {
+		normalized += "-" + strings.ToUpper(region)
+	}
+	return normalized, nil
+}
+
+func isASCIILetters(s string, minLength, maxLength int) bool {
+	if len(s) < minLength || len(s) > maxLength {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// localeLanguage returns the language of a locale without its region
+func localeLanguage(locale string) string {
+	language, _, _ := strings.Cut(locale, "-")
+	return strings.ToLower(language)
+}
+
+// matchLocale picks the available locale best matching the preferred locales, which are in order of
+// preference. A preferred locale matches exactly first, then any locale of the same language,
+// favouring the original one and the language without a region. Without a match it returns original.
+func matchLocale(preferred []string, original string, translated []string) string {
+	available := append([]string{original}, translated...)
+	for _, wanted := range preferred {
+		for _, locale := range available {
+			if strings.EqualFold(wanted, locale) {
+				return locale
+			}
+		}
+
+		language := localeLanguage(wanted)
+		var sameLanguage []string
+		for _, locale := range available {
+			if localeLanguage(locale) == language {
+				sameLanguage = append(sameLanguage, locale)
+			}
+		}
+		if len(sameLanguage) == 0 {
+			continue
+		}
+		sort.SliceStable(sameLanguage, func(i, j int) bool {
+			return localeRank(sameLanguage[i], original) < localeRank(sameLanguage[j], original)
+		})
+		return sameLanguage[0]
+	}
+	return original
+}
+
+func localeRank(locale, original string) int {
+	switch {
+	case locale == original:
+		return 0
+	case !strings.Contains(locale, "-"):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// LocalizeRecipe replaces the recipe's title, description, ingredient names and step contents, those
+// of its sections included, with the translation best matching the preferred locales, and records the
+// locale that was served. Expanded sub-recipes are localized the same way, each from its own translations.
+func (s *recipeService) LocalizeRecipe(recipe *domain.Recipe, preferred []string) {
+	for _, section := range recipe.Sections {
+		if section.Recipe != nil {
+			s.LocalizeRecipe(section.Recipe, preferred)
+		}
+	}
+
+	original := recipe.Locale
+	if original == "" {
+		original = domain.DefaultLocale
+	}
+
+	translated := make([]string, 0, len(recipe.Translations))
+	for locale := range recipe.Translations {
+		translated = append(translated, locale)
+	}
+	sort.Strings(translated)
+
+	served := matchLocale(preferred, original, translated)
+	recipe.ServedLocale = served
+	if served == original {
+		return
+	}
+
+	translation := recipe.Translations[served]
+	if translation.Title != "" {
+		recipe.Title = translation.Title
+	}
+	if translation.Description != "" {
+		recipe.Description = translation.Description
+	}
+
+	ingredientNames := translatedTexts(translation.SourceIngredients, translation.Ingredients)
+	stepContents := translatedTexts(translation.SourceSteps, translation.Steps)
+	recipe.Ingredients = translateIngredients(recipe.Ingredients, ingredientNames)
+	recipe.Steps = translateSteps(recipe.Steps, stepContents)
+
+	sections := make(domain.Sections, len(recipe.Sections))
+	copy(sections, recipe.Sections)
+	for i := range sections {
+		sections[i].Ingredients = translateIngredients(sections[i].Ingredients, ingredientNames)
+		sections[i].Steps = translateSteps(sections[i].Steps, stepContents)
+	}
+	recipe.Sections = sections
+}
+
+// translateIngredients returns a copy of the ingredients with the names that have a translation replaced
+func translateIngredients(ingredients domain.Ingredients, names map[string]string) domain.Ingredients {
+	if ingredients == nil {
+		return nil
+	}
+	translated := make(domain.Ingredients, len(ingredients))
+	copy(translated, ingredients)
+	for i := range translated {
+		if name, ok := names[translated[i].Name]; ok {
+			translated[i].Name = name
+		}
+	}
+	return translated
+}
+
+// translateSteps returns a copy of the steps with the contents that have a translation replaced
+func translateSteps(steps domain.Steps, contents map[string]string) domain.Steps {
+	if steps == nil {
+		return nil
+	}
+	translated := make(domain.Steps, len(steps))
+	copy(translated, steps)
+	for i := range translated {
+		if content, ok := contents[translated[i].Content]; ok {
+			translated[i].Content = content
+		}
+	}
+	return translated
+}
+
+// translatableIngredients lists the ingredient names a translation covers, in reading order: the
+// recipe's own ingredients, then those of each section that doesn't reference another recipe
+func translatableIngredients(recipe *domain.Recipe) []string {
+	var names []string
+	for _, ingredient := range recipe.Ingredients {
+		names = append(names, ingredient.Name)
+	}
+	for _, section := range recipe.Sections {
+		for _, ingredient := range section.Ingredients {
+			names = append(names, ingredient.Name)
+		}
+	}
+	return names
+}
+
+// translatableSteps lists the step contents a translation covers, in the order of translatableIngredients
+func translatableSteps(recipe *domain.Recipe) []string {
+	var contents []string
+	for _, step := range recipe.Steps {
+		contents = append(contents, step.Content)
+	}
+	for _, section := range recipe.Sections {
+		for _, step := range section.Steps {
+			contents = append(contents, step.Content)
+		}
+	}
+	return contents
+}
+
+// translatedTexts maps original texts to their non-empty translations. Entries without a recorded
+// source are skipped, since there is no telling which ingredient or step they belong to.
+func translatedTexts(sources, translations []string) map[string]string {
+	texts := make(map[string]string, len(translations))
+	for i, translated := range translations {
+		if i < len(sources) && translated != "" {
+			texts[sources[i]] = translated
+		}
+	}
+	return texts
+}
+
+// SetTranslation adds or replaces the translation of a recipe owned by the user
+func (s *recipeService) SetTranslation(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, locale string, input interfaces.RecipeTranslationInput) (*domain.Recipe, error) {
+	recipe, locale, err := s.translatableRecipe(ctx, id, userID, version, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	translation := domain.RecipeTranslation{
+		Title:       strings.TrimSpace(input.Title),
+		Description: strings.TrimSpace(input.Description),
+		Ingredients: input.Ingredients,
+		Steps:       input.Steps,
+		UpdatedAt:   time.Now(),
+	}
+	if translation.Title == "" && translation.Description == "" && len(translation.Ingredients) == 0 && len(translation.Steps) == 0 {
+		return nil, interfaces.NewValidationError("a translation needs a title, description, ingredients or steps")
+	}
+	ingredientNames := translatableIngredients(recipe)
+	stepContents := translatableSteps(recipe)
+	if len(translation.Ingredients) > len(ingredientNames) {
+		return nil, interfaces.NewValidationError("translation has more ingredients than the recipe")
+	}
+	if len(translation.Steps) > len(stepContents) {
+		return nil, interfaces.NewValidationError("translation has more steps than the recipe")
+	}
+
+	// The translated entries are given in the recipe's current reading order; keep the text they translate
+	translation.SourceIngredients = ingredientNames[:len(translation.Ingredients)]
+	translation.SourceSteps = stepContents[:len(translation.Steps)]
+
+	if recipe.Translations == nil {
+		recipe.Translations = make(map[string]domain.RecipeTranslation)
+	}
+	recipe.Translations[locale] = translation
+
+	if err := s.recipeRepo.UpdateRecipe(ctx, recipe); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// DeleteTranslation removes the translation of a recipe owned by the user
+func (s *recipeService) DeleteTranslation(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, locale string) (*domain.Recipe, error) {
+	recipe, locale, err := s.translatableRecipe(ctx, id, userID, version, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := recipe.Translations[locale]; !ok {
+		return nil, interfaces.NewNotFoundError("translation not found")
+	}
+	delete(recipe.Translations, locale)
+
+	if err := s.recipeRepo.UpdateRecipe(ctx, recipe); err != nil {
+		return nil, err
+	}
+	return recipe, nil
+}
+
+// translatableRecipe gets a recipe owned by the user at the given version and checks the locale can be translated into
+func (s *recipeService) translatableRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, locale string) (*domain.Recipe, string, error) {
+	locale, err := normalizeLocale(locale)
+	if err != nil {
+		return nil, "", err
+	}
+
+	recipe, err := s.recipeRepo.GetRecipe(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, "", err
+	}
+	if recipe.UserID != userID {
+		return nil, "", interfaces.NewUnauthorizedError("unauthorized to translate this recipe")
+	}
+	if version != interfaces.AnyVersion && recipe.Version != version {
+		return nil, "", interfaces.ErrVersionConflict
+	}
+	if locale == recipe.Locale {
+		return nil, "", interfaces.NewValidationError("the recipe is already written in this locale")
+	}
+	return recipe, locale, nil
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"cookaholic/internal/domain"
+	"testing"
+)
+
+func TestLocalizeRecipeFollowsEditedRecipe(t *testing.T) {
+	translation := domain.RecipeTranslation{
+		Title:             "Pfannkuchen",
+		Ingredients:       []string{"Mehl", "Milch"},
+		Steps:             []string{"Verrühren.", ""},
+		SourceIngredients: []string{"flour", "milk"},
+		SourceSteps:       []string{"Whisk.", "Fry."},
+	}
+
+	tests := []struct {
+		name        string
+		ingredients []string
+		steps       []string
+		wantNames   []string
+		wantSteps   []string
+	}{
+		{
+			name:        "unchanged recipe",
+			ingredients: []string{"flour", "milk"},
+			steps:       []string{"Whisk.", "Fry."},
+			wantNames:   []string{"Mehl", "Milch"},
+			wantSteps:   []string{"Verrühren.", "Fry."},
+		},
+		{
+			name:        "inserted and reordered ingredients",
+			ingredients: []string{"eggs", "milk", "flour"},
+			steps:       []string{"Whisk.", "Fry."},
+			wantNames:   []string{"eggs", "Milch", "Mehl"},
+			wantSteps:   []string{"Verrühren.", "Fry."},
+		},
+		{
+			name:        "edited step falls back to the original",
+			ingredients: []string{"flour", "milk"},
+			steps:       []string{"Whisk well.", "Fry."},
+			wantNames:   []string{"Mehl", "Milch"},
+			wantSteps:   []string{"Whisk well.", "Fry."},
+		},
+	}
+
+	service := &recipeService{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recipe := &domain.Recipe{
+				Title:        "Pancakes",
+				Locale:       "en",
+				Translations: map[string]domain.RecipeTranslation{"de": translation},
+			}
+			for _, name := range tt.ingredients {
+				recipe.Ingredients = append(recipe.Ingredients, domain.Ingredient{Name: name})
+			}
+			for i, content := range tt.steps {
+				recipe.Steps = append(recipe.Steps, domain.Step{Order: i + 1, Content: content})
+			}
+
+			service.LocalizeRecipe(recipe, []string{"de-DE"})
+
+			if recipe.ServedLocale != "de" || recipe.Title != "Pfannkuchen" {
+				t.Fatalf("served %q with title %q, want de and Pfannkuchen", recipe.ServedLocale, recipe.Title)
+			}
+			for i, want := range tt.wantNames {
+				if recipe.Ingredients[i].Name != want {
+					t.Errorf("ingredient %d = %q, want %q", i, recipe.Ingredients[i].Name, want)
+				}
+			}
+			for i, want := range tt.wantSteps {
+				if recipe.Steps[i].Content != want {
+					t.Errorf("step %d = %q, want %q", i+1, recipe.Steps[i].Content, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLocalizeRecipeSectionsAndSubRecipes(t *testing.T) {
+	recipe := &domain.Recipe{
+		Title:       "Soup",
+		Locale:      "en",
+		Ingredients: domain.Ingredients{{Name: "water"}},
+		Sections: domain.Sections{
+			{Name: "Garnish", Ingredients: domain.Ingredients{{Name: "parsley"}}, Steps: domain.Steps{{Order: 1, Content: "Chop."}}},
+			{Name: "Stock", Recipe: &domain.Recipe{
+				Title:        "Stock",
+				Locale:       "en",
+				Ingredients:  domain.Ingredients{{Name: "bones"}},
+				Translations: map[string]domain.RecipeTranslation{"de": {Title: "Brühe", Ingredients: []string{"Knochen"}, SourceIngredients: []string{"bones"}}},
+			}},
+		},
+		Translations: map[string]domain.RecipeTranslation{"de": {
+			Title:             "Suppe",
+			Ingredients:       []string{"Wasser", "Petersilie"},
+			Steps:             []string{"Hacken."},
+			SourceIngredients: []string{"water", "parsley"},
+			SourceSteps:       []string{"Chop."},
+		}},
+	}
+
+	service := &recipeService{}
+	service.LocalizeRecipe(recipe, []string{"de"})
+
+	garnish := recipe.Sections[0]
+	if garnish.Ingredients[0].Name != "Petersilie" || garnish.Steps[0].Content != "Hacken." {
+		t.Errorf("section = %q / %q, want Petersilie / Hacken.", garnish.Ingredients[0].Name, garnish.Steps[0].Content)
+	}
+	stock := recipe.Sections[1].Recipe
+	if stock.ServedLocale != "de" || stock.Title != "Brühe" || stock.Ingredients[0].Name != "Knochen" {
+		t.Errorf("sub-recipe served %q as %q with %q, want de, Brühe and Knochen", stock.ServedLocale, stock.Title, stock.Ingredients[0].Name)
+	}
+}
@@ -0,0 +1,431 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// schemaRecipe is the subset of a schema.org Recipe that can be mapped onto a domain.Recipe
+type schemaRecipe struct {
+	Name         string
+	Description  string
+	Images       []string
+	Ingredients  []string
+	Instructions []string
+	TotalTime    string
+	PrepTime     string
+	CookTime     string
+	Yield        string
+}
+
+// extractSchemaRecipe looks for a schema.org Recipe in the page, preferring JSON-LD over microdata
+func extractSchemaRecipe(page []byte) (*schemaRecipe, error) {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, script := range findJSONLDScripts(doc) {
+		var data interface{}
+		if err := json.Unmarshal([]byte(script), &data); err != nil {
+			continue
+		}
+		if node := findJSONLDRecipe(data); node != nil {
+			return recipeFromJSONLD(node), nil
+		}
+	}
+
+	if node := findMicrodataRecipe(doc); node != nil {
+		return recipeFromMicrodata(node), nil
+	}
+
+	return nil, nil
+}
+
+func findJSONLDScripts(n *html.Node) []string {
+	var scripts []string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "script" &&
+			strings.EqualFold(attr(n, "type"), "application/ld+json") && n.FirstChild != nil {
+			scripts = append(scripts, n.FirstChild.Data)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return scripts
+}
+
+// findJSONLDRecipe searches arrays, @graph containers and nested objects for a node typed Recipe
+func findJSONLDRecipe(data interface{}) map[string]interface{} {
+	switch v := data.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if node := findJSONLDRecipe(item); node != nil {
+				return node
+			}
+		}
+	case map[string]interface{}:
+		if hasSchemaType(v["@type"], "Recipe") {
+			return v
+		}
+		if graph, ok := v["@graph"]; ok {
+			return findJSONLDRecipe(graph)
+		}
+		if entity, ok := v["mainEntity"]; ok {
+			return findJSONLDRecipe(entity)
+		}
+	}
+	return nil
+}
+
+func hasSchemaType(value interface{}, want string) bool {
+	switch t := value.(type) {
+	case string:
+		return t == want || strings.HasSuffix(t, "/"+want)
+	case []interface{}:
+		for _, item := range t {
+			if hasSchemaType(item, want) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func recipeFromJSONLD(node map[string]interface{}) *schemaRecipe {
+	recipe := &schemaRecipe{
+		Name:        cleanText(jsonString(node["name"])),
+		Description: cleanText(jsonString(node["description"])),
+		TotalTime:   jsonString(node["totalTime"]),
+		PrepTime:    jsonString(node["prepTime"]),
+		CookTime:    jsonString(node["cookTime"]),
+		Yield:       jsonString(node["recipeYield"]),
+		Images:      jsonImages(node["image"]),
+	}
+
+	ingredients := node["recipeIngredient"]
+	if ingredients == nil {
+		ingredients = node["ingredients"]
+	}
+	for _, line := range jsonStrings(ingredients) {
+		if line = cleanText(line); line != "" {
+			recipe.Ingredients = append(recipe.Ingredients, line)
+		}
+	}
+
+	recipe.Instructions = jsonInstructions(node["recipeInstructions"])
+
+	return recipe
+}
+
+// jsonString returns the first textual value of a JSON-LD property
+func jsonString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []interface{}:
+		for _, item := range v {
+			if s := jsonString(item); s != "" {
+				return s
+			}
+		}
+	case map[string]interface{}:
+		if s := jsonString(v["@value"]); s != "" {
+			return s
+		}
+		return jsonString(v["text"])
+	}
+	return ""
+}
+
+func jsonStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := jsonString(item); s != "" {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+// jsonImages accepts a URL, a list of URLs or ImageObject nodes
+func jsonImages(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var result []string
+		for _, item := range v {
+			result = append(result, jsonImages(item)...)
+		}
+		return result
+	case map[string]interface{}:
+		if url := jsonString(v["url"]); url != "" {
+			return []string{url}
+		}
+		if url := jsonString(v["contentUrl"]); url != "" {
+			return []string{url}
+		}
+	}
+	return nil
+}
+
+// jsonInstructions flattens plain text, HowToStep and HowToSection instructions into step texts
+func jsonInstructions(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return splitInstructionText(v)
+	case []interface{}:
+		var steps []string
+		for _, item := range v {
+			steps = append(steps, jsonInstructions(item)...)
+		}
+		return steps
+	case map[string]interface{}:
+		if elements, ok := v["itemListElement"]; ok {
+			return jsonInstructions(elements)
+		}
+		text := jsonString(v["text"])
+		if text == "" {
+			text = jsonString(v["name"])
+		}
+		if text = cleanText(text); text != "" {
+			return []string{text}
+		}
+	}
+	return nil
+}
+
+func splitInstructionText(text string) []string {
+	var steps []string
+	for _, line := range strings.Split(text, "\n") {
+		if line = cleanText(line); line != "" {
+			steps = append(steps, line)
+		}
+	}
+	return steps
+}
+
+func findMicrodataRecipe(n *html.Node) *html.Node {
+	if n.Type == html.ElementNode && hasAttr(n, "itemscope") {
+		for _, itemType := range strings.Fields(attr(n, "itemtype")) {
+			if strings.HasSuffix(itemType, "schema.org/Recipe") {
+				return n
+			}
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findMicrodataRecipe(c); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func recipeFromMicrodata(root *html.Node) *schemaRecipe {
+	recipe := &schemaRecipe{}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+
+			for _, prop := range strings.Fields(attr(c, "itemprop")) {
+				value := cleanText(microdataValue(c))
+				switch prop {
+				case "name":
+					if recipe.Name == "" {
+						recipe.Name = value
+					}
+				case "description":
+					if recipe.Description == "" {
+						recipe.Description = value
+					}
+				case "image":
+					if value != "" {
+						recipe.Images = append(recipe.Images, value)
+					}
+				case "recipeIngredient", "ingredients":
+					if value != "" {
+						recipe.Ingredients = append(recipe.Ingredients, value)
+					}
+				case "recipeInstructions":
+					if hasAttr(c, "itemscope") {
+						recipe.Instructions = append(recipe.Instructions, microdataSteps(c)...)
+					} else {
+						recipe.Instructions = append(recipe.Instructions, splitInstructionText(textContent(c))...)
+					}
+				case "totalTime":
+					recipe.TotalTime = value
+				case "prepTime":
+					recipe.PrepTime = value
+				case "cookTime":
+					recipe.CookTime = value
+				case "recipeYield":
+					recipe.Yield = value
+				}
+			}
+
+			// Nested items belong to another entity, except the instructions handled above
+			if !hasAttr(c, "itemscope") {
+				walk(c)
+			}
+		}
+	}
+	walk(root)
+
+	return recipe
+}
+
+// microdataSteps reads the text of HowToStep items nested in an instructions item
+func microdataSteps(n *html.Node) []string {
+	var steps []string
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+			if strings.Contains(attr(c, "itemprop"), "text") {
+				if text := cleanText(textContent(c)); text != "" {
+					steps = append(steps, text)
+				}
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+
+	if len(steps) == 0 {
+		return splitInstructionText(textContent(n))
+	}
+	return steps
+}
+
+func microdataValue(n *html.Node) string {
+	switch n.Data {
+	case "meta":
+		return attr(n, "content")
+	case "img", "audio", "video", "source":
+		return attr(n, "src")
+	case "a", "link":
+		return attr(n, "href")
+	case "time":
+		if dt := attr(n, "datetime"); dt != "" {
+			return dt
+		}
+	}
+	if content := attr(n, "content"); content != "" {
+		return content
+	}
+	return textContent(n)
+}
+
+func textContent(n *html.Node) string {
+	var buf strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			buf.WriteString(n.Data)
+		case n.Type == html.ElementNode && (n.Data == "br" || n.Data == "p" || n.Data == "li"):
+			buf.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return buf.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+var whitespaceRegexp = regexp.MustCompile(`\s+`)
+
+// cleanText strips markup left inside JSON-LD strings and collapses whitespace
+func cleanText(s string) string {
+	if strings.ContainsAny(s, "<&") {
+		if nodes, err := html.ParseFragment(strings.NewReader(s), nil); err == nil {
+			var buf strings.Builder
+			for _, n := range nodes {
+				buf.WriteString(textContent(n))
+			}
+			s = buf.String()
+		}
+	}
+	return strings.TrimSpace(whitespaceRegexp.ReplaceAllString(s, " "))
+}
+
+var isoDurationRegexp = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// parseISODuration converts an ISO 8601 duration such as "PT1H30M" into whole minutes
+func parseISODuration(s string) (int, error) {
+	matches := isoDurationRegexp.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if matches == nil || strings.Join(matches[1:], "") == "" {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+
+	var minutes float64
+	factors := []float64{24 * 60, 60, 1, 1.0 / 60}
+	for i, factor := range factors {
+		if matches[i+1] == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(matches[i+1], 64)
+		if err != nil {
+			return 0, err
+		}
+		minutes += value * factor
+	}
+
+	return int(minutes + 0.5), nil
+}
+
+var leadingNumberRegexp = regexp.MustCompile(`\d+`)
+
+// parseYield extracts the serving count from values such as "4", "4 servings" or "Serves 4-6"
+func parseYield(s string) int {
+	match := leadingNumberRegexp.FindString(s)
+	if match == "" {
+		return 0
+	}
+	value, _ := strconv.Atoi(match)
+	return value
+}
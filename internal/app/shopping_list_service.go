@@ -0,0 +1,494 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const defaultShoppingListName = "Shopping list"
+
+type shoppingListService struct {
+	shoppingListRepo     interfaces.ShoppingListRepository
+	recipeService        interfaces.RecipeService
+	collectionRepo       interfaces.CollectionRepository
+	recipeCollectionRepo interfaces.RecipeCollectionRepository
+}
+
+// NewShoppingListService creates a new shopping list service
+func NewShoppingListService(
+	shoppingListRepo interfaces.ShoppingListRepository,
+	recipeService interfaces.RecipeService,
+	collectionRepo interfaces.CollectionRepository,
+	recipeCollectionRepo interfaces.RecipeCollectionRepository) interfaces.ShoppingListService {
+	return &shoppingListService{
+		shoppingListRepo:     shoppingListRepo,
+		recipeService:        recipeService,
+		collectionRepo:       collectionRepo,
+		recipeCollectionRepo: recipeCollectionRepo,
+	}
+}
+
+// recipeIngredients are the scaled ingredients contributed by one recipe
+type recipeIngredients struct {
+	RecipeID    uuid.UUID
+	Ingredients []domain.Ingredient
+}
+
+// GenerateShoppingList builds a shopping list from the given recipes and collection
+func (s *shoppingListService) GenerateShoppingList(ctx context.Context, input interfaces.GenerateShoppingListInput) (*domain.ShoppingList, error) {
+	if len(input.Recipes) == 0 && input.CollectionID == nil {
+		return nil, interfaces.NewValidationError("select at least one recipe or a collection")
+	}
+
+	var sources []recipeIngredients
+	for _, entry := range input.Recipes {
+		recipe, err := s.recipeService.GetRecipe(ctx, entry.RecipeID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, interfaces.NewNotFoundError("recipe not found")
+			}
+			return nil, err
+		}
+
+		source, err := s.scaledRecipeIngredients(ctx, recipe, entry.Servings)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, *source)
+	}
+
+	if input.CollectionID != nil {
+		collectionSources, err := s.collectionIngredients(ctx, *input.CollectionID, input.UserID)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, collectionSources...)
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		name = defaultShoppingListName
+	}
+
+	list := &domain.ShoppingList{
+		BaseModel: &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		},
+		UserID: input.UserID,
+		Name:   name,
+		Items:  mergeShoppingItems(sources),
+	}
+
+	if err := s.shoppingListRepo.Create(ctx, list); err != nil {
+		return nil, err
+	}
+
+	list.Aisles = groupByAisle(list.Items)
+	return list, nil
+}
+
+// collectionIngredients gathers the ingredients of every recipe in a collection owned by the user
+func (s *shoppingListService) collectionIngredients(ctx context.Context, collectionID uuid.UUID, userID uuid.UUID) ([]recipeIngredients, error) {
+	collection, err := s.collectionRepo.GetByID(ctx, collectionID)
+	if err != nil {
+		return nil, interfaces.NewNotFoundError("collection not found")
+	}
+	if collection.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to use this collection")
+	}
+
+	var sources []recipeIngredients
+	cursor := uuid.Nil
+	for {
+		recipes, nextCursor, err := s.recipeCollectionRepo.GetRecipesByCollectionID(ctx, collectionID, 100, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range recipes {
+			source, err := s.scaledRecipeIngredients(ctx, &recipes[i], 0)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, *source)
+		}
+
+		if nextCursor == uuid.Nil {
+			return sources, nil
+		}
+		cursor = nextCursor
+	}
+}
+
+// scaledRecipeIngredients collects a recipe's ingredients, including its sections and sub-recipes,
+// scaled to the requested servings. Zero servings keeps the recipe's own serving size.
+func (s *shoppingListService) scaledRecipeIngredients(ctx context.Context, recipe *domain.Recipe, servings int) (*recipeIngredients, error) {
+	ingredients, err := expandScaledIngredients(ctx, s.recipeService, recipe, servings)
+	if err != nil {
+		return nil, err
+	}
+	return &recipeIngredients{RecipeID: recipe.ID, Ingredients: ingredients}, nil
+}
+
+// expandScaledIngredients expands the recipe's sub-recipes and returns all its ingredients scaled to the servings
+func expandScaledIngredients(ctx context.Context, recipeService interfaces.RecipeService, recipe *domain.Recipe, servings int) ([]domain.Ingredient, error) {
+	if err := recipeService.ExpandSections(ctx, recipe); err != nil {
+		return nil, err
+	}
+
+	scale := 1.0
+	if servings > 0 && recipe.ServingSize > 0 {
+		scale = float64(servings) / float64(recipe.ServingSize)
+	}
+
+	ingredients := collectRecipeIngredients(recipe)
+	for i := range ingredients {
+		ingredients[i].Amount *= scale
+		ingredients[i].AmountMax *= scale
+	}
+	return ingredients, nil
+}
+
+// collectRecipeIngredients flattens the ingredients of a recipe, its sections and expanded sub-recipes
+func collectRecipeIngredients(recipe *domain.Recipe) []domain.Ingredient {
+	ingredients := append([]domain.Ingredient{}, recipe.Ingredients...)
+	for _, section := range recipe.Sections {
+		ingredients = append(ingredients, section.Ingredients...)
+		if section.Recipe != nil {
+			ingredients = append(ingredients, collectRecipeIngredients(section.Recipe)...)
+		}
+	}
+	return ingredients
+}
+
+// mergeShoppingItems merges matching ingredients across recipes. Amounts are summed when the
+// units can be converted into each other; ingredients in unrelated units stay separate items.
+func mergeShoppingItems(sources []recipeIngredients) []domain.ShoppingListItem {
+	type mergedItem struct {
+		name      string
+		dimension string
+		unit      string // first unit seen, kept when every amount used it
+		mixed     bool
+		amount    float64 // in the dimension's base unit when dimension is set
+		recipeIDs []uuid.UUID
+	}
+
+	var order []string
+	merged := make(map[string]*mergedItem)
+
+	for _, source := range sources {
+		for _, ingredient := range source.Ingredients {
+			name := strings.TrimSpace(ingredient.Name)
+			if name == "" {
+				continue
+			}
+
+			unit := normalizeUnit(ingredient.Unit)
+			amount := ingredient.Amount
+			dimension := ""
+			group := ingredientKey(name) + "|" + unit
+			if info, ok := convertibleUnits[unit]; ok {
+				dimension = info.Dimension
+				amount *= info.Factor
+				group = ingredientKey(name) + "|" + dimension
+			}
+
+			item, ok := merged[group]
+			if !ok {
+				item = &mergedItem{name: name, dimension: dimension, unit: unit}
+				merged[group] = item
+				order = append(order, group)
+			}
+			if item.unit != unit {
+				item.mixed = true
+			}
+			item.amount += amount
+			if !containsUUID(item.recipeIDs, source.RecipeID) {
+				item.recipeIDs = append(item.recipeIDs, source.RecipeID)
+			}
+		}
+	}
+
+	items := make([]domain.ShoppingListItem, 0, len(order))
+	for _, group := range order {
+		item := merged[group]
+		amount, unit := item.amount, item.unit
+		if item.dimension != "" {
+			if item.mixed {
+				amount, unit = readableBaseAmount(item.amount, item.dimension)
+			} else {
+				amount /= convertibleUnits[item.unit].Factor
+			}
+		}
+
+		items = append(items, domain.ShoppingListItem{
+			BaseModel: &common.BaseModel{
+				ID:        uuid.New(),
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+				Status:    1,
+			},
+			Name:      item.name,
+			Amount:    roundAmount(amount),
+			Unit:      unit,
+			Aisle:     classifyAisle(item.name),
+			RecipeIDs: item.recipeIDs,
+		})
+	}
+	return items
+}
+
+// groupByAisle groups items by aisle in store order; items within an aisle are sorted by name
+func groupByAisle(items []domain.ShoppingListItem) []domain.ShoppingAisle {
+	byAisle := make(map[string][]domain.ShoppingListItem)
+	var names []string
+	for _, item := range items {
+		aisle := item.Aisle
+		if aisle == "" {
+			aisle = aisleOther
+		}
+		if _, ok := byAisle[aisle]; !ok {
+			names = append(names, aisle)
+		}
+		byAisle[aisle] = append(byAisle[aisle], item)
+	}
+	sortAisleNames(names)
+
+	aisles := make([]domain.ShoppingAisle, 0, len(names))
+	for _, name := range names {
+		aisleItems := byAisle[name]
+		sort.SliceStable(aisleItems, func(i, j int) bool {
+			return strings.ToLower(aisleItems[i].Name) < strings.ToLower(aisleItems[j].Name)
+		})
+		aisles = append(aisles, domain.ShoppingAisle{Name: name, Items: aisleItems})
+	}
+	return aisles
+}
+
+// GetShoppingList gets a shopping list owned by the user
+func (s *shoppingListService) GetShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ShoppingList, error) {
+	list, err := s.ownedList(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	list.Aisles = groupByAisle(list.Items)
+	return list, nil
+}
+
+// GetSharedShoppingList gets a shopping list by its share token
+func (s *shoppingListService) GetSharedShoppingList(ctx context.Context, token string) (*domain.ShoppingList, error) {
+	list, err := s.shoppingListRepo.GetByShareToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("shopping list not found")
+		}
+		return nil, err
+	}
+	list.Aisles = groupByAisle(list.Items)
+	return list, nil
+}
+
+// GetUserShoppingLists gets all shopping lists of a user
+func (s *shoppingListService) GetUserShoppingLists(ctx context.Context, userID uuid.UUID) ([]domain.ShoppingList, error) {
+	lists, err := s.shoppingListRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].Aisles = []domain.ShoppingAisle{}
+	}
+	return lists, nil
+}
+
+// DeleteShoppingList deletes a shopping list owned by the user
+func (s *shoppingListService) DeleteShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.ownedList(ctx, id, userID); err != nil {
+		return err
+	}
+	return s.shoppingListRepo.Delete(ctx, id)
+}
+
+// AddItem adds a manual item to a shopping list
+func (s *shoppingListService) AddItem(ctx context.Context, listID uuid.UUID, userID uuid.UUID, input interfaces.AddShoppingListItemInput) (*domain.ShoppingListItem, error) {
+	if _, err := s.ownedList(ctx, listID, userID); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, interfaces.NewValidationError("item name is required")
+	}
+
+	aisle := strings.TrimSpace(input.Aisle)
+	if aisle == "" {
+		aisle = classifyAisle(name)
+	}
+
+	item := &domain.ShoppingListItem{
+		BaseModel: &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		},
+		ListID: listID,
+		Name:   name,
+		Amount: input.Amount,
+		Unit:   normalizeUnit(input.Unit),
+		Aisle:  aisle,
+		Manual: true,
+	}
+
+	if err := s.shoppingListRepo.AddItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateItem updates an item of a shopping list owned by the user
+func (s *shoppingListService) UpdateItem(ctx context.Context, listID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, input interfaces.UpdateShoppingListItemInput) (*domain.ShoppingListItem, error) {
+	item, err := s.ownedItem(ctx, listID, itemID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" {
+			return nil, interfaces.NewValidationError("item name is required")
+		}
+		item.Name = name
+	}
+	if input.Amount != nil {
+		item.Amount = *input.Amount
+	}
+	if input.Unit != nil {
+		item.Unit = normalizeUnit(*input.Unit)
+	}
+	if input.Aisle != nil {
+		item.Aisle = strings.TrimSpace(*input.Aisle)
+		if item.Aisle == "" {
+			item.Aisle = classifyAisle(item.Name)
+		}
+	}
+	if input.Checked != nil {
+		item.Checked = *input.Checked
+	}
+	item.UpdatedAt = time.Now()
+
+	if err := s.shoppingListRepo.UpdateItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteItem removes an item from a shopping list owned by the user
+func (s *shoppingListService) DeleteItem(ctx context.Context, listID uuid.UUID, itemID uuid.UUID, userID uuid.UUID) error {
+	if _, err := s.ownedItem(ctx, listID, itemID, userID); err != nil {
+		return err
+	}
+	return s.shoppingListRepo.DeleteItem(ctx, itemID)
+}
+
+// ShareShoppingList creates a share token for the list; sharing an already shared list keeps its token
+func (s *shoppingListService) ShareShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ShoppingList, error) {
+	list, err := s.ownedList(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.ShareToken == "" {
+		token, err := generateShareToken()
+		if err != nil {
+			return nil, err
+		}
+		list.ShareToken = token
+		if err := s.shoppingListRepo.Update(ctx, list); err != nil {
+			return nil, err
+		}
+	}
+
+	list.Aisles = groupByAisle(list.Items)
+	return list, nil
+}
+
+// UnshareShoppingList revokes the share token of the list
+func (s *shoppingListService) UnshareShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) error {
+	list, err := s.ownedList(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	list.ShareToken = ""
+	return s.shoppingListRepo.Update(ctx, list)
+}
+
+func (s *shoppingListService) ownedList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ShoppingList, error) {
+	list, err := s.shoppingListRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("shopping list not found")
+		}
+		return nil, err
+	}
+	if list.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to access this shopping list")
+	}
+	return list, nil
+}
+
+func (s *shoppingListService) ownedItem(ctx context.Context, listID uuid.UUID, itemID uuid.UUID, userID uuid.UUID) (*domain.ShoppingListItem, error) {
+	if _, err := s.ownedList(ctx, listID, userID); err != nil {
+		return nil, err
+	}
+
+	item, err := s.shoppingListRepo.GetItem(ctx, itemID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("shopping list item not found")
+		}
+		return nil, err
+	}
+	if item.ListID != listID {
+		return nil, interfaces.NewNotFoundError("shopping list item not found")
+	}
+	return item, nil
+}
+
+// generateShareToken returns a random, URL safe token
+func generateShareToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func containsUUID(values []uuid.UUID, value uuid.UUID) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// roundAmount keeps at most three decimals so converted amounts stay readable
+func roundAmount(amount float64) float64 {
+	return math.Round(amount*1000) / 1000
+}
@@ -0,0 +1,321 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Weights of the similarity signals; they add up to 1
+const (
+	similarityIngredientWeight = 0.4
+	similarityTagWeight        = 0.2
+	similarityCategoryWeight   = 0.15
+	similarityCoSaveWeight     = 0.15
+	similarityTimeWeight       = 0.1
+)
+
+const (
+	// similarNeighbourCount is how many neighbours are stored per recipe
+	similarNeighbourCount = 20
+	defaultSimilarRecipes = 10
+	// minSimilarityScore drops neighbours that only share a category or a similar cooking time
+	minSimilarityScore = 0.15
+	// similarityBatchSize is how many recipes are loaded at a time when recomputing
+	similarityBatchSize = 500
+	// similarityCandidateRecipes caps how many recipes are scored when a recipe has no stored neighbours yet
+	similarityCandidateRecipes = 200
+	// maxSimilarityPostings skips features shared by so many recipes that they say little about similarity
+	maxSimilarityPostings = 2000
+)
+
+// similarityIgnoredIngredients are staples that appear in too many recipes to make them similar
+var similarityIgnoredIngredients = map[string]bool{
+	"salt":          true,
+	"pepper":        true,
+	"black pepper":  true,
+	"water":         true,
+	"oil":           true,
+	"olive oil":     true,
+	"vegetable oil": true,
+	"sugar":         true,
+}
+
+type similarRecipeService struct {
+	similarityRepo       interfaces.RecipeSimilarityRepository
+	recipeRepo           interfaces.RecipeRepository
+	recipeCollectionRepo interfaces.RecipeCollectionRepository
+}
+
+// NewSimilarRecipeService creates a new similar recipe service
+func NewSimilarRecipeService(
+	similarityRepo interfaces.RecipeSimilarityRepository,
+	recipeRepo interfaces.RecipeRepository,
+	recipeCollectionRepo interfaces.RecipeCollectionRepository) interfaces.SimilarRecipeService {
+	return &similarRecipeService{
+		similarityRepo:       similarityRepo,
+		recipeRepo:           recipeRepo,
+		recipeCollectionRepo: recipeCollectionRepo,
+	}
+}
+
+// similarityFeatures holds what a recipe is compared on
+type similarityFeatures struct {
+	id          uuid.UUID
+	categoryID  uuid.UUID
+	time        int
+	ingredients map[string]bool
+	tags        map[string]bool
+	collections map[uuid.UUID]bool
+}
+
+func recipeSimilarityFeatures(recipe *domain.Recipe, collectionIDs []uuid.UUID) similarityFeatures {
+	features := similarityFeatures{
+		id:          recipe.ID,
+		categoryID:  recipe.CategoryID,
+		time:        recipe.Time,
+		ingredients: make(map[string]bool),
+		tags:        make(map[string]bool),
+		collections: make(map[uuid.UUID]bool),
+	}
+	for _, ingredient := range collectRecipeIngredients(recipe) {
+		key := ingredientKey(ingredient.Name)
+		if key != "" && !similarityIgnoredIngredients[key] {
+			features.ingredients[key] = true
+		}
+	}
+	for _, tag := range recipe.Tags {
+		features.tags[tag] = true
+	}
+	for _, id := range collectionIDs {
+		features.collections[id] = true
+	}
+	return features
+}
+
+// similarityScore combines the similarity signals of two recipes into a score between 0 and 1
+func similarityScore(a, b similarityFeatures) float64 {
+	score := similarityIngredientWeight*jaccard(a.ingredients, b.ingredients) +
+		similarityTagWeight*jaccard(a.tags, b.tags)
+
+	if a.categoryID != uuid.Nil && a.categoryID == b.categoryID {
+		score += similarityCategoryWeight
+	}
+
+	// Co-saves: cosine similarity of the sets of collections the recipes were saved to
+	if len(a.collections) > 0 && len(b.collections) > 0 {
+		shared := 0
+		for id := range a.collections {
+			if b.collections[id] {
+				shared++
+			}
+		}
+		score += similarityCoSaveWeight * float64(shared) / math.Sqrt(float64(len(a.collections)*len(b.collections)))
+	}
+
+	if a.time > 0 && b.time > 0 {
+		longer := math.Max(float64(a.time), float64(b.time))
+		score += similarityTimeWeight * (1 - math.Abs(float64(a.time-b.time))/longer)
+	}
+
+	return roundAmount(score)
+}
+
+func jaccard(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for key := range a {
+		if b[key] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// GetSimilarRecipes gets the recipes most similar to a recipe. Stored neighbours are used when
+// available; a recipe the background job has not seen yet is compared with recipes sharing its ingredients.
+func (s *similarRecipeService) GetSimilarRecipes(ctx context.Context, recipeID uuid.UUID, limit int) ([]domain.SimilarRecipe, error) {
+	if limit <= 0 {
+		limit = defaultSimilarRecipes
+	}
+	if limit > similarNeighbourCount {
+		limit = similarNeighbourCount
+	}
+
+	recipe, err := s.recipeRepo.GetRecipe(ctx, recipeID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found")
+		}
+		return nil, err
+	}
+
+	neighbours, err := s.similarityRepo.GetByRecipeID(ctx, recipeID, similarNeighbourCount)
+	if err != nil {
+		return nil, err
+	}
+	if len(neighbours) == 0 {
+		return s.computeSimilarRecipes(ctx, recipe, limit)
+	}
+
+	similar := make([]domain.SimilarRecipe, 0, limit)
+	for _, neighbour := range neighbours {
+		if len(similar) == limit {
+			break
+		}
+		neighbourRecipe, err := s.recipeRepo.GetRecipe(ctx, neighbour.SimilarRecipeID)
+		if err != nil {
+			// Skip recipes deleted since the neighbours were computed
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		similar = append(similar, domain.SimilarRecipe{Recipe: neighbourRecipe, Score: neighbour.Score})
+	}
+	return similar, nil
+}
+
+// computeSimilarRecipes scores the recipes sharing an ingredient with the recipe. Co-saves are left
+// out since they would need the collections of every candidate.
+func (s *similarRecipeService) computeSimilarRecipes(ctx context.Context, recipe *domain.Recipe, limit int) ([]domain.SimilarRecipe, error) {
+	features := recipeSimilarityFeatures(recipe, nil)
+	if len(features.ingredients) == 0 {
+		return []domain.SimilarRecipe{}, nil
+	}
+
+	names := make([]string, 0, len(features.ingredients))
+	for name := range features.ingredients {
+		names = append(names, name)
+	}
+	candidates, err := s.recipeRepo.FindRecipesByIngredientNames(ctx, names, similarityCandidateRecipes)
+	if err != nil {
+		return nil, err
+	}
+
+	similar := []domain.SimilarRecipe{}
+	for i := range candidates {
+		if candidates[i].ID == recipe.ID {
+			continue
+		}
+		score := similarityScore(features, recipeSimilarityFeatures(&candidates[i], nil))
+		if score >= minSimilarityScore {
+			similar = append(similar, domain.SimilarRecipe{Recipe: &candidates[i], Score: score})
+		}
+	}
+
+	sort.SliceStable(similar, func(i, j int) bool {
+		return similar[i].Score > similar[j].Score
+	})
+	if len(similar) > limit {
+		similar = similar[:limit]
+	}
+	return similar, nil
+}
+
+// RecomputeSimilarities recomputes the nearest neighbours of every recipe. Only recipes sharing an
+// ingredient, a tag or a collection are compared, which keeps the job far from comparing every pair.
+func (s *similarRecipeService) RecomputeSimilarities(ctx context.Context) error {
+	// Whole seconds, so the stored timestamps compare equal whatever the column precision
+	startedAt := time.Now().Truncate(time.Second)
+
+	collectionIDs, err := s.recipeCollectionRepo.GetCollectionIDsForAllRecipes(ctx)
+	if err != nil {
+		return err
+	}
+
+	var recipes []similarityFeatures
+	for offset := 0; ; offset += similarityBatchSize {
+		batch, err := s.recipeRepo.ListRecipes(ctx, offset, similarityBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range batch {
+			recipes = append(recipes, recipeSimilarityFeatures(&batch[i], collectionIDs[batch[i].ID]))
+		}
+		if len(batch) < similarityBatchSize {
+			break
+		}
+	}
+
+	// Inverted index from each feature to the recipes that have it
+	postings := make(map[string][]int)
+	for i, recipe := range recipes {
+		for key := range similarityFeatureKeys(recipe) {
+			postings[key] = append(postings[key], i)
+		}
+	}
+
+	for i, recipe := range recipes {
+		candidates := make(map[int]bool)
+		for key := range similarityFeatureKeys(recipe) {
+			if len(postings[key]) > maxSimilarityPostings {
+				continue
+			}
+			for _, j := range postings[key] {
+				if j != i {
+					candidates[j] = true
+				}
+			}
+		}
+
+		neighbours := make([]domain.RecipeSimilarity, 0, len(candidates))
+		for j := range candidates {
+			score := similarityScore(recipe, recipes[j])
+			if score < minSimilarityScore {
+				continue
+			}
+			neighbours = append(neighbours, domain.RecipeSimilarity{
+				RecipeID:        recipe.id,
+				SimilarRecipeID: recipes[j].id,
+				Score:           score,
+				ComputedAt:      startedAt,
+			})
+		}
+
+		// Ties are broken by ID so repeated runs store the same order
+		sort.Slice(neighbours, func(a, b int) bool {
+			if neighbours[a].Score != neighbours[b].Score {
+				return neighbours[a].Score > neighbours[b].Score
+			}
+			return neighbours[a].SimilarRecipeID.String() < neighbours[b].SimilarRecipeID.String()
+		})
+		if len(neighbours) > similarNeighbourCount {
+			neighbours = neighbours[:similarNeighbourCount]
+		}
+		for rank := range neighbours {
+			neighbours[rank].Rank = rank + 1
+		}
+
+		if err := s.similarityRepo.ReplaceForRecipe(ctx, recipe.id, neighbours); err != nil {
+			return err
+		}
+	}
+
+	// Neighbours of recipes deleted since the last run were not replaced
+	return s.similarityRepo.DeleteComputedBefore(ctx, startedAt)
+}
+
+// similarityFeatureKeys lists the features used to find candidate neighbours
+func similarityFeatureKeys(features similarityFeatures) map[string]bool {
+	keys := make(map[string]bool, len(features.ingredients)+len(features.tags)+len(features.collections))
+	for ingredient := range features.ingredients {
+		keys["ingredient:"+ingredient] = true
+	}
+	for tag := range features.tags {
+		keys["tag:"+tag] = true
+	}
+	for id := range features.collections {
+		keys["collection:"+id.String()] = true
+	}
+	return keys
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// trashRetentionDays is how long deleted recipes, collections and categories can be restored
+const trashRetentionDays = 30
+
+type trashService struct {
+	trashRepo interfaces.TrashRepository
+}
+
+// NewTrashService creates a new trash service
+func NewTrashService(trashRepo interfaces.TrashRepository) interfaces.TrashService {
+	return &trashService{
+		trashRepo: trashRepo,
+	}
+}
+
+// GetTrash gets the user's deleted recipes and collections
+func (s *trashService) GetTrash(ctx context.Context, userID uuid.UUID) (*domain.Trash, error) {
+	recipes, err := s.trashRepo.GetTrashedRecipes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	collections, err := s.trashRepo.GetTrashedCollections(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Trash{
+		Recipes:       recipes,
+		Collections:   collections,
+		RetentionDays: trashRetentionDays,
+	}, nil
+}
+
+// RestoreRecipe restores one of the user's deleted recipes
+func (s *trashService) RestoreRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Recipe, error) {
+	recipe, err := s.trashRepo.GetTrashedRecipe(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found in trash")
+		}
+		return nil, err
+	}
+	if recipe.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to restore this recipe")
+	}
+
+	if err := s.trashRepo.RestoreRecipe(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("recipe not found in trash")
+		}
+		return nil, err
+	}
+	recipe.Status = 1
+	recipe.TrashedAt = nil
+	recipe.Version++
+	return recipe, nil
+}
+
+// RestoreCollection restores one of the user's deleted collections
+func (s *trashService) RestoreCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Collection, error) {
+	collection, err := s.trashRepo.GetTrashedCollection(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("collection not found in trash")
+		}
+		return nil, err
+	}
+	if collection.UserID != userID {
+		return nil, interfaces.NewUnauthorizedError("unauthorized to restore this collection")
+	}
+
+	if err := s.trashRepo.RestoreCollection(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("collection not found in trash")
+		}
+		return nil, err
+	}
+	collection.Status = 1
+	collection.TrashedAt = nil
+	collection.Version++
+	return collection, nil
+}
+
+// GetTrashedCategories gets the deleted categories
+func (s *trashService) GetTrashedCategories(ctx context.Context) ([]domain.Category, error) {
+	return s.trashRepo.GetTrashedCategories(ctx)
+}
+
+// RestoreCategory restores a deleted category
+func (s *trashService) RestoreCategory(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
+	category, err := s.trashRepo.GetTrashedCategory(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("category not found in trash")
+		}
+		return nil, err
+	}
+
+	if err := s.trashRepo.RestoreCategory(ctx, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, interfaces.NewNotFoundError("category not found in trash")
+		}
+		return nil, err
+	}
+	category.Status = 1
+	category.TrashedAt = nil
+	category.Version++
+	return category, nil
+}
+
+// PurgeExpired permanently deletes the items deleted more than trashRetentionDays ago
+func (s *trashService) PurgeExpired(ctx context.Context) (*domain.TrashPurgeResult, error) {
+	return s.trashRepo.PurgeBefore(ctx, time.Now().AddDate(0, 0, -trashRetentionDays))
+}
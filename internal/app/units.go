@@ -0,0 +1,90 @@
+package app
+
+import (
+	"strings"
+)
+
+// Unit dimensions; amounts can only be converted between units of the same dimension
+const (
+	dimensionMass   = "mass"
+	dimensionVolume = "volume"
+)
+
+// unitInfo describes a unit by its dimension and its size in the dimension's base unit (g or ml)
+type unitInfo struct {
+	Dimension string
+	Factor    float64
+}
+
+var convertibleUnits = map[string]unitInfo{
+	"mg":    {dimensionMass, 0.001},
+	"g":     {dimensionMass, 1},
+	"kg":    {dimensionMass, 1000},
+	"oz":    {dimensionMass, 28.349523125},
+	"lb":    {dimensionMass, 453.59237},
+	"ml":    {dimensionVolume, 1},
+	"cl":    {dimensionVolume, 10},
+	"dl":    {dimensionVolume, 100},
+	"l":     {dimensionVolume, 1000},
+	"tsp":   {dimensionVolume, 4.92892159375},
+	"tbsp":  {dimensionVolume, 14.78676478125},
+	"fl oz": {dimensionVolume, 29.5735295625},
+	"cup":   {dimensionVolume, 236.5882365},
+	"pint":  {dimensionVolume, 473.176473},
+	"quart": {dimensionVolume, 946.352946},
+	"gal":   {dimensionVolume, 3785.411784},
+}
+
+// extraUnitSpellings complements knownUnits with spellings only seen in stored ingredients
+var extraUnitSpellings = map[string]string{
+	"gr": "g", "kgs": "kg",
+	"mls": "ml", "centiliter": "cl", "centilitre": "cl", "deciliter": "dl", "decilitre": "dl",
+	"tsps": "tsp", "tbs": "tbsp", "tbsps": "tbsp", "tbl": "tbsp",
+	"floz": "fl oz", "fl. oz": "fl oz", "fluid ounce": "fl oz", "fluid ounces": "fl oz",
+	"pt": "pint", "pints": "pint", "qt": "quart", "quarts": "quart",
+	"gallon": "gal", "gallons": "gal",
+	"pc": "piece", "pcs": "piece",
+}
+
+// normalizeUnit returns the canonical spelling of a unit, or the lowercased unit when it is unknown
+func normalizeUnit(unit string) string {
+	unit = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(unit), "."))
+	if canonical, ok := knownUnits[unit]; ok {
+		return canonical
+	}
+	if canonical, ok := extraUnitSpellings[unit]; ok {
+		return canonical
+	}
+	return unit
+}
+
+// convertAmount converts an amount between two units of the same dimension
+func convertAmount(amount float64, from, to string) (float64, bool) {
+	from, to = normalizeUnit(from), normalizeUnit(to)
+	if from == to {
+		return amount, true
+	}
+	fromInfo, ok1 := convertibleUnits[from]
+	toInfo, ok2 := convertibleUnits[to]
+	if !ok1 || !ok2 || fromInfo.Dimension != toInfo.Dimension {
+		return 0, false
+	}
+	return amount * fromInfo.Factor / toInfo.Factor, true
+}
+
+// readableBaseAmount picks a display unit for an amount expressed in a dimension's base unit
+func readableBaseAmount(amount float64, dimension string) (float64, string) {
+	switch dimension {
+	case dimensionMass:
+		if amount >= 1000 {
+			return amount / 1000, "kg"
+		}
+		return amount, "g"
+	case dimensionVolume:
+		if amount >= 1000 {
+			return amount / 1000, "l"
+		}
+		return amount, "ml"
+	}
+	return amount, ""
+}
@@ -5,6 +5,7 @@ import (
 	"cookaholic/internal/common"
 	"cookaholic/internal/domain"
 	"cookaholic/internal/interfaces"
+	"fmt"
 	"log"
 	"time"
 
@@ -12,6 +13,9 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// minPasswordLength matches the min=6 binding on CreateUserInput
+const minPasswordLength = 6
+
 type UserService struct {
 	repo     interfaces.UserRepository
 	eventBus interfaces.EventBus
@@ -80,7 +84,7 @@ func (s *UserService) GetByID(ctx context.Context, id uuid.UUID) (*domain.User,
 	return user, nil
 }
 
-func (s *UserService) Update(ctx context.Context, id uuid.UUID, input interfaces.UpdateUserInput) (*domain.User, error) {
+func (s *UserService) Update(ctx context.Context, id uuid.UUID, version int64, input interfaces.UpdateUserInput) (*domain.User, error) {
 	user, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -88,6 +92,9 @@ func (s *UserService) Update(ctx context.Context, id uuid.UUID, input interfaces
 	if user == nil {
 		return nil, interfaces.ErrUserNotFound
 	}
+	if version != interfaces.AnyVersion && user.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
 
 	if input.FullName != "" {
 		user.FullName = input.FullName
@@ -116,7 +123,62 @@ func (s *UserService) Update(ctx context.Context, id uuid.UUID, input interfaces
 	return user, nil
 }
 
-func (s *UserService) Delete(ctx context.Context, id uuid.UUID) error {
+// userDocument is the part of a user a merge patch can change. The password is write-only, so it
+// is never in the document being patched and is only changed when the patch sets it.
+type userDocument struct {
+	FullName string       `json:"full_name"`
+	Password string       `json:"password,omitempty"`
+	Avatar   common.Image `json:"avatar"`
+	Bio      string       `json:"bio"`
+}
+
+func (s *UserService) Patch(ctx context.Context, id uuid.UUID, version int64, patch []byte) (*domain.User, error) {
+	user, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, interfaces.ErrUserNotFound
+	}
+	if version != interfaces.AnyVersion && user.Version != version {
+		return nil, interfaces.ErrVersionConflict
+	}
+
+	if patchRemoves(patch, "password") {
+		return nil, interfaces.NewValidationError("password cannot be removed")
+	}
+	document := userDocument{
+		FullName: user.FullName,
+		Avatar:   user.Avatar,
+		Bio:      user.Bio,
+	}
+	var patched userDocument
+	if err := applyMergePatch(document, patch, &patched); err != nil {
+		return nil, err
+	}
+
+	if patched.Password != "" {
+		if len(patched.Password) < minPasswordLength {
+			return nil, interfaces.NewValidationError(fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(patched.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, err
+		}
+		user.Password = string(hashedPassword)
+	}
+	user.FullName = patched.FullName
+	user.Avatar = patched.Avatar
+	user.Bio = patched.Bio
+
+	if err := s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) Delete(ctx context.Context, id uuid.UUID, version int64) error {
 	user, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return err
@@ -124,8 +186,11 @@ func (s *UserService) Delete(ctx context.Context, id uuid.UUID) error {
 	if user == nil {
 		return interfaces.ErrUserNotFound
 	}
+	if version != interfaces.AnyVersion && user.Version != version {
+		return interfaces.ErrVersionConflict
+	}
 
-	return s.repo.Delete(ctx, id)
+	return s.repo.Delete(ctx, id, user.Version)
 }
 
 func (s *UserService) List(ctx context.Context, page, pageSize int) ([]domain.User, error) {
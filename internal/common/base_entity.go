@@ -11,4 +11,5 @@ type BaseEntity struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Status    int       `json:"status" gorm:"column:status;default:1;"`
+	Version   int64     `json:"version" gorm:"not null;default:1"` // bumped by the repository writes that check it
 }
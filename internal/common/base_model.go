@@ -11,4 +11,5 @@ type BaseModel struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Status    int       `json:"status"`
+	Version   int64     `json:"version,omitempty"`
 }
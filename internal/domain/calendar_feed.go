@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// CalendarFeed is a user's secret iCalendar subscription to their meal plan
+type CalendarFeed struct {
+	*common.BaseModel
+	UserID uuid.UUID `json:"user_id"`
+	Token  string    `json:"token"`
+	URL    string    `json:"url"` // subscription URL for calendar apps
+}
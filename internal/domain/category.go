@@ -2,10 +2,13 @@ package domain
 
 import (
 	"cookaholic/internal/common"
+	"time"
 )
 
 type Category struct {
 	*common.BaseModel
 	Name  string       `json:"name"`
 	Image common.Image `json:"image"`
+	// TrashedAt is when the category was deleted
+	TrashedAt *time.Time `json:"trashed_at,omitempty"`
 }
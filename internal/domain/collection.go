@@ -2,6 +2,7 @@ package domain
 
 import (
 	"cookaholic/internal/common"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,5 +13,6 @@ type Collection struct {
 	Description string       `json:"description"`
 	Image       common.Image `json:"image"`
 	UserID      uuid.UUID    `json:"user_id"`
+	TrashedAt   *time.Time   `json:"trashed_at,omitempty"` // when the collection was deleted
 }
 
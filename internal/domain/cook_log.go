@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CookLog records that a user cooked a recipe
+type CookLog struct {
+	*common.BaseModel
+	UserID   uuid.UUID      `json:"user_id"`
+	RecipeID uuid.UUID      `json:"recipe_id"`
+	CookedAt time.Time      `json:"cooked_at"`
+	Notes    string         `json:"notes"`
+	Photos   []common.Image `json:"photos"`
+	Servings int            `json:"servings"` // servings made
+	Recipe   *Recipe        `json:"recipe,omitempty"`
+	// PantryDeduction is returned when logging a cook also deducted the ingredients from the pantry
+	PantryDeduction *PantryCookResult `json:"pantry_deduction,omitempty"`
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Reasons a recipe appears in the home feed
+const (
+	FeedReasonFollowing = "following" // published by a user the reader follows
+	FeedReasonPopular   = "popular"   // popular in a category the reader engages with
+)
+
+// FeedItem is a recipe in a user's home feed
+type FeedItem struct {
+	Recipe      *Recipe   `json:"recipe"`
+	Reason      string    `json:"reason"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// FeedPage is a page of the home feed; an empty NextCursor means the feed has no more items
+type FeedPage struct {
+	Items      []FeedItem `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+// FeedPosition is a position in a list of recipes ordered by publication time, newest first
+type FeedPosition struct {
+	PublishedAt time.Time `json:"t"`
+	RecipeID    uuid.UUID `json:"id"`
+}
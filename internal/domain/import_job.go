@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// States of a bulk import job
+const (
+	ImportJobPending   = "pending"
+	ImportJobRunning   = "running"
+	ImportJobCompleted = "completed"
+	ImportJobFailed    = "failed"
+)
+
+// ImportJob tracks the progress of a bulk recipe import running in the background
+type ImportJob struct {
+	*common.BaseModel
+	UserID       uuid.UUID        `json:"user_id"`
+	Format       string           `json:"format"`
+	State        string           `json:"state"`
+	TotalRows    int              `json:"total_rows"`
+	ImportedRows int              `json:"imported_rows"`
+	FailedRows   int              `json:"failed_rows"`
+	Errors       []ImportRowError `json:"errors"`          // rows that were not imported
+	RecipeIDs    []uuid.UUID      `json:"recipe_ids"`      // recipes created so far
+	Error        string           `json:"error,omitempty"` // why the whole job failed, such as an unreadable file
+	StartedAt    *time.Time       `json:"started_at,omitempty"`
+	FinishedAt   *time.Time       `json:"finished_at,omitempty"`
+}
+
+// ImportRowError reports why a row of an import file was not imported
+type ImportRowError struct {
+	Row     int    `json:"row"` // 1-based, not counting a CSV header
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
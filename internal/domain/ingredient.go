@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// Categories of catalogue ingredients
+const (
+	IngredientCategoryProduce   = "produce"
+	IngredientCategoryBakery    = "bakery"
+	IngredientCategoryMeat      = "meat"
+	IngredientCategorySeafood   = "seafood"
+	IngredientCategoryDairy     = "dairy"
+	IngredientCategoryPantry    = "pantry"
+	IngredientCategorySpices    = "spices"
+	IngredientCategoryFrozen    = "frozen"
+	IngredientCategoryBeverages = "beverages"
+	IngredientCategoryOther     = "other"
+)
+
+// IngredientCategories lists the valid ingredient categories
+var IngredientCategories = []string{
+	IngredientCategoryProduce, IngredientCategoryBakery, IngredientCategoryMeat, IngredientCategorySeafood,
+	IngredientCategoryDairy, IngredientCategoryPantry, IngredientCategorySpices, IngredientCategoryFrozen,
+	IngredientCategoryBeverages, IngredientCategoryOther,
+}
+
+// CanonicalIngredient is an entry of the ingredient catalogue. Recipe ingredient lines are linked
+// to it when a recipe is saved, by its name, plural or any of its synonyms.
+type CanonicalIngredient struct {
+	*common.BaseModel
+	Name     string   `json:"name"`     // canonical name, such as "scallion"
+	Plural   string   `json:"plural"`   // irregular or preferred plural, such as "scallions"
+	Synonyms []string `json:"synonyms"` // other names, such as "spring onion" or "green onion"
+	Category string   `json:"category"`
+	// Density in grams per millilitre, used to convert between weights and volumes
+	Density *float64 `json:"density,omitempty"`
+	// NutrientRef identifies the ingredient in a nutrient database such as USDA FoodData Central
+	NutrientRef string `json:"nutrient_ref,omitempty"`
+	// Nutrition holds the nutrition facts per 100 g
+	Nutrition *Nutrition `json:"nutrition,omitempty"`
+}
+
+// IngredientSuggestion is an autocomplete result; Matched is the name, plural or synonym that matched
+type IngredientSuggestion struct {
+	IngredientID uuid.UUID `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	Category     string    `json:"category"`
+	Matched      string    `json:"matched"`
+}
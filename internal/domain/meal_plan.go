@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// Meal slots a recipe can be planned for
+const (
+	MealSlotBreakfast = "breakfast"
+	MealSlotLunch     = "lunch"
+	MealSlotDinner    = "dinner"
+	MealSlotSnack     = "snack"
+)
+
+// MealSlots lists the meal slots in the order they happen during a day
+var MealSlots = []string{MealSlotBreakfast, MealSlotLunch, MealSlotSnack, MealSlotDinner}
+
+// MealPlanEntry is a recipe planned for a meal slot on a given date
+type MealPlanEntry struct {
+	*common.BaseModel
+	UserID   uuid.UUID `json:"user_id"`
+	RecipeID uuid.UUID `json:"recipe_id"`
+	Date     string    `json:"date"` // YYYY-MM-DD
+	Slot     string    `json:"slot"`
+	Servings int       `json:"servings,omitempty"` // overrides the recipe's serving size when set
+	Recipe   *Recipe   `json:"recipe,omitempty"`
+}
+
+// MealPlanDay holds the entries planned for one date
+type MealPlanDay struct {
+	Date    string          `json:"date"`
+	Entries []MealPlanEntry `json:"entries"`
+}
+
+// MealPlanWeek is the meal plan of a week, Monday to Sunday
+type MealPlanWeek struct {
+	WeekStart string        `json:"week_start"`
+	WeekEnd   string        `json:"week_end"`
+	Days      []MealPlanDay `json:"days"`
+}
+
+// MealPlanSummary totals the cooking time and nutrition of a week's plan
+type MealPlanSummary struct {
+	WeekStart        string           `json:"week_start"`
+	WeekEnd          string           `json:"week_end"`
+	MealCount        int              `json:"meal_count"`
+	TotalTime        int              `json:"total_time"` // cooking time in minutes
+	Nutrition        Nutrition        `json:"nutrition"`  // total for all planned servings
+	DailyNutrition   []DailyNutrition `json:"daily_nutrition"`
+	MissingNutrition []uuid.UUID      `json:"missing_nutrition"` // planned recipes without nutrition facts
+}
+
+// DailyNutrition is the nutrition total of one day of a meal plan
+type DailyNutrition struct {
+	Date      string    `json:"date"`
+	Nutrition Nutrition `json:"nutrition"`
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PantryItem is an ingredient a user has at home
+type PantryItem struct {
+	*common.BaseModel
+	UserID    uuid.UUID  `json:"user_id"`
+	Name      string     `json:"name"`
+	Amount    float64    `json:"amount"`
+	Unit      string     `json:"unit"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// ExpiryNotifiedAt is set once the owner has been warned that the item is about to expire
+	ExpiryNotifiedAt *time.Time `json:"-"`
+}
+
+// PantrySuggestion is a recipe that can be cooked with items from the pantry
+type PantrySuggestion struct {
+	Recipe        *Recipe  `json:"recipe"`
+	MatchedItems  []string `json:"matched_items"`  // pantry items the recipe uses
+	ExpiringItems []string `json:"expiring_items"` // matched items that expire soon
+	Coverage      float64  `json:"coverage"`       // share of the recipe's ingredients found in the pantry
+}
+
+// PantryDeduction records how much of a pantry item was used when cooking a recipe
+type PantryDeduction struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	Name      string    `json:"name"`
+	Amount    float64   `json:"amount"`
+	Unit      string    `json:"unit"`
+	Remaining float64   `json:"remaining"`
+}
+
+// PantryCookResult lists what cooking a recipe took from the pantry and which ingredients were not found
+type PantryCookResult struct {
+	Deducted []PantryDeduction `json:"deducted"`
+	Missing  []Ingredient      `json:"missing"`
+}
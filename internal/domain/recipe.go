@@ -5,6 +5,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,11 +14,34 @@ type Ingredient struct {
 	Name   string  `json:"name"`
 	Amount float64 `json:"amount"`
 	Unit   string  `json:"unit"`
+	// AmountMax is the upper end of a range such as "2-3"; Amount holds the lower end
+	AmountMax float64 `json:"amount_max,omitempty"`
+	// Note holds preparation notes and sizes, such as "sifted" or "14 oz"
+	Note string `json:"note,omitempty"`
+	// IngredientID is the catalogue ingredient the line was linked to when the recipe was saved
+	IngredientID *uuid.UUID `json:"ingredient_id,omitempty"`
 }
 
+// Temperature units accepted on a step
+const (
+	TemperatureCelsius    = "C"
+	TemperatureFahrenheit = "F"
+)
+
+type Temperature struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"` // "C" or "F"
+}
+
+// Step is a single instruction. All fields besides Order and Content are optional,
+// so steps stored before they were introduced still decode unchanged.
 type Step struct {
-	Order   int    `json:"order"`
-	Content string `json:"content"`
+	Order          int            `json:"order"`
+	Content        string         `json:"content"`
+	Duration       int            `json:"duration,omitempty"`        // timer length in seconds
+	Temperature    *Temperature   `json:"temperature,omitempty"`     // oven or cooking temperature
+	Images         []common.Image `json:"images,omitempty"`          // photos illustrating the step
+	IngredientRefs []int          `json:"ingredient_refs,omitempty"` // indexes into Recipe.Ingredients used in this step
 }
 
 // Ingredients type for JSON serialization
@@ -62,6 +86,75 @@ func (s *Steps) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, s)
 }
 
+// Nutrition holds nutrition facts per serving; energy in kcal, sodium in mg and the rest in grams
+type Nutrition struct {
+	Calories      float64 `json:"calories"`
+	Protein       float64 `json:"protein"`
+	Carbohydrates float64 `json:"carbohydrates"`
+	Fat           float64 `json:"fat"`
+	Fiber         float64 `json:"fiber"`
+	Sugar         float64 `json:"sugar"`
+	Sodium        float64 `json:"sodium"`
+}
+
+// Add returns the sum of two nutrition values
+func (n Nutrition) Add(other Nutrition) Nutrition {
+	return Nutrition{
+		Calories:      n.Calories + other.Calories,
+		Protein:       n.Protein + other.Protein,
+		Carbohydrates: n.Carbohydrates + other.Carbohydrates,
+		Fat:           n.Fat + other.Fat,
+		Fiber:         n.Fiber + other.Fiber,
+		Sugar:         n.Sugar + other.Sugar,
+		Sodium:        n.Sodium + other.Sodium,
+	}
+}
+
+// Scale returns the nutrition values multiplied by factor
+func (n Nutrition) Scale(factor float64) Nutrition {
+	return Nutrition{
+		Calories:      n.Calories * factor,
+		Protein:       n.Protein * factor,
+		Carbohydrates: n.Carbohydrates * factor,
+		Fat:           n.Fat * factor,
+		Fiber:         n.Fiber * factor,
+		Sugar:         n.Sugar * factor,
+		Sodium:        n.Sodium * factor,
+	}
+}
+
+// RecipeSection groups ingredients and steps under a name such as "Dough" or "Filling".
+// A section may instead reference another recipe, scaled by Multiplier.
+type RecipeSection struct {
+	Name        string      `json:"name"`
+	Ingredients Ingredients `json:"ingredients,omitempty"`
+	Steps       Steps       `json:"steps,omitempty"`
+	RecipeID    *uuid.UUID  `json:"recipe_id,omitempty"`  // referenced sub-recipe
+	Multiplier  float64     `json:"multiplier,omitempty"` // quantity multiplier applied to the sub-recipe
+	Recipe      *Recipe     `json:"recipe,omitempty"`     // the sub-recipe, only set when a read expands sections
+}
+
+// Sections type for JSON serialization
+type Sections []RecipeSection
+
+// Value implements the driver.Valuer interface for Sections
+func (s Sections) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for Sections
+func (s *Sections) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, s)
+}
+
 type Recipe struct {
 	*common.BaseModel
 	UserID      uuid.UUID      `json:"user_id"`
@@ -69,10 +162,26 @@ type Recipe struct {
 	Description string         `json:"description"`
 	Time        int            `json:"time"` // cooking time in minutes
 	CategoryID  uuid.UUID      `json:"category_id"`
-	ServingSize int            `json:"serving_size"` // number of people
-	Images      []common.Image `json:"images"`       // JSON array of image URLs
-	Ingredients Ingredients    `json:"ingredients"`  // JSON array of ingredients
-	Steps       Steps          `json:"steps"`        // JSON array of steps
-	RatingCount int            `json:"rating_count"` // Number of ratings
-	AvgRating   float64        `json:"avg_rating"`   // Average rating (0-5)
+	ServingSize int            `json:"serving_size"`        // number of people
+	Images      []common.Image `json:"images"`              // JSON array of image URLs
+	Ingredients Ingredients    `json:"ingredients"`         // JSON array of ingredients
+	Steps       Steps          `json:"steps"`               // JSON array of steps
+	Sections    Sections       `json:"sections,omitempty"`  // named ingredient and step sections
+	Nutrition   *Nutrition     `json:"nutrition,omitempty"` // nutrition facts per serving
+	Tags        []string       `json:"tags"`                // lowercase labels such as "vegetarian"
+	RatingCount int            `json:"rating_count"`        // Number of ratings
+	AvgRating   float64        `json:"avg_rating"`          // Average rating (0-5)
+	CookCount   int            `json:"cook_count"`          // Number of times the recipe was logged as cooked
+	// ForkedFromID is the recipe this one was copied from, if any
+	ForkedFromID *uuid.UUID `json:"forked_from_id,omitempty"`
+	// Locale is the language the recipe was written in, such as "en" or "pt-BR"
+	Locale       string                       `json:"locale"`
+	Translations map[string]RecipeTranslation `json:"translations,omitempty"`  // keyed by locale
+	ServedLocale string                       `json:"served_locale,omitempty"` // locale of the text in this response
+	// PossibleDuplicates lists the author's own recipes that a newly saved recipe looks like
+	PossibleDuplicates []DuplicateWarning `json:"possible_duplicates,omitempty"`
+	// Notes is the requesting user's private note on the recipe, never shown to anyone else
+	Notes *RecipeNote `json:"notes,omitempty"`
+	// TrashedAt is when the recipe was deleted; it is purged once the trash retention has passed
+	TrashedAt *time.Time `json:"trashed_at,omitempty"`
 }
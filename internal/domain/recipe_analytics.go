@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipeView is a view of a recipe. A viewer is counted once per recipe per window, so
+// reloading a recipe does not inflate its views.
+type RecipeView struct {
+	RecipeID    uuid.UUID
+	ViewerID    uuid.UUID
+	WindowStart time.Time
+	ViewedAt    time.Time
+}
+
+// RecipeStats counts the activity on one or more recipes
+type RecipeStats struct {
+	Views   int `json:"views"`
+	Saves   int `json:"saves"` // times saved to a collection
+	Ratings int `json:"ratings"`
+	Forks   int `json:"forks"`
+}
+
+// Add adds the counts of other to the stats
+func (s *RecipeStats) Add(other RecipeStats) {
+	s.Views += other.Views
+	s.Saves += other.Saves
+	s.Ratings += other.Ratings
+	s.Forks += other.Forks
+}
+
+// RecipeDailyStats is the activity on a recipe during one day
+type RecipeDailyStats struct {
+	RecipeID uuid.UUID
+	Day      time.Time
+	RecipeStats
+}
+
+// AnalyticsDay is the activity during one day of an analytics time series
+type AnalyticsDay struct {
+	Day string `json:"day"` // YYYY-MM-DD
+	RecipeStats
+}
+
+// RecipeAnalytics is the activity on one recipe over the analytics period
+type RecipeAnalytics struct {
+	RecipeID uuid.UUID   `json:"recipe_id"`
+	Title    string      `json:"title"`
+	Totals   RecipeStats `json:"totals"`
+}
+
+// AuthorAnalytics is the activity on an author's recipes over a period of days
+type AuthorAnalytics struct {
+	From    string            `json:"from"` // YYYY-MM-DD, inclusive
+	To      string            `json:"to"`   // YYYY-MM-DD, inclusive
+	Totals  RecipeStats       `json:"totals"`
+	Series  []AnalyticsDay    `json:"series"`  // one entry per day, including days without activity
+	Recipes []RecipeAnalytics `json:"recipes"` // recipes with activity, most viewed first
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCurrency is used for prices and cost estimates that do not name a currency
+const DefaultCurrency = "USD"
+
+// IngredientPrice is what an ingredient costs per unit in a currency. Admins maintain the shared
+// prices; a user's own prices take precedence over them for that user's estimates.
+type IngredientPrice struct {
+	*common.BaseModel
+	UserID   *uuid.UUID `json:"user_id,omitempty"` // nil for shared prices
+	Name     string     `json:"name"`
+	Price    float64    `json:"price"`    // price of one Unit
+	Unit     string     `json:"unit"`     // canonical unit, empty for a price per item
+	Currency string     `json:"currency"` // ISO 4217 code such as "USD" or "EUR"
+}
+
+// IngredientCost is the estimated cost of one ingredient of a recipe
+type IngredientCost struct {
+	Name   string  `json:"name"`
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"`
+	Cost   float64 `json:"cost"`
+	// PriceName is the catalogue entry the ingredient was priced with
+	PriceName string `json:"price_name"`
+}
+
+// UnpricedIngredient is an ingredient left out of a cost estimate
+type UnpricedIngredient struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+// RecipeCost is the estimated cost of a recipe. The total only covers the priced ingredients.
+type RecipeCost struct {
+	RecipeID       uuid.UUID            `json:"recipe_id"`
+	Currency       string               `json:"currency"`
+	TotalCost      float64              `json:"total_cost"`
+	CostPerServing float64              `json:"cost_per_serving"`
+	ServingSize    int                  `json:"serving_size"`
+	Ingredients    []IngredientCost     `json:"ingredients"`
+	Unpriced       []UnpricedIngredient `json:"unpriced"`
+	Complete       bool                 `json:"complete"` // every ingredient was priced
+}
+
+// RecipeCostSummary is the cost of a recipe from the shared prices, stored for filtering by cost
+type RecipeCostSummary struct {
+	RecipeID       uuid.UUID
+	Currency       string
+	TotalCost      float64
+	CostPerServing float64
+	Complete       bool
+	ComputedAt     time.Time
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipeFingerprint is the MinHash signature of a recipe's title words, ingredients and step text
+type RecipeFingerprint struct {
+	RecipeID  uuid.UUID
+	UserID    uuid.UUID
+	Signature []uint64
+	Bands     []uint64 // LSH band hashes, 0 for bands that are left out
+	UpdatedAt time.Time
+}
+
+// RecipeDuplicate is a recipe found to be a likely duplicate of an earlier one
+type RecipeDuplicate struct {
+	RecipeID      uuid.UUID `json:"recipe_id"`
+	DuplicateOfID uuid.UUID `json:"duplicate_of_id"`
+	Similarity    float64   `json:"similarity"`  // estimated share of title words, ingredients and step text in common
+	SameAuthor    bool      `json:"same_author"` // copies of other users' recipes are flagged for moderation
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// DuplicateWarning tells an author that a recipe they saved looks like one they already have
+type DuplicateWarning struct {
+	RecipeID   uuid.UUID `json:"recipe_id"`
+	Title      string    `json:"title"`
+	Similarity float64   `json:"similarity"`
+}
+
+// DuplicateClusterRecipe is a recipe in a cluster of duplicates
+type DuplicateClusterRecipe struct {
+	RecipeID  uuid.UUID `json:"recipe_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Title     string    `json:"title"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DuplicateCluster is a group of recipes that are likely duplicates of each other
+type DuplicateCluster struct {
+	Recipes       []DuplicateClusterRecipe `json:"recipes"` // oldest first
+	MaxSimilarity float64                  `json:"max_similarity"`
+	Flagged       bool                     `json:"flagged"` // the cluster has recipes by more than one user
+}
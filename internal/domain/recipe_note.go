@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// RecipeNote is a user's private note on a recipe, visible only to that user
+type RecipeNote struct {
+	*common.BaseModel
+	UserID    uuid.UUID  `json:"user_id"`
+	RecipeID  uuid.UUID  `json:"recipe_id"`
+	Content   string     `json:"content"`
+	StepNotes []StepNote `json:"step_notes"` // annotations on single steps, ordered by step
+}
+
+// StepNote annotates the recipe step with the given order
+type StepNote struct {
+	Step    int    `json:"step"`
+	Content string `json:"content"`
+}
@@ -9,10 +9,11 @@ import (
 // RecipeRating represents a user's rating and comment for a recipe
 type RecipeRating struct {
 	*common.BaseModel
-	RecipeID uuid.UUID `json:"recipe_id"`
-	UserID   uuid.UUID `json:"user_id"`
-	Rating   int       `json:"rating"`  // Rating value (1-5)
-	Comment  string    `json:"comment"` // Optional comment
+	RecipeID     uuid.UUID `json:"recipe_id"`
+	UserID       uuid.UUID `json:"user_id"`
+	Rating       int       `json:"rating"`        // Rating value (1-5)
+	Comment      string    `json:"comment"`       // Optional comment
+	VerifiedCook bool      `json:"verified_cook"` // the user has logged cooking the recipe
 }
 
 // RecipeRatingWithUser represents a recipe rating with user information
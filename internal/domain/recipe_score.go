@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Ranking windows
+const (
+	RankingWindowDaily   = "daily"
+	RankingWindowWeekly  = "weekly"
+	RankingWindowAllTime = "all_time"
+)
+
+// RecipeEngagement is the engagement with a recipe within a ranking window. The decayed
+// values count each event less the longer ago it happened.
+type RecipeEngagement struct {
+	RecipeID      uuid.UUID
+	DecayedRating float64 // each rating counts stars / 5
+	DecayedSaves  float64
+	DecayedCooks  float64
+	DecayedViews  float64
+	RatingCount   int
+	RatingSum     int
+}
+
+// RecipeScore is the precomputed ranking of a recipe within a ranking window
+type RecipeScore struct {
+	RecipeID      uuid.UUID `json:"recipe_id"`
+	Window        string    `json:"window"`
+	TrendingScore float64   `json:"trending_score"`
+	TopScore      float64   `json:"top_score"` // Bayesian average rating, 0 when the recipe has no ratings in the window
+	RatingCount   int       `json:"rating_count"`
+	ComputedAt    time.Time `json:"computed_at"`
+}
+
+// RankedRecipe is a recipe in a trending or top rated ranking
+type RankedRecipe struct {
+	Recipe *Recipe `json:"recipe"`
+	Rank   int     `json:"rank"`
+	Score  float64 `json:"score"`
+}
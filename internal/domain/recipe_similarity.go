@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipeSimilarity is a precomputed neighbour of a recipe
+type RecipeSimilarity struct {
+	RecipeID        uuid.UUID `json:"recipe_id"`
+	SimilarRecipeID uuid.UUID `json:"similar_recipe_id"`
+	Score           float64   `json:"score"` // 0 to 1, higher is more similar
+	Rank            int       `json:"rank"`  // 1 is the most similar recipe
+	ComputedAt      time.Time `json:"computed_at"`
+}
+
+// SimilarRecipe is a recipe recommended as similar to another one
+type SimilarRecipe struct {
+	Recipe *Recipe `json:"recipe"`
+	Score  float64 `json:"score"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// DefaultLocale is the locale of recipes written without one
+const DefaultLocale = "en"
+
+// RecipeTranslation is the text of a recipe in another locale. Ingredients and Steps hold the
+// translated ingredient names and step contents; SourceIngredients and SourceSteps hold the original
+// text each entry translates, so a translation stays attached to its ingredient or step when the
+// recipe is reordered and is dropped when the original text changes. Empty entries, like an empty
+// title or description, fall back to the original text.
+type RecipeTranslation struct {
+	Title             string    `json:"title"`
+	Description       string    `json:"description"`
+	Ingredients       []string  `json:"ingredients,omitempty"`
+	Steps             []string  `json:"steps,omitempty"`
+	SourceIngredients []string  `json:"source_ingredients,omitempty"`
+	SourceSteps       []string  `json:"source_steps,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"cookaholic/internal/common"
+
+	"github.com/google/uuid"
+)
+
+// ShoppingList is a list of ingredients to buy, usually generated from one or more recipes
+type ShoppingList struct {
+	*common.BaseModel
+	UserID     uuid.UUID          `json:"user_id"`
+	Name       string             `json:"name"`
+	ShareToken string             `json:"share_token,omitempty"` // set while the list is shared
+	Items      []ShoppingListItem `json:"-"`
+	Aisles     []ShoppingAisle    `json:"aisles"` // items grouped by store aisle
+}
+
+// ShoppingListItem is a single entry of a shopping list
+type ShoppingListItem struct {
+	*common.BaseModel
+	ListID    uuid.UUID   `json:"list_id"`
+	Name      string      `json:"name"`
+	Amount    float64     `json:"amount"`
+	Unit      string      `json:"unit"`
+	Aisle     string      `json:"aisle"`
+	Checked   bool        `json:"checked"`
+	Manual    bool        `json:"manual"`               // added by hand rather than generated from a recipe
+	RecipeIDs []uuid.UUID `json:"recipe_ids,omitempty"` // recipes the item was generated from
+}
+
+// ShoppingAisle groups the items of a shopping list found in the same store aisle
+type ShoppingAisle struct {
+	Name  string             `json:"name"`
+	Items []ShoppingListItem `json:"items"`
+}
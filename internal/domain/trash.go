@@ -0,0 +1,16 @@
+package domain
+
+// Trash holds a user's deleted recipes and collections, which can be restored until they are purged
+type Trash struct {
+	Recipes     []Recipe     `json:"recipes"`
+	Collections []Collection `json:"collections"`
+	// RetentionDays is how long deleted items are kept before they are purged for good
+	RetentionDays int `json:"retention_days"`
+}
+
+// TrashPurgeResult counts the items a purge removed for good
+type TrashPurgeResult struct {
+	Recipes     int64 `json:"recipes"`
+	Collections int64 `json:"collections"`
+	Categories  int64 `json:"categories"`
+}
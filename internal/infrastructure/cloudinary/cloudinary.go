@@ -4,6 +4,7 @@ import (
 	"context"
 	"cookaholic/internal/common"
 	"errors"
+	"io"
 	"mime/multipart"
 	"os"
 
@@ -47,8 +48,13 @@ func (s *CloudinaryService) UploadImage(ctx context.Context, file *multipart.Fil
 	}
 	defer src.Close()
 
+	return s.UploadImageData(ctx, src, folder)
+}
+
+// UploadImageData uploads image data read from any source to Cloudinary
+func (s *CloudinaryService) UploadImageData(ctx context.Context, data io.Reader, folder string) (*common.Image, error) {
 	// Upload the file to Cloudinary
-	uploadResult, err := s.cld.Upload.Upload(ctx, src, uploader.UploadParams{
+	uploadResult, err := s.cld.Upload.Upload(ctx, data, uploader.UploadParams{
 		Folder:      "cookaholic",
 		AssetFolder: folder,
 	})
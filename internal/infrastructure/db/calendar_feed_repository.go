@@ -0,0 +1,112 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CalendarFeedEntity is the database model for meal plan calendar feeds
+type CalendarFeedEntity struct {
+	*common.BaseEntity
+	UserID uuid.UUID `json:"user_id" gorm:"type:char(36);not null;uniqueIndex"`
+	Token  string    `json:"token" gorm:"type:varchar(64);not null;uniqueIndex"`
+}
+
+// TableName returns the table name for the CalendarFeedEntity
+func (c *CalendarFeedEntity) TableName() string {
+	return "calendar_feeds"
+}
+
+// ToCalendarFeedDomain converts a CalendarFeedEntity to a domain.CalendarFeed
+func (c *CalendarFeedEntity) ToCalendarFeedDomain() *domain.CalendarFeed {
+	return &domain.CalendarFeed{
+		BaseModel: &common.BaseModel{
+			ID:        c.ID,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+			Status:    c.Status,
+		},
+		UserID: c.UserID,
+		Token:  c.Token,
+	}
+}
+
+// CalendarFeedRepository is the repository implementation for calendar feeds
+type CalendarFeedRepository struct {
+	db *gorm.DB
+}
+
+// NewCalendarFeedRepository creates a new calendar feed repository
+func NewCalendarFeedRepository(db *gorm.DB) interfaces.CalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+// GetByUserID gets the calendar feed of a user
+func (r *CalendarFeedRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error) {
+	var entity CalendarFeedEntity
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToCalendarFeedDomain(), nil
+}
+
+// GetByToken gets a calendar feed by its token
+func (r *CalendarFeedRepository) GetByToken(ctx context.Context, token string) (*domain.CalendarFeed, error) {
+	var entity CalendarFeedEntity
+	if err := r.db.WithContext(ctx).Where("token = ?", token).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToCalendarFeedDomain(), nil
+}
+
+// Save creates the user's feed or replaces the token of the existing one
+func (r *CalendarFeedRepository) Save(ctx context.Context, feed *domain.CalendarFeed) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing CalendarFeedEntity
+		err := tx.Where("user_id = ?", feed.UserID).First(&existing).Error
+		if err == nil {
+			feed.BaseModel = &common.BaseModel{
+				ID:        existing.ID,
+				CreatedAt: existing.CreatedAt,
+				UpdatedAt: time.Now(),
+				Status:    existing.Status,
+			}
+			return tx.Model(&CalendarFeedEntity{}).Where("id = ?", existing.ID).Updates(map[string]interface{}{
+				"token":      feed.Token,
+				"updated_at": feed.UpdatedAt,
+			}).Error
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		feed.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+		return tx.Create(&CalendarFeedEntity{
+			BaseEntity: &common.BaseEntity{
+				ID:        feed.ID,
+				CreatedAt: feed.CreatedAt,
+				UpdatedAt: feed.UpdatedAt,
+				Status:    feed.Status,
+			},
+			UserID: feed.UserID,
+			Token:  feed.Token,
+		}).Error
+	})
+}
+
+// DeleteByUserID deletes the calendar feed of a user
+func (r *CalendarFeedRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&CalendarFeedEntity{}, "user_id = ?", userID).Error
+}
@@ -15,6 +15,8 @@ type CategoryEntity struct {
 	*common.BaseEntity
 	Name  string       `json:"name"`
 	Image common.Image `json:"image" gorm:"serializer:json;type:text;default:null"`
+	// TrashedAt is when the category was deleted
+	TrashedAt *time.Time `json:"trashed_at" gorm:"index"`
 }
 
 func (c *CategoryEntity) TableName() string {
@@ -45,9 +47,11 @@ func (c *CategoryEntity) ToCategoryDomain() *domain.Category {
 			CreatedAt: c.CreatedAt,
 			UpdatedAt: c.UpdatedAt,
 			Status:    c.Status,
+			Version:   c.Version,
 		},
-		Name:  c.Name,
-		Image: c.Image,
+		Name:      c.Name,
+		Image:     c.Image,
+		TrashedAt: c.TrashedAt,
 	}
 }
 
@@ -58,6 +62,7 @@ func FromCategoryDomain(category *domain.Category) *CategoryEntity {
 			CreatedAt: category.CreatedAt,
 			UpdatedAt: category.UpdatedAt,
 			Status:    category.Status,
+			Version:   category.Version,
 		},
 		Name:  category.Name,
 		Image: category.Image,
@@ -84,12 +89,42 @@ func (r *categoryRepository) Get(ctx context.Context, id uuid.UUID) (*domain.Cat
 	return category.ToCategoryDomain(), nil
 }
 
+// GetByName gets an active category by its name, ignoring case
+func (r *categoryRepository) GetByName(ctx context.Context, name string) (*domain.Category, error) {
+	var category CategoryEntity
+	if err := r.db.WithContext(ctx).Where("LOWER(name) = LOWER(?) AND status = ?", name, 1).First(&category).Error; err != nil {
+		return nil, err
+	}
+	return category.ToCategoryDomain(), nil
+}
+
+// Update saves the name and image of an active category, leaving its status and trash time alone.
+// It returns gorm.ErrRecordNotFound when the category doesn't exist or was deleted.
 func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) error {
-	return r.db.WithContext(ctx).Save(FromCategoryDomain(category)).Error
+	entity := FromCategoryDomain(category)
+	result := r.db.WithContext(ctx).Model(&CategoryEntity{}).Where("id = ? AND status = ?", category.ID, 1).Updates(map[string]interface{}{
+		"name":       entity.Name,
+		"image":      entity.Image,
+		"updated_at": time.Now(),
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	category.Version++
+	return nil
 }
 
 func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Update("status", 0).Error
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&CategoryEntity{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     0,
+		"trashed_at": now,
+		"updated_at": now,
+	}).Error
 }
 
 func (r *categoryRepository) List(ctx context.Context, cursor uuid.UUID, limit int) ([]domain.Category, uuid.UUID, error) {
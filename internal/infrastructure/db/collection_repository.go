@@ -18,6 +18,7 @@ type CollectionEntity struct {
 	Name        string       `json:"name" gorm:"not null"`
 	Description string       `json:"description"`
 	Image       common.Image `json:"image" gorm:"serializer:json;type:text"`
+	TrashedAt   *time.Time   `json:"trashed_at" gorm:"index"` // when the collection was deleted
 }
 
 func (c *CollectionEntity) TableName() string {
@@ -43,17 +44,18 @@ func (c *CollectionEntity) BeforeUpdate(tx *gorm.DB) error {
 
 func (c *CollectionEntity) ToCollectionDomain() *domain.Collection {
 	return &domain.Collection{
-		BaseModel:   &common.BaseModel{ID: c.ID, UpdatedAt: c.UpdatedAt, Status: c.Status, CreatedAt: c.CreatedAt},
+		BaseModel:   &common.BaseModel{ID: c.ID, UpdatedAt: c.UpdatedAt, Status: c.Status, CreatedAt: c.CreatedAt, Version: c.Version},
 		UserID:      c.UserID,
 		Name:        c.Name,
 		Description: c.Description,
 		Image:       c.Image,
+		TrashedAt:   c.TrashedAt,
 	}
 }
 
 func FromCollectionDomain(collection *domain.Collection) *CollectionEntity {
 	return &CollectionEntity{
-		BaseEntity:  &common.BaseEntity{ID: collection.ID, UpdatedAt: collection.UpdatedAt, Status: collection.Status},
+		BaseEntity:  &common.BaseEntity{ID: collection.ID, CreatedAt: collection.CreatedAt, UpdatedAt: collection.UpdatedAt, Status: collection.Status, Version: collection.Version},
 		UserID:      collection.UserID,
 		Name:        collection.Name,
 		Description: collection.Description,
@@ -109,20 +111,34 @@ func (r *CollectionRepository) Update(ctx context.Context, collection *domain.Co
 	if existingCollection.Status == 0 {
 		return errors.New("collection not found")
 	}
+	if existingCollection.Version != collection.Version {
+		return interfaces.ErrVersionConflict
+	}
 
-	updatedCollection := FromCollectionDomain(collection)
-	existingCollection.Name = updatedCollection.Name
-	existingCollection.Description = updatedCollection.Description
-	existingCollection.Image = updatedCollection.Image
-
-	updateError := r.db.WithContext(ctx).Where("id = ?", existingCollection.ID).Save(&existingCollection).Error
-	if updateError != nil {
-		return updateError
+	existingCollection.Name = collection.Name
+	existingCollection.Description = collection.Description
+	existingCollection.Image = collection.Image
+
+	// Update the editable columns and bump the version, but only if nobody else updated the collection since it was read
+	updatedCollection := FromCollectionDomain(existingCollection)
+	updatedCollection.Version = collection.Version + 1
+	result := r.db.WithContext(ctx).
+		Select("name", "description", "image", "version", "updated_at").
+		Where("version = ?", collection.Version).
+		Updates(updatedCollection)
+	if result.Error != nil {
+		return result.Error
 	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrVersionConflict
+	}
+
+	collection.Version++
+	collection.UpdatedAt = updatedCollection.UpdatedAt
 	return nil
 }
 
-func (r *CollectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+func (r *CollectionRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
 	var collection CollectionEntity
 	if err := r.db.WithContext(ctx).First(&collection, "id = ?", id).Error; err != nil {
 		return err
@@ -132,6 +148,18 @@ func (r *CollectionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 		return errors.New("collection not found")
 	}
 
-	collection.Status = 0
-	return r.db.WithContext(ctx).Save(&collection).Error
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&CollectionEntity{}).Where("id = ? AND version = ?", id, version).Updates(map[string]interface{}{
+		"status":     0,
+		"trashed_at": now,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrVersionConflict
+	}
+	return nil
 }
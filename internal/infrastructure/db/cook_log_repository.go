@@ -0,0 +1,186 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CookLogEntity is the database model for cook logs
+type CookLogEntity struct {
+	*common.BaseEntity
+	UserID   uuid.UUID      `json:"user_id" gorm:"type:char(36);not null;index:idx_cook_log_user_recipe"`
+	RecipeID uuid.UUID      `json:"recipe_id" gorm:"type:char(36);not null;index:idx_cook_log_user_recipe;index"`
+	CookedAt time.Time      `json:"cooked_at" gorm:"not null;index"`
+	Notes    string         `json:"notes" gorm:"type:text"`
+	Photos   []common.Image `json:"photos" gorm:"serializer:json;type:text"`
+	Servings int            `json:"servings" gorm:"default:0"`
+}
+
+// TableName returns the table name for the CookLogEntity
+func (c *CookLogEntity) TableName() string {
+	return "cook_logs"
+}
+
+// ToCookLogDomain converts a CookLogEntity to a domain.CookLog
+func (c *CookLogEntity) ToCookLogDomain() *domain.CookLog {
+	photos := c.Photos
+	if photos == nil {
+		photos = []common.Image{}
+	}
+
+	return &domain.CookLog{
+		BaseModel: &common.BaseModel{
+			ID:        c.ID,
+			CreatedAt: c.CreatedAt,
+			UpdatedAt: c.UpdatedAt,
+			Status:    c.Status,
+		},
+		UserID:   c.UserID,
+		RecipeID: c.RecipeID,
+		CookedAt: c.CookedAt,
+		Notes:    c.Notes,
+		Photos:   photos,
+		Servings: c.Servings,
+	}
+}
+
+// FromCookLogDomain converts a domain.CookLog to a CookLogEntity
+func FromCookLogDomain(log *domain.CookLog) *CookLogEntity {
+	if log.BaseModel == nil {
+		log.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	return &CookLogEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        log.ID,
+			CreatedAt: log.CreatedAt,
+			UpdatedAt: log.UpdatedAt,
+			Status:    log.Status,
+		},
+		UserID:   log.UserID,
+		RecipeID: log.RecipeID,
+		CookedAt: log.CookedAt,
+		Notes:    log.Notes,
+		Photos:   log.Photos,
+		Servings: log.Servings,
+	}
+}
+
+// CookLogRepository is the repository implementation for cook logs
+type CookLogRepository struct {
+	db *gorm.DB
+}
+
+// NewCookLogRepository creates a new cook log repository
+func NewCookLogRepository(db *gorm.DB) interfaces.CookLogRepository {
+	return &CookLogRepository{db: db}
+}
+
+// Create creates a cook log and updates the recipe's cook count. The pantry deduction of the log,
+// if any, is applied in the same transaction so a failed cook leaves the pantry untouched.
+func (r *CookLogRepository) Create(ctx context.Context, log *domain.CookLog) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(FromCookLogDomain(log)).Error; err != nil {
+			return err
+		}
+		if log.PantryDeduction != nil {
+			if err := deductPantryItems(tx, log.UserID, log.PantryDeduction.Deducted); err != nil {
+				return err
+			}
+		}
+		return addRecipeCookCount(tx, log.RecipeID, 1)
+	})
+}
+
+// GetByID gets a cook log by ID
+func (r *CookLogRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CookLog, error) {
+	var entity CookLogEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToCookLogDomain(), nil
+}
+
+// GetByUserID gets a user's cook logs, most recently cooked first. A nil recipe ID returns the logs of all recipes.
+func (r *CookLogRepository) GetByUserID(ctx context.Context, userID uuid.UUID, recipeID uuid.UUID, cursor uuid.UUID, limit int) ([]domain.CookLog, uuid.UUID, error) {
+	var entities []CookLogEntity
+
+	query := r.db.WithContext(ctx).Where("user_id = ? AND status = ?", userID, 1)
+	if recipeID != uuid.Nil {
+		query = query.Where("recipe_id = ?", recipeID)
+	}
+	if cursor != uuid.Nil {
+		var cursorLog CookLogEntity
+		if err := r.db.WithContext(ctx).Select("id", "cooked_at").Where("id = ?", cursor).First(&cursorLog).Error; err != nil {
+			return nil, uuid.Nil, err
+		}
+		// Several cooks can share a timestamp, so the ID breaks ties
+		query = query.Where("cooked_at < ? OR (cooked_at = ? AND id < ?)", cursorLog.CookedAt, cursorLog.CookedAt, cursorLog.ID)
+	}
+
+	if err := query.Order("cooked_at DESC, id DESC").Limit(limit).Find(&entities).Error; err != nil {
+		return nil, uuid.Nil, err
+	}
+
+	logs := make([]domain.CookLog, len(entities))
+	for i, entity := range entities {
+		logs[i] = *entity.ToCookLogDomain()
+	}
+
+	var nextCursor uuid.UUID
+	if len(entities) == limit {
+		nextCursor = entities[len(entities)-1].ID
+	}
+
+	return logs, nextCursor, nil
+}
+
+// ExistsForUserAndRecipe checks whether the user has logged cooking the recipe
+func (r *CookLogRepository) ExistsForUserAndRecipe(ctx context.Context, userID, recipeID uuid.UUID) (bool, error) {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&CookLogEntity{}).
+		Where("user_id = ? AND recipe_id = ? AND status = ?", userID, recipeID, 1).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// Delete soft deletes a cook log and updates the recipe's cook count in the same transaction
+func (r *CookLogRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var entity CookLogEntity
+		if err := tx.Where("id = ?", id).First(&entity).Error; err != nil {
+			return err
+		}
+
+		// Only the request that actually deletes the log lowers the count
+		result := tx.Model(&CookLogEntity{}).Where("id = ? AND status = ?", id, 1).Updates(map[string]interface{}{
+			"status":     0,
+			"updated_at": time.Now(),
+		})
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return addRecipeCookCount(tx, entity.RecipeID, -1)
+	})
+}
+
+// addRecipeCookCount changes how many times a recipe was logged as cooked. The update is a single
+// statement so concurrent logs cannot overwrite each other's count, and the column is written
+// directly so logging a cook does not count as editing the recipe.
+func addRecipeCookCount(tx *gorm.DB, recipeID uuid.UUID, delta int) error {
+	return tx.Model(&RecipeEntity{}).Where("id = ? AND cook_count + ? >= 0", recipeID, delta).
+		UpdateColumn("cook_count", gorm.Expr("cook_count + ?", delta)).Error
+}
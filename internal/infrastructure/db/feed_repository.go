@@ -0,0 +1,153 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// A recipe is popular once enough users rated it highly or cooked it
+const (
+	popularMinRatings   = 3
+	popularMinAvgRating = 4.0
+	popularMinCooks     = 3
+)
+
+// FeedItemEntity is the database model for home feed items, written when a followed user publishes a recipe
+type FeedItemEntity struct {
+	UserID      uuid.UUID `gorm:"type:char(36);primaryKey;index:idx_feed_user_published,priority:1"`
+	RecipeID    uuid.UUID `gorm:"type:char(36);primaryKey;index:idx_feed_user_published,priority:3"`
+	AuthorID    uuid.UUID `gorm:"type:char(36);not null"`
+	PublishedAt time.Time `gorm:"not null;index:idx_feed_user_published,priority:2"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for this entity
+func (FeedItemEntity) TableName() string {
+	return "feed_items"
+}
+
+// FeedRepository is the repository implementation for home feeds
+type FeedRepository struct {
+	db *gorm.DB
+}
+
+// NewFeedRepository creates a new feed repository
+func NewFeedRepository(db *gorm.DB) interfaces.FeedRepository {
+	return &FeedRepository{db: db}
+}
+
+// FanOutRecipe copies a new recipe into the feed of every follower of its author with a single statement
+func (r *FeedRepository) FanOutRecipe(ctx context.Context, recipeID, authorID uuid.UUID, publishedAt time.Time) error {
+	return r.db.WithContext(ctx).Exec(
+		"INSERT IGNORE INTO feed_items (user_id, recipe_id, author_id, published_at, created_at) "+
+			"SELECT DISTINCT follower_id, ?, ?, ?, ? FROM user_followers WHERE following_id = ?",
+		recipeID, authorID, publishedAt, time.Now(), authorID,
+	).Error
+}
+
+// GetFollowedRecipes gets feed items newest first. Items of users the reader has unfollowed since are skipped.
+func (r *FeedRepository) GetFollowedRecipes(ctx context.Context, userID uuid.UUID, after *domain.FeedPosition, limit int) ([]domain.FeedItem, error) {
+	var entities []FeedItemEntity
+
+	query := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("EXISTS (SELECT 1 FROM user_followers WHERE user_followers.follower_id = feed_items.user_id AND user_followers.following_id = feed_items.author_id)").
+		Where("EXISTS (SELECT 1 FROM recipes WHERE recipes.id = feed_items.recipe_id AND recipes.status = ?)", 1)
+	if after != nil {
+		query = query.Where("(published_at < ? OR (published_at = ? AND recipe_id < ?))", after.PublishedAt, after.PublishedAt, after.RecipeID)
+	}
+
+	if err := query.Order("published_at DESC, recipe_id DESC").Limit(limit).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return []domain.FeedItem{}, nil
+	}
+
+	recipeIDs := make([]uuid.UUID, len(entities))
+	for i, entity := range entities {
+		recipeIDs[i] = entity.RecipeID
+	}
+	var recipes []RecipeEntity
+	if err := r.db.WithContext(ctx).Where("id IN ? AND status = ?", recipeIDs, 1).Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+	recipeMap := make(map[uuid.UUID]*domain.Recipe, len(recipes))
+	for i := range recipes {
+		recipeMap[recipes[i].ID] = recipes[i].ToRecipeDomain()
+	}
+
+	// Keep the feed order; a recipe deleted between the two queries is dropped
+	items := make([]domain.FeedItem, 0, len(entities))
+	for _, entity := range entities {
+		if recipe, ok := recipeMap[entity.RecipeID]; ok {
+			items = append(items, domain.FeedItem{
+				Recipe:      recipe,
+				Reason:      domain.FeedReasonFollowing,
+				PublishedAt: entity.PublishedAt,
+			})
+		}
+	}
+	return items, nil
+}
+
+// GetPopularRecipes gets popular recipes newest first, so the order stays stable while ratings change
+func (r *FeedRepository) GetPopularRecipes(ctx context.Context, userID uuid.UUID, categoryIDs []uuid.UUID, after *domain.FeedPosition, limit int) ([]domain.FeedItem, error) {
+	var entities []RecipeEntity
+
+	query := r.db.WithContext(ctx).
+		Where("status = ? AND user_id <> ?", 1, userID).
+		Where("user_id NOT IN (SELECT following_id FROM user_followers WHERE follower_id = ?)", userID).
+		Where("((rating_count >= ? AND avg_rating >= ?) OR cook_count >= ?)", popularMinRatings, popularMinAvgRating, popularMinCooks)
+	if len(categoryIDs) > 0 {
+		query = query.Where("category_id IN ?", categoryIDs)
+	}
+	if after != nil {
+		query = query.Where("(created_at < ? OR (created_at = ? AND id < ?))", after.PublishedAt, after.PublishedAt, after.RecipeID)
+	}
+
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]domain.FeedItem, len(entities))
+	for i := range entities {
+		items[i] = domain.FeedItem{
+			Recipe:      entities[i].ToRecipeDomain(),
+			Reason:      domain.FeedReasonPopular,
+			PublishedAt: entities[i].CreatedAt,
+		}
+	}
+	return items, nil
+}
+
+// GetEngagedCategoryIDs gets the categories of the recipes the user rated, saved and cooked, most engaged first
+func (r *FeedRepository) GetEngagedCategoryIDs(ctx context.Context, userID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	var rows []struct {
+		CategoryID uuid.UUID
+	}
+
+	if err := r.db.WithContext(ctx).Raw(
+		"SELECT recipes.category_id FROM recipes JOIN ("+
+			"SELECT recipe_id FROM recipe_ratings WHERE user_id = ? "+
+			"UNION ALL SELECT recipe_collections.recipe_id FROM recipe_collections "+
+			"JOIN collections ON collections.id = recipe_collections.collection_id WHERE collections.user_id = ? "+
+			"UNION ALL SELECT recipe_id FROM cook_logs WHERE user_id = ? AND status = ?"+
+			") engaged ON engaged.recipe_id = recipes.id "+
+			"GROUP BY recipes.category_id ORDER BY COUNT(*) DESC LIMIT ?",
+		userID, userID, userID, 1, limit,
+	).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	categoryIDs := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		categoryIDs[i] = row.CategoryID
+	}
+	return categoryIDs, nil
+}
@@ -0,0 +1,152 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImportRowErrorEntity is a row of an import file that was not imported
+type ImportRowErrorEntity struct {
+	Row     int    `json:"row"`
+	Title   string `json:"title,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportJobEntity is the database model for bulk import jobs
+type ImportJobEntity struct {
+	*common.BaseEntity
+	UserID       uuid.UUID              `json:"user_id" gorm:"type:char(36);not null;index"`
+	Format       string                 `json:"format" gorm:"type:varchar(16);not null"`
+	State        string                 `json:"state" gorm:"type:varchar(16);not null;index"`
+	TotalRows    int                    `json:"total_rows" gorm:"default:0"`
+	ImportedRows int                    `json:"imported_rows" gorm:"default:0"`
+	FailedRows   int                    `json:"failed_rows" gorm:"default:0"`
+	Errors       []ImportRowErrorEntity `json:"errors" gorm:"serializer:json;type:mediumtext"`
+	RecipeIDs    []uuid.UUID            `json:"recipe_ids" gorm:"serializer:json;type:mediumtext"`
+	Error        string                 `json:"error" gorm:"type:text"`
+	StartedAt    *time.Time             `json:"started_at"`
+	FinishedAt   *time.Time             `json:"finished_at"`
+}
+
+// TableName returns the table name for the ImportJobEntity
+func (i *ImportJobEntity) TableName() string {
+	return "import_jobs"
+}
+
+// ToImportJobDomain converts an ImportJobEntity to a domain.ImportJob
+func (i *ImportJobEntity) ToImportJobDomain() *domain.ImportJob {
+	rowErrors := make([]domain.ImportRowError, len(i.Errors))
+	for j, rowError := range i.Errors {
+		rowErrors[j] = domain.ImportRowError(rowError)
+	}
+	recipeIDs := i.RecipeIDs
+	if recipeIDs == nil {
+		recipeIDs = []uuid.UUID{}
+	}
+
+	return &domain.ImportJob{
+		BaseModel: &common.BaseModel{
+			ID:        i.ID,
+			CreatedAt: i.CreatedAt,
+			UpdatedAt: i.UpdatedAt,
+			Status:    i.Status,
+		},
+		UserID:       i.UserID,
+		Format:       i.Format,
+		State:        i.State,
+		TotalRows:    i.TotalRows,
+		ImportedRows: i.ImportedRows,
+		FailedRows:   i.FailedRows,
+		Errors:       rowErrors,
+		RecipeIDs:    recipeIDs,
+		Error:        i.Error,
+		StartedAt:    i.StartedAt,
+		FinishedAt:   i.FinishedAt,
+	}
+}
+
+// FromImportJobDomain converts a domain.ImportJob to an ImportJobEntity
+func FromImportJobDomain(job *domain.ImportJob) *ImportJobEntity {
+	if job.BaseModel == nil {
+		job.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	rowErrors := make([]ImportRowErrorEntity, len(job.Errors))
+	for i, rowError := range job.Errors {
+		rowErrors[i] = ImportRowErrorEntity(rowError)
+	}
+
+	return &ImportJobEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        job.ID,
+			CreatedAt: job.CreatedAt,
+			UpdatedAt: job.UpdatedAt,
+			Status:    job.Status,
+		},
+		UserID:       job.UserID,
+		Format:       job.Format,
+		State:        job.State,
+		TotalRows:    job.TotalRows,
+		ImportedRows: job.ImportedRows,
+		FailedRows:   job.FailedRows,
+		Errors:       rowErrors,
+		RecipeIDs:    job.RecipeIDs,
+		Error:        job.Error,
+		StartedAt:    job.StartedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+}
+
+// ImportJobRepository is the repository implementation for bulk import jobs
+type ImportJobRepository struct {
+	db *gorm.DB
+}
+
+// NewImportJobRepository creates a new import job repository
+func NewImportJobRepository(db *gorm.DB) interfaces.ImportJobRepository {
+	return &ImportJobRepository{db: db}
+}
+
+// Create creates a new import job
+func (r *ImportJobRepository) Create(ctx context.Context, job *domain.ImportJob) error {
+	return r.db.WithContext(ctx).Create(FromImportJobDomain(job)).Error
+}
+
+// GetByID gets an import job by ID
+func (r *ImportJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error) {
+	var entity ImportJobEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToImportJobDomain(), nil
+}
+
+// Update saves the progress of an import job
+func (r *ImportJobRepository) Update(ctx context.Context, job *domain.ImportJob) error {
+	job.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(FromImportJobDomain(job)).Error
+}
+
+// FailUnfinished marks the pending and running import jobs as failed
+func (r *ImportJobRepository) FailUnfinished(ctx context.Context, message string) error {
+	now := time.Now()
+	return r.db.WithContext(ctx).Model(&ImportJobEntity{}).
+		Where("state IN ?", []string{domain.ImportJobPending, domain.ImportJobRunning}).
+		Updates(map[string]interface{}{
+			"state":       domain.ImportJobFailed,
+			"error":       message,
+			"finished_at": now,
+			"updated_at":  now,
+		}).Error
+}
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IngredientPriceEntity is the database model for the ingredient price catalogue
+type IngredientPriceEntity struct {
+	*common.BaseEntity
+	UserID   *uuid.UUID `json:"user_id" gorm:"type:char(36);index"`
+	Name     string     `json:"name" gorm:"not null;index"`
+	Price    float64    `json:"price" gorm:"not null"`
+	Unit     string     `json:"unit" gorm:"type:varchar(16)"`
+	Currency string     `json:"currency" gorm:"type:char(3);not null;index"`
+}
+
+// TableName returns the table name for the IngredientPriceEntity
+func (i *IngredientPriceEntity) TableName() string {
+	return "ingredient_prices"
+}
+
+// ToIngredientPriceDomain converts an IngredientPriceEntity to a domain.IngredientPrice
+func (i *IngredientPriceEntity) ToIngredientPriceDomain() *domain.IngredientPrice {
+	return &domain.IngredientPrice{
+		BaseModel: &common.BaseModel{
+			ID:        i.ID,
+			CreatedAt: i.CreatedAt,
+			UpdatedAt: i.UpdatedAt,
+			Status:    i.Status,
+		},
+		UserID:   i.UserID,
+		Name:     i.Name,
+		Price:    i.Price,
+		Unit:     i.Unit,
+		Currency: i.Currency,
+	}
+}
+
+// FromIngredientPriceDomain converts a domain.IngredientPrice to an IngredientPriceEntity
+func FromIngredientPriceDomain(price *domain.IngredientPrice) *IngredientPriceEntity {
+	if price.BaseModel == nil {
+		price.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	return &IngredientPriceEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        price.ID,
+			CreatedAt: price.CreatedAt,
+			UpdatedAt: price.UpdatedAt,
+			Status:    price.Status,
+		},
+		UserID:   price.UserID,
+		Name:     price.Name,
+		Price:    price.Price,
+		Unit:     price.Unit,
+		Currency: price.Currency,
+	}
+}
+
+// RecipeCostEntity is the database model for recipe costs computed from the shared prices
+type RecipeCostEntity struct {
+	RecipeID       uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Currency       string    `gorm:"type:char(3);primaryKey;index:idx_recipe_cost_per_serving,priority:1"`
+	TotalCost      float64   `gorm:"not null"`
+	CostPerServing float64   `gorm:"not null;index:idx_recipe_cost_per_serving,priority:2"`
+	Complete       bool      `gorm:"not null;default:false"`
+	ComputedAt     time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for the RecipeCostEntity
+func (RecipeCostEntity) TableName() string {
+	return "recipe_costs"
+}
+
+// IngredientPriceRepository is the repository implementation for ingredient prices and recipe costs
+type IngredientPriceRepository struct {
+	db *gorm.DB
+}
+
+// NewIngredientPriceRepository creates a new ingredient price repository
+func NewIngredientPriceRepository(db *gorm.DB) interfaces.IngredientPriceRepository {
+	return &IngredientPriceRepository{db: db}
+}
+
+// Create creates an ingredient price
+func (r *IngredientPriceRepository) Create(ctx context.Context, price *domain.IngredientPrice) error {
+	return r.db.WithContext(ctx).Create(FromIngredientPriceDomain(price)).Error
+}
+
+// GetByID gets an ingredient price by ID
+func (r *IngredientPriceRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.IngredientPrice, error) {
+	var entity IngredientPriceEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToIngredientPriceDomain(), nil
+}
+
+// GetByName gets the price of an ingredient name in a currency, ignoring case
+func (r *IngredientPriceRepository) GetByName(ctx context.Context, userID *uuid.UUID, name string, currency string) (*domain.IngredientPrice, error) {
+	query := r.db.WithContext(ctx).Where("LOWER(name) = LOWER(?) AND currency = ? AND status = ?", name, currency, 1)
+	if userID == nil {
+		query = query.Where("user_id IS NULL")
+	} else {
+		query = query.Where("user_id = ?", *userID)
+	}
+
+	var entity IngredientPriceEntity
+	if err := query.First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToIngredientPriceDomain(), nil
+}
+
+// GetPrices gets the shared prices and the user's own prices in a currency, the user's first
+func (r *IngredientPriceRepository) GetPrices(ctx context.Context, userID uuid.UUID, currency string) ([]domain.IngredientPrice, error) {
+	var entities []IngredientPriceEntity
+	if err := r.db.WithContext(ctx).
+		Where("(user_id IS NULL OR user_id = ?) AND currency = ? AND status = ?", userID, currency, 1).
+		Order("user_id IS NULL, name ASC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return toIngredientPricesDomain(entities), nil
+}
+
+// GetSharedPrices gets the shared prices in every currency
+func (r *IngredientPriceRepository) GetSharedPrices(ctx context.Context) ([]domain.IngredientPrice, error) {
+	var entities []IngredientPriceEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id IS NULL AND status = ?", 1).
+		Order("currency ASC, name ASC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return toIngredientPricesDomain(entities), nil
+}
+
+// Update updates an ingredient price
+func (r *IngredientPriceRepository) Update(ctx context.Context, price *domain.IngredientPrice) error {
+	entity := FromIngredientPriceDomain(price)
+	return r.db.WithContext(ctx).Model(&IngredientPriceEntity{}).Where("id = ?", entity.ID).Updates(map[string]interface{}{
+		"name":       entity.Name,
+		"price":      entity.Price,
+		"unit":       entity.Unit,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// Delete soft deletes an ingredient price
+func (r *IngredientPriceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&IngredientPriceEntity{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     0,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// ReplaceRecipeCosts replaces all stored recipe costs in a single transaction
+func (r *IngredientPriceRepository) ReplaceRecipeCosts(ctx context.Context, costs []domain.RecipeCostSummary) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&RecipeCostEntity{}).Error; err != nil {
+			return err
+		}
+
+		if len(costs) == 0 {
+			return nil
+		}
+
+		entities := make([]RecipeCostEntity, len(costs))
+		for i, cost := range costs {
+			entities[i] = RecipeCostEntity{
+				RecipeID:       cost.RecipeID,
+				Currency:       cost.Currency,
+				TotalCost:      cost.TotalCost,
+				CostPerServing: cost.CostPerServing,
+				Complete:       cost.Complete,
+				ComputedAt:     cost.ComputedAt,
+			}
+		}
+		return tx.CreateInBatches(&entities, 500).Error
+	})
+}
+
+func toIngredientPricesDomain(entities []IngredientPriceEntity) []domain.IngredientPrice {
+	prices := make([]domain.IngredientPrice, len(entities))
+	for i, entity := range entities {
+		prices[i] = *entity.ToIngredientPriceDomain()
+	}
+	return prices
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CanonicalIngredientEntity is the database model for catalogue ingredients
+type CanonicalIngredientEntity struct {
+	*common.BaseEntity
+	Name        string            `json:"name" gorm:"type:varchar(191);not null;index"`
+	Plural      string            `json:"plural" gorm:"type:varchar(191)"`
+	Synonyms    []string          `json:"synonyms" gorm:"serializer:json;type:text"`
+	Category    string            `json:"category" gorm:"type:varchar(32);not null;index"`
+	Density     *float64          `json:"density"`
+	NutrientRef string            `json:"nutrient_ref" gorm:"type:varchar(64)"`
+	Nutrition   *domain.Nutrition `json:"nutrition" gorm:"serializer:json;type:text"`
+}
+
+// TableName returns the table name for the CanonicalIngredientEntity
+func (i *CanonicalIngredientEntity) TableName() string {
+	return "ingredients"
+}
+
+// IngredientTermEntity maps a normalized name, plural or synonym to the catalogue ingredient it belongs to
+type IngredientTermEntity struct {
+	Term         string    `json:"term" gorm:"type:varchar(191);primaryKey"`
+	Display      string    `json:"display" gorm:"type:varchar(191);not null"`
+	IngredientID uuid.UUID `json:"ingredient_id" gorm:"type:char(36);not null;index"`
+}
+
+// TableName returns the table name for the IngredientTermEntity
+func (t *IngredientTermEntity) TableName() string {
+	return "ingredient_terms"
+}
+
+// ToCanonicalIngredientDomain converts a CanonicalIngredientEntity to a domain.CanonicalIngredient
+func (i *CanonicalIngredientEntity) ToCanonicalIngredientDomain() *domain.CanonicalIngredient {
+	synonyms := i.Synonyms
+	if synonyms == nil {
+		synonyms = []string{}
+	}
+
+	return &domain.CanonicalIngredient{
+		BaseModel: &common.BaseModel{
+			ID:        i.ID,
+			CreatedAt: i.CreatedAt,
+			UpdatedAt: i.UpdatedAt,
+			Status:    i.Status,
+		},
+		Name:        i.Name,
+		Plural:      i.Plural,
+		Synonyms:    synonyms,
+		Category:    i.Category,
+		Density:     i.Density,
+		NutrientRef: i.NutrientRef,
+		Nutrition:   i.Nutrition,
+	}
+}
+
+// FromCanonicalIngredientDomain converts a domain.CanonicalIngredient to a CanonicalIngredientEntity
+func FromCanonicalIngredientDomain(ingredient *domain.CanonicalIngredient) *CanonicalIngredientEntity {
+	if ingredient.BaseModel == nil {
+		ingredient.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	return &CanonicalIngredientEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        ingredient.ID,
+			CreatedAt: ingredient.CreatedAt,
+			UpdatedAt: ingredient.UpdatedAt,
+			Status:    ingredient.Status,
+		},
+		Name:        ingredient.Name,
+		Plural:      ingredient.Plural,
+		Synonyms:    ingredient.Synonyms,
+		Category:    ingredient.Category,
+		Density:     ingredient.Density,
+		NutrientRef: ingredient.NutrientRef,
+		Nutrition:   ingredient.Nutrition,
+	}
+}
+
+// IngredientRepository is the repository implementation for the ingredient catalogue
+type IngredientRepository struct {
+	db *gorm.DB
+}
+
+// NewIngredientRepository creates a new ingredient catalogue repository
+func NewIngredientRepository(db *gorm.DB) interfaces.IngredientRepository {
+	return &IngredientRepository{db: db}
+}
+
+// Create creates a catalogue ingredient along with its lookup terms, keyed by normalized term
+func (r *IngredientRepository) Create(ctx context.Context, ingredient *domain.CanonicalIngredient, terms map[string]string) error {
+	entity := FromCanonicalIngredientDomain(ingredient)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entity).Error; err != nil {
+			return err
+		}
+		return createIngredientTerms(tx, entity.ID, terms)
+	})
+}
+
+// GetByID gets a catalogue ingredient by ID
+func (r *IngredientRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CanonicalIngredient, error) {
+	var entity CanonicalIngredientEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToCanonicalIngredientDomain(), nil
+}
+
+// Update updates a catalogue ingredient and replaces its lookup terms
+func (r *IngredientRepository) Update(ctx context.Context, ingredient *domain.CanonicalIngredient, terms map[string]string) error {
+	ingredient.UpdatedAt = time.Now()
+	entity := FromCanonicalIngredientDomain(ingredient)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(entity).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("ingredient_id = ?", entity.ID).Delete(&IngredientTermEntity{}).Error; err != nil {
+			return err
+		}
+		return createIngredientTerms(tx, entity.ID, terms)
+	})
+}
+
+// Delete soft deletes a catalogue ingredient. Its lookup terms are removed so they can be reused.
+func (r *IngredientRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&CanonicalIngredientEntity{}).Where("id = ?", id).Updates(map[string]interface{}{
+			"status":     0,
+			"updated_at": time.Now(),
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Where("ingredient_id = ?", id).Delete(&IngredientTermEntity{}).Error
+	})
+}
+
+// GetIDsByTerms gets the IDs of the ingredients owning each of the given normalized terms
+func (r *IngredientRepository) GetIDsByTerms(ctx context.Context, terms []string) (map[string]uuid.UUID, error) {
+	ids := make(map[string]uuid.UUID)
+	if len(terms) == 0 {
+		return ids, nil
+	}
+
+	var entities []IngredientTermEntity
+	if err := r.db.WithContext(ctx).Where("term IN ?", terms).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	for _, entity := range entities {
+		ids[entity.Term] = entity.IngredientID
+	}
+	return ids, nil
+}
+
+// SearchTerms finds the terms starting with the query, or with a word starting with it. Exact
+// matches come first, then shorter terms.
+func (r *IngredientRepository) SearchTerms(ctx context.Context, query string, limit int) ([]domain.IngredientSuggestion, error) {
+	pattern := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query)
+
+	var suggestions []domain.IngredientSuggestion
+	err := r.db.WithContext(ctx).Model(&IngredientTermEntity{}).
+		Select("ingredient_terms.ingredient_id, ingredients.name, ingredients.category, ingredient_terms.display AS matched").
+		Joins("JOIN ingredients ON ingredients.id = ingredient_terms.ingredient_id AND ingredients.status = ?", 1).
+		Where("ingredient_terms.term LIKE ? OR ingredient_terms.term LIKE ?", pattern+"%", "% "+pattern+"%").
+		Order(gorm.Expr("ingredient_terms.term = ? DESC", query)).
+		Order("CHAR_LENGTH(ingredient_terms.term), ingredient_terms.term").
+		Limit(limit).
+		Scan(&suggestions).Error
+	if err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+func createIngredientTerms(tx *gorm.DB, ingredientID uuid.UUID, terms map[string]string) error {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	entities := make([]IngredientTermEntity, 0, len(terms))
+	for term, display := range terms {
+		entities = append(entities, IngredientTermEntity{Term: term, Display: display, IngredientID: ingredientID})
+	}
+	return tx.Create(&entities).Error
+}
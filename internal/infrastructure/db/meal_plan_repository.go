@@ -0,0 +1,155 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// mealPlanDateLayout is the layout of meal plan dates in the domain
+const mealPlanDateLayout = "2006-01-02"
+
+// MealPlanEntryEntity is the database model for meal plan entries
+type MealPlanEntryEntity struct {
+	*common.BaseEntity
+	UserID   uuid.UUID `json:"user_id" gorm:"type:char(36);not null;index:idx_meal_plan_user_date"`
+	RecipeID uuid.UUID `json:"recipe_id" gorm:"type:char(36);not null;index"`
+	Date     time.Time `json:"date" gorm:"type:date;not null;index:idx_meal_plan_user_date"`
+	Slot     string    `json:"slot" gorm:"type:varchar(16);not null"`
+	Servings int       `json:"servings" gorm:"default:0"`
+}
+
+// TableName returns the table name for the MealPlanEntryEntity
+func (m *MealPlanEntryEntity) TableName() string {
+	return "meal_plan_entries"
+}
+
+// ToMealPlanEntryDomain converts a MealPlanEntryEntity to a domain.MealPlanEntry
+func (m *MealPlanEntryEntity) ToMealPlanEntryDomain() *domain.MealPlanEntry {
+	return &domain.MealPlanEntry{
+		BaseModel: &common.BaseModel{
+			ID:        m.ID,
+			CreatedAt: m.CreatedAt,
+			UpdatedAt: m.UpdatedAt,
+			Status:    m.Status,
+		},
+		UserID:   m.UserID,
+		RecipeID: m.RecipeID,
+		Date:     m.Date.Format(mealPlanDateLayout),
+		Slot:     m.Slot,
+		Servings: m.Servings,
+	}
+}
+
+// FromMealPlanEntryDomain converts a domain.MealPlanEntry to a MealPlanEntryEntity
+func FromMealPlanEntryDomain(entry *domain.MealPlanEntry) *MealPlanEntryEntity {
+	if entry.BaseModel == nil {
+		entry.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	// The service validates dates, an unparsable one is stored as the zero date
+	date, _ := time.ParseInLocation(mealPlanDateLayout, entry.Date, time.Local)
+
+	return &MealPlanEntryEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        entry.ID,
+			CreatedAt: entry.CreatedAt,
+			UpdatedAt: entry.UpdatedAt,
+			Status:    entry.Status,
+		},
+		UserID:   entry.UserID,
+		RecipeID: entry.RecipeID,
+		Date:     date,
+		Slot:     entry.Slot,
+		Servings: entry.Servings,
+	}
+}
+
+// MealPlanRepository is the repository implementation for meal plans
+type MealPlanRepository struct {
+	db *gorm.DB
+}
+
+// NewMealPlanRepository creates a new meal plan repository
+func NewMealPlanRepository(db *gorm.DB) interfaces.MealPlanRepository {
+	return &MealPlanRepository{db: db}
+}
+
+// Create creates a meal plan entry
+func (r *MealPlanRepository) Create(ctx context.Context, entry *domain.MealPlanEntry) error {
+	return r.db.WithContext(ctx).Create(FromMealPlanEntryDomain(entry)).Error
+}
+
+// CreateEntries creates several meal plan entries in one statement
+func (r *MealPlanRepository) CreateEntries(ctx context.Context, entries []domain.MealPlanEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	entities := make([]*MealPlanEntryEntity, len(entries))
+	for i := range entries {
+		entities[i] = FromMealPlanEntryDomain(&entries[i])
+	}
+	return r.db.WithContext(ctx).Create(&entities).Error
+}
+
+// GetByID gets a meal plan entry by ID
+func (r *MealPlanRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.MealPlanEntry, error) {
+	var entity MealPlanEntryEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToMealPlanEntryDomain(), nil
+}
+
+// Update updates the date, slot and servings of a meal plan entry
+func (r *MealPlanRepository) Update(ctx context.Context, entry *domain.MealPlanEntry) error {
+	entity := FromMealPlanEntryDomain(entry)
+	return r.db.WithContext(ctx).Model(&MealPlanEntryEntity{}).Where("id = ?", entity.ID).Updates(map[string]interface{}{
+		"date":       entity.Date,
+		"slot":       entity.Slot,
+		"servings":   entity.Servings,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// Delete deletes a meal plan entry
+func (r *MealPlanRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&MealPlanEntryEntity{}, "id = ?", id).Error
+}
+
+// GetByUserAndDateRange gets a user's entries between two dates, ordered by date
+func (r *MealPlanRepository) GetByUserAndDateRange(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.MealPlanEntry, error) {
+	var entities []MealPlanEntryEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, 1).
+		Where("date BETWEEN ? AND ?", from.Format(mealPlanDateLayout), to.Format(mealPlanDateLayout)).
+		Order("date ASC, created_at ASC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	entries := make([]domain.MealPlanEntry, len(entities))
+	for i, entity := range entities {
+		entries[i] = *entity.ToMealPlanEntryDomain()
+	}
+	return entries, nil
+}
+
+// DeleteByUserAndDateRange deletes a user's entries between two dates
+func (r *MealPlanRepository) DeleteByUserAndDateRange(ctx context.Context, userID uuid.UUID, from, to time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Where("date BETWEEN ? AND ?", from.Format(mealPlanDateLayout), to.Format(mealPlanDateLayout)).
+		Delete(&MealPlanEntryEntity{}).Error
+}
@@ -0,0 +1,198 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PantryItemEntity is the database model for pantry items
+type PantryItemEntity struct {
+	*common.BaseEntity
+	UserID           uuid.UUID  `json:"user_id" gorm:"type:char(36);not null;index"`
+	Name             string     `json:"name" gorm:"not null"`
+	Amount           float64    `json:"amount"`
+	Unit             string     `json:"unit"`
+	ExpiresAt        *time.Time `json:"expires_at" gorm:"index"`
+	ExpiryNotifiedAt *time.Time `json:"expiry_notified_at"`
+}
+
+// TableName returns the table name for the PantryItemEntity
+func (p *PantryItemEntity) TableName() string {
+	return "pantry_items"
+}
+
+// ToPantryItemDomain converts a PantryItemEntity to a domain.PantryItem
+func (p *PantryItemEntity) ToPantryItemDomain() *domain.PantryItem {
+	return &domain.PantryItem{
+		BaseModel: &common.BaseModel{
+			ID:        p.ID,
+			CreatedAt: p.CreatedAt,
+			UpdatedAt: p.UpdatedAt,
+			Status:    p.Status,
+		},
+		UserID:           p.UserID,
+		Name:             p.Name,
+		Amount:           p.Amount,
+		Unit:             p.Unit,
+		ExpiresAt:        p.ExpiresAt,
+		ExpiryNotifiedAt: p.ExpiryNotifiedAt,
+	}
+}
+
+// FromPantryItemDomain converts a domain.PantryItem to a PantryItemEntity
+func FromPantryItemDomain(item *domain.PantryItem) *PantryItemEntity {
+	if item.BaseModel == nil {
+		item.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	return &PantryItemEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        item.ID,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+			Status:    item.Status,
+		},
+		UserID:           item.UserID,
+		Name:             item.Name,
+		Amount:           item.Amount,
+		Unit:             item.Unit,
+		ExpiresAt:        item.ExpiresAt,
+		ExpiryNotifiedAt: item.ExpiryNotifiedAt,
+	}
+}
+
+// PantryRepository is the repository implementation for pantry items
+type PantryRepository struct {
+	db *gorm.DB
+}
+
+// NewPantryRepository creates a new pantry repository
+func NewPantryRepository(db *gorm.DB) interfaces.PantryRepository {
+	return &PantryRepository{db: db}
+}
+
+// Create creates a pantry item
+func (r *PantryRepository) Create(ctx context.Context, item *domain.PantryItem) error {
+	return r.db.WithContext(ctx).Create(FromPantryItemDomain(item)).Error
+}
+
+// GetByID gets a pantry item by ID
+func (r *PantryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.PantryItem, error) {
+	var entity PantryItemEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToPantryItemDomain(), nil
+}
+
+// GetByUserID gets all pantry items of a user; items without an expiry date come last
+func (r *PantryRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.PantryItem, error) {
+	var entities []PantryItemEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, 1).
+		Order("expires_at IS NULL, expires_at ASC, name ASC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return toPantryItemsDomain(entities), nil
+}
+
+// GetUnnotifiedExpiringBefore gets the items of all users that expire before the given time
+// and whose owners have not been warned yet
+func (r *PantryRepository) GetUnnotifiedExpiringBefore(ctx context.Context, before time.Time) ([]domain.PantryItem, error) {
+	var entities []PantryItemEntity
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at < ? AND expiry_notified_at IS NULL", 1, before).
+		Order("user_id ASC, expires_at ASC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return toPantryItemsDomain(entities), nil
+}
+
+// Update updates a pantry item
+func (r *PantryRepository) Update(ctx context.Context, item *domain.PantryItem) error {
+	entity := FromPantryItemDomain(item)
+	return r.db.WithContext(ctx).Model(&PantryItemEntity{}).Where("id = ?", entity.ID).Updates(map[string]interface{}{
+		"name":               entity.Name,
+		"amount":             entity.Amount,
+		"unit":               entity.Unit,
+		"expires_at":         entity.ExpiresAt,
+		"expiry_notified_at": entity.ExpiryNotifiedAt,
+		"updated_at":         time.Now(),
+	}).Error
+}
+
+// Deduct subtracts the used amounts from the user's pantry items in one transaction. Each update
+// only applies while the item still holds the amount, so concurrent cooks cannot both use the same stock.
+func (r *PantryRepository) Deduct(ctx context.Context, userID uuid.UUID, deductions []domain.PantryDeduction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return deductPantryItems(tx, userID, deductions)
+	})
+}
+
+// pantryAmountTolerance absorbs floating point error when comparing amounts
+const pantryAmountTolerance = 1e-9
+
+// deductPantryItems applies pantry deductions inside the caller's transaction and removes used up items
+func deductPantryItems(tx *gorm.DB, userID uuid.UUID, deductions []domain.PantryDeduction) error {
+	now := time.Now()
+	for _, deduction := range deductions {
+		result := tx.Model(&PantryItemEntity{}).
+			Where("id = ? AND user_id = ? AND status = ? AND amount >= ?", deduction.ItemID, userID, 1, deduction.Amount-pantryAmountTolerance).
+			Updates(map[string]interface{}{
+				"amount":     gorm.Expr("GREATEST(amount - ?, 0)", deduction.Amount),
+				"updated_at": now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return interfaces.ErrPantryChanged
+		}
+
+		// Used up items leave the pantry
+		if err := tx.Model(&PantryItemEntity{}).
+			Where("id = ? AND amount <= ?", deduction.ItemID, pantryAmountTolerance).
+			Updates(map[string]interface{}{"amount": 0, "status": 0}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MarkExpiryNotified records that the owners of the items were warned about their expiry
+func (r *PantryRepository) MarkExpiryNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&PantryItemEntity{}).Where("id IN ?", ids).
+		Update("expiry_notified_at", at).Error
+}
+
+// Delete soft deletes a pantry item
+func (r *PantryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&PantryItemEntity{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     0,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+func toPantryItemsDomain(entities []PantryItemEntity) []domain.PantryItem {
+	items := make([]domain.PantryItem, len(entities))
+	for i, entity := range entities {
+		items[i] = *entity.ToPantryItemDomain()
+	}
+	return items
+}
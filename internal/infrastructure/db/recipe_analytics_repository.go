@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RecipeViewEntity is the database model for recipe views, one row per viewer per recipe per window
+type RecipeViewEntity struct {
+	RecipeID    uuid.UUID `gorm:"type:char(36);primaryKey"`
+	ViewerID    uuid.UUID `gorm:"type:char(36);primaryKey"`
+	WindowStart time.Time `gorm:"primaryKey"`
+	ViewedAt    time.Time `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for this entity
+func (RecipeViewEntity) TableName() string {
+	return "recipe_views"
+}
+
+// RecipeDailyStatsEntity is the database model for the daily rollup of recipe activity
+type RecipeDailyStatsEntity struct {
+	RecipeID uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Day      time.Time `gorm:"type:date;primaryKey;index"`
+	Views    int       `gorm:"not null;default:0"`
+	Saves    int       `gorm:"not null;default:0"`
+	Ratings  int       `gorm:"not null;default:0"`
+	Forks    int       `gorm:"not null;default:0"`
+}
+
+// TableName specifies the table name for this entity
+func (RecipeDailyStatsEntity) TableName() string {
+	return "recipe_daily_stats"
+}
+
+// ToRecipeDailyStatsDomain converts a RecipeDailyStatsEntity to a domain.RecipeDailyStats
+func (r *RecipeDailyStatsEntity) ToRecipeDailyStatsDomain() domain.RecipeDailyStats {
+	return domain.RecipeDailyStats{
+		RecipeID: r.RecipeID,
+		Day:      r.Day,
+		RecipeStats: domain.RecipeStats{
+			Views:   r.Views,
+			Saves:   r.Saves,
+			Ratings: r.Ratings,
+			Forks:   r.Forks,
+		},
+	}
+}
+
+// RecipeAnalyticsRepository is the repository implementation for recipe views and daily stats
+type RecipeAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+// NewRecipeAnalyticsRepository creates a new recipe analytics repository
+func NewRecipeAnalyticsRepository(db *gorm.DB) interfaces.RecipeAnalyticsRepository {
+	return &RecipeAnalyticsRepository{db: db}
+}
+
+// RecordView records a view. A second view by the same viewer in the same window hits the
+// primary key and is ignored.
+func (r *RecipeAnalyticsRepository) RecordView(ctx context.Context, view domain.RecipeView) error {
+	entity := &RecipeViewEntity{
+		RecipeID:    view.RecipeID,
+		ViewerID:    view.ViewerID,
+		WindowStart: view.WindowStart,
+		ViewedAt:    view.ViewedAt,
+	}
+	return r.db.WithContext(ctx).Clauses(clause.Insert{Modifier: "IGNORE"}).Create(entity).Error
+}
+
+// RollupDailyStats recomputes the daily views, saves, ratings and forks of every recipe in a single transaction
+func (r *RecipeAnalyticsRepository) RollupDailyStats(ctx context.Context, from, to time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("day >= ? AND day < ?", from, to).Delete(&RecipeDailyStatsEntity{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(
+			"INSERT INTO recipe_daily_stats (recipe_id, day, views, saves, ratings, forks) "+
+				"SELECT recipe_id, day, SUM(views), SUM(saves), SUM(ratings), SUM(forks) FROM ("+
+				"SELECT recipe_id, DATE(viewed_at) AS day, 1 AS views, 0 AS saves, 0 AS ratings, 0 AS forks "+
+				"FROM recipe_views WHERE viewed_at >= ? AND viewed_at < ? "+
+				"UNION ALL SELECT recipe_id, DATE(created_at), 0, 1, 0, 0 "+
+				"FROM recipe_collections WHERE created_at >= ? AND created_at < ? "+
+				"UNION ALL SELECT recipe_id, DATE(created_at), 0, 0, 1, 0 "+
+				"FROM recipe_ratings WHERE created_at >= ? AND created_at < ? "+
+				"UNION ALL SELECT forked_from_id, DATE(created_at), 0, 0, 0, 1 "+
+				"FROM recipes WHERE forked_from_id IS NOT NULL AND status = ? AND created_at >= ? AND created_at < ?"+
+				") activity GROUP BY recipe_id, day",
+			from, to, from, to, from, to, 1, from, to,
+		).Error
+	})
+}
+
+// DeleteViewsBefore deletes views recorded before the given time
+func (r *RecipeAnalyticsRepository) DeleteViewsBefore(ctx context.Context, before time.Time) error {
+	return r.db.WithContext(ctx).Where("viewed_at < ?", before).Delete(&RecipeViewEntity{}).Error
+}
+
+// GetAuthorDailyStats gets the daily stats of an author's active recipes, oldest day first
+func (r *RecipeAnalyticsRepository) GetAuthorDailyStats(ctx context.Context, authorID uuid.UUID, recipeID uuid.UUID, from, to time.Time) ([]domain.RecipeDailyStats, error) {
+	query := r.db.WithContext(ctx).
+		Model(&RecipeDailyStatsEntity{}).
+		Joins("JOIN recipes ON recipes.id = recipe_daily_stats.recipe_id").
+		Where("recipes.user_id = ? AND recipes.status = ?", authorID, 1).
+		Where("recipe_daily_stats.day >= ? AND recipe_daily_stats.day < ?", from, to)
+
+	if recipeID != uuid.Nil {
+		query = query.Where("recipe_daily_stats.recipe_id = ?", recipeID)
+	}
+
+	var entities []RecipeDailyStatsEntity
+	if err := query.Order("recipe_daily_stats.day ASC").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	stats := make([]domain.RecipeDailyStats, len(entities))
+	for i, entity := range entities {
+		stats[i] = entity.ToRecipeDailyStatsDomain()
+	}
+	return stats, nil
+}
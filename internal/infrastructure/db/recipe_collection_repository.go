@@ -248,6 +248,20 @@ func (r *RecipeCollectionRepository) IsRecipeInCollection(ctx context.Context, c
 	return count > 0, err
 }
 
+// GetCollectionIDsForAllRecipes maps every saved recipe to the collections that contain it
+func (r *RecipeCollectionRepository) GetCollectionIDsForAllRecipes(ctx context.Context) (map[uuid.UUID][]uuid.UUID, error) {
+	var entities []RecipeCollectionEntity
+	if err := r.db.WithContext(ctx).Select("collection_id", "recipe_id").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	collections := make(map[uuid.UUID][]uuid.UUID)
+	for _, entity := range entities {
+		collections[entity.RecipeID] = append(collections[entity.RecipeID], entity.CollectionID)
+	}
+	return collections, nil
+}
+
 // TestCursorConversion is a debug method to test the cursor conversion logic
 // It should be removed in production, but helps verify that the conversion works
 func TestCursorConversion() (uuid.UUID, time.Time, bool) {
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecipeFingerprintEntity is the database model for recipe MinHash signatures
+type RecipeFingerprintEntity struct {
+	RecipeID  uuid.UUID `gorm:"type:char(36);primaryKey"`
+	UserID    uuid.UUID `gorm:"type:char(36);not null;index"`
+	Signature []uint64  `gorm:"serializer:json;type:text"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for this entity
+func (RecipeFingerprintEntity) TableName() string {
+	return "recipe_fingerprints"
+}
+
+// RecipeFingerprintBandEntity is the database model for the LSH band hashes used to find candidate duplicates
+type RecipeFingerprintBandEntity struct {
+	RecipeID uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Band     int       `gorm:"primaryKey;autoIncrement:false;index:idx_fingerprint_band_hash,priority:1"`
+	Hash     uint64    `gorm:"type:bigint unsigned;not null;index:idx_fingerprint_band_hash,priority:2"`
+}
+
+// TableName specifies the table name for this entity
+func (RecipeFingerprintBandEntity) TableName() string {
+	return "recipe_fingerprint_bands"
+}
+
+// RecipeDuplicateEntity is the database model for likely duplicate recipes
+type RecipeDuplicateEntity struct {
+	RecipeID      uuid.UUID `gorm:"type:char(36);primaryKey"`
+	DuplicateOfID uuid.UUID `gorm:"type:char(36);primaryKey;index"`
+	Similarity    float64   `gorm:"not null"`
+	SameAuthor    bool      `gorm:"not null;default:false"`
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for this entity
+func (RecipeDuplicateEntity) TableName() string {
+	return "recipe_duplicates"
+}
+
+// ToRecipeDuplicateDomain converts a RecipeDuplicateEntity to a domain.RecipeDuplicate
+func (r *RecipeDuplicateEntity) ToRecipeDuplicateDomain() domain.RecipeDuplicate {
+	return domain.RecipeDuplicate{
+		RecipeID:      r.RecipeID,
+		DuplicateOfID: r.DuplicateOfID,
+		Similarity:    r.Similarity,
+		SameAuthor:    r.SameAuthor,
+		CreatedAt:     r.CreatedAt,
+	}
+}
+
+// RecipeDuplicateRepository is the repository implementation for duplicate recipe detection
+type RecipeDuplicateRepository struct {
+	db *gorm.DB
+}
+
+// NewRecipeDuplicateRepository creates a new recipe duplicate repository
+func NewRecipeDuplicateRepository(db *gorm.DB) interfaces.RecipeDuplicateRepository {
+	return &RecipeDuplicateRepository{db: db}
+}
+
+// SaveFingerprint replaces the fingerprint and band hashes of a recipe in a single transaction
+func (r *RecipeDuplicateRepository) SaveFingerprint(ctx context.Context, fingerprint domain.RecipeFingerprint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		entity := &RecipeFingerprintEntity{
+			RecipeID:  fingerprint.RecipeID,
+			UserID:    fingerprint.UserID,
+			Signature: fingerprint.Signature,
+			UpdatedAt: fingerprint.UpdatedAt,
+		}
+		if err := tx.Save(entity).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("recipe_id = ?", fingerprint.RecipeID).Delete(&RecipeFingerprintBandEntity{}).Error; err != nil {
+			return err
+		}
+
+		var bands []RecipeFingerprintBandEntity
+		for band, hash := range fingerprint.Bands {
+			if hash != 0 {
+				bands = append(bands, RecipeFingerprintBandEntity{RecipeID: fingerprint.RecipeID, Band: band, Hash: hash})
+			}
+		}
+		if len(bands) == 0 {
+			return nil
+		}
+		return tx.Create(&bands).Error
+	})
+}
+
+// FindCandidates gets the fingerprints of active recipes sharing a band hash with the given bands
+func (r *RecipeDuplicateRepository) FindCandidates(ctx context.Context, recipeID uuid.UUID, bands []uint64, limit int) ([]domain.RecipeFingerprint, error) {
+	var pairs [][]interface{}
+	for band, hash := range bands {
+		if hash != 0 {
+			pairs = append(pairs, []interface{}{band, hash})
+		}
+	}
+	if len(pairs) == 0 {
+		return []domain.RecipeFingerprint{}, nil
+	}
+
+	var entities []RecipeFingerprintEntity
+	if err := r.db.WithContext(ctx).
+		Where("recipe_id IN (?)", r.db.Model(&RecipeFingerprintBandEntity{}).
+			Distinct("recipe_id").
+			Where("(band, hash) IN ?", pairs).
+			Where("recipe_id <> ?", recipeID)).
+		Where("EXISTS (SELECT 1 FROM recipes WHERE recipes.id = recipe_fingerprints.recipe_id AND recipes.status = ?)", 1).
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	fingerprints := make([]domain.RecipeFingerprint, len(entities))
+	for i, entity := range entities {
+		fingerprints[i] = domain.RecipeFingerprint{
+			RecipeID:  entity.RecipeID,
+			UserID:    entity.UserID,
+			Signature: entity.Signature,
+			UpdatedAt: entity.UpdatedAt,
+		}
+	}
+	return fingerprints, nil
+}
+
+// GetUnfingerprintedRecipeIDs gets the IDs of active recipes without a fingerprint, oldest first
+func (r *RecipeDuplicateRepository) GetUnfingerprintedRecipeIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.WithContext(ctx).
+		Model(&RecipeEntity{}).
+		Where("status = ?", 1).
+		Where("NOT EXISTS (SELECT 1 FROM recipe_fingerprints WHERE recipe_fingerprints.recipe_id = recipes.id)").
+		Order("created_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ReplaceDuplicates replaces the duplicates found for a recipe in a single transaction
+func (r *RecipeDuplicateRepository) ReplaceDuplicates(ctx context.Context, recipeID uuid.UUID, duplicates []domain.RecipeDuplicate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("recipe_id = ?", recipeID).Delete(&RecipeDuplicateEntity{}).Error; err != nil {
+			return err
+		}
+
+		if len(duplicates) == 0 {
+			return nil
+		}
+
+		entities := make([]RecipeDuplicateEntity, len(duplicates))
+		for i, duplicate := range duplicates {
+			entities[i] = RecipeDuplicateEntity{
+				RecipeID:      recipeID,
+				DuplicateOfID: duplicate.DuplicateOfID,
+				Similarity:    duplicate.Similarity,
+				SameAuthor:    duplicate.SameAuthor,
+				CreatedAt:     duplicate.CreatedAt,
+			}
+		}
+		return tx.Create(&entities).Error
+	})
+}
+
+// GetDuplicates gets the duplicates where both recipes are still active, most similar first
+func (r *RecipeDuplicateRepository) GetDuplicates(ctx context.Context, limit int) ([]domain.RecipeDuplicate, error) {
+	var entities []RecipeDuplicateEntity
+	if err := r.db.WithContext(ctx).
+		Where("EXISTS (SELECT 1 FROM recipes WHERE recipes.id = recipe_duplicates.recipe_id AND recipes.status = ?)", 1).
+		Where("EXISTS (SELECT 1 FROM recipes WHERE recipes.id = recipe_duplicates.duplicate_of_id AND recipes.status = ?)", 1).
+		Order("similarity DESC").
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	duplicates := make([]domain.RecipeDuplicate, len(entities))
+	for i, entity := range entities {
+		duplicates[i] = entity.ToRecipeDuplicateDomain()
+	}
+	return duplicates, nil
+}
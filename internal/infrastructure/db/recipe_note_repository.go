@@ -0,0 +1,130 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecipeNoteEntity is the database model for private recipe notes
+type RecipeNoteEntity struct {
+	*common.BaseEntity
+	UserID    uuid.UUID         `json:"user_id" gorm:"type:char(36);not null;index:idx_recipe_note_user_recipe"`
+	RecipeID  uuid.UUID         `json:"recipe_id" gorm:"type:char(36);not null;index:idx_recipe_note_user_recipe"`
+	Content   string            `json:"content" gorm:"type:text"`
+	StepNotes []domain.StepNote `json:"step_notes" gorm:"serializer:json;type:text"`
+}
+
+// TableName returns the table name for the RecipeNoteEntity
+func (n *RecipeNoteEntity) TableName() string {
+	return "recipe_notes"
+}
+
+// ToRecipeNoteDomain converts a RecipeNoteEntity to a domain.RecipeNote
+func (n *RecipeNoteEntity) ToRecipeNoteDomain() *domain.RecipeNote {
+	stepNotes := n.StepNotes
+	if stepNotes == nil {
+		stepNotes = []domain.StepNote{}
+	}
+
+	return &domain.RecipeNote{
+		BaseModel: &common.BaseModel{
+			ID:        n.ID,
+			CreatedAt: n.CreatedAt,
+			UpdatedAt: n.UpdatedAt,
+			Status:    n.Status,
+		},
+		UserID:    n.UserID,
+		RecipeID:  n.RecipeID,
+		Content:   n.Content,
+		StepNotes: stepNotes,
+	}
+}
+
+// FromRecipeNoteDomain converts a domain.RecipeNote to a RecipeNoteEntity
+func FromRecipeNoteDomain(note *domain.RecipeNote) *RecipeNoteEntity {
+	if note.BaseModel == nil {
+		note.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	return &RecipeNoteEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        note.ID,
+			CreatedAt: note.CreatedAt,
+			UpdatedAt: note.UpdatedAt,
+			Status:    note.Status,
+		},
+		UserID:    note.UserID,
+		RecipeID:  note.RecipeID,
+		Content:   note.Content,
+		StepNotes: note.StepNotes,
+	}
+}
+
+// RecipeNoteRepository is the repository implementation for private recipe notes
+type RecipeNoteRepository struct {
+	db *gorm.DB
+}
+
+// NewRecipeNoteRepository creates a new recipe note repository
+func NewRecipeNoteRepository(db *gorm.DB) interfaces.RecipeNoteRepository {
+	return &RecipeNoteRepository{db: db}
+}
+
+// GetByUserAndRecipe gets the user's note on a recipe
+func (r *RecipeNoteRepository) GetByUserAndRecipe(ctx context.Context, userID, recipeID uuid.UUID) (*domain.RecipeNote, error) {
+	var entity RecipeNoteEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND recipe_id = ? AND status = ?", userID, recipeID, 1).
+		First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToRecipeNoteDomain(), nil
+}
+
+// GetByUserID gets the user's notes, most recently updated first
+func (r *RecipeNoteRepository) GetByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]domain.RecipeNote, error) {
+	var entities []RecipeNoteEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, 1).
+		Order("updated_at DESC, id DESC").
+		Offset(offset).Limit(limit).
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	notes := make([]domain.RecipeNote, len(entities))
+	for i, entity := range entities {
+		notes[i] = *entity.ToRecipeNoteDomain()
+	}
+	return notes, nil
+}
+
+// Create creates a note
+func (r *RecipeNoteRepository) Create(ctx context.Context, note *domain.RecipeNote) error {
+	return r.db.WithContext(ctx).Create(FromRecipeNoteDomain(note)).Error
+}
+
+// Update updates a note
+func (r *RecipeNoteRepository) Update(ctx context.Context, note *domain.RecipeNote) error {
+	note.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Save(FromRecipeNoteDomain(note)).Error
+}
+
+// Delete soft deletes a note
+func (r *RecipeNoteRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&RecipeNoteEntity{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":     0,
+		"updated_at": time.Now(),
+	}).Error
+}
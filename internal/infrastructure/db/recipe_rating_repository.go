@@ -15,10 +15,11 @@ import (
 // RecipeRatingEntity is the database model for recipe ratings
 type RecipeRatingEntity struct {
 	*common.BaseEntity
-	RecipeID uuid.UUID `json:"recipe_id" gorm:"type:char(36);not null;index"`
-	UserID   uuid.UUID `json:"user_id" gorm:"type:char(36);not null;index"`
-	Rating   int       `json:"rating" gorm:"not null"`
-	Comment  string    `json:"comment"`
+	RecipeID     uuid.UUID `json:"recipe_id" gorm:"type:char(36);not null;index"`
+	UserID       uuid.UUID `json:"user_id" gorm:"type:char(36);not null;index"`
+	Rating       int       `json:"rating" gorm:"not null"`
+	Comment      string    `json:"comment"`
+	VerifiedCook bool      `json:"verified_cook" gorm:"default:false"` // the user has logged cooking the recipe
 }
 
 // TableName returns the table name for the RecipeRatingEntity
@@ -35,10 +36,11 @@ func (r *RecipeRatingEntity) ToRatingDomain() *domain.RecipeRating {
 			UpdatedAt: r.UpdatedAt,
 			Status:    r.Status,
 		},
-		RecipeID: r.RecipeID,
-		UserID:   r.UserID,
-		Rating:   r.Rating,
-		Comment:  r.Comment,
+		RecipeID:     r.RecipeID,
+		UserID:       r.UserID,
+		Rating:       r.Rating,
+		Comment:      r.Comment,
+		VerifiedCook: r.VerifiedCook,
 	}
 }
 
@@ -66,10 +68,11 @@ func FromRatingDomain(rating *domain.RecipeRating) *RecipeRatingEntity {
 			UpdatedAt: rating.UpdatedAt,
 			Status:    rating.Status,
 		},
-		RecipeID: rating.RecipeID,
-		UserID:   rating.UserID,
-		Rating:   rating.Rating,
-		Comment:  rating.Comment,
+		RecipeID:     rating.RecipeID,
+		UserID:       rating.UserID,
+		Rating:       rating.Rating,
+		Comment:      rating.Comment,
+		VerifiedCook: rating.VerifiedCook,
 	}
 }
 
@@ -104,9 +107,10 @@ func (r *RecipeRatingRepository) GetRating(ctx context.Context, id uuid.UUID) (*
 func (r *RecipeRatingRepository) UpdateRating(ctx context.Context, rating *domain.RecipeRating) error {
 	entity := FromRatingDomain(rating)
 	result := r.db.Model(&RecipeRatingEntity{}).Where("id = ?", entity.ID).Updates(map[string]interface{}{
-		"rating":     entity.Rating,
-		"comment":    entity.Comment,
-		"updated_at": time.Now(),
+		"rating":        entity.Rating,
+		"comment":       entity.Comment,
+		"verified_cook": entity.VerifiedCook,
+		"updated_at":    time.Now(),
 	})
 	if result.Error != nil {
 		return result.Error
@@ -178,6 +182,13 @@ func (r *RecipeRatingRepository) GetRatingByUserAndRecipeID(ctx context.Context,
 	return entity.ToRatingDomain(), nil
 }
 
+// SetVerifiedCook marks the user's rating of a recipe as verified or not, if the user rated it
+func (r *RecipeRatingRepository) SetVerifiedCook(ctx context.Context, userID, recipeID uuid.UUID, verified bool) error {
+	return r.db.WithContext(ctx).Model(&RecipeRatingEntity{}).
+		Where("user_id = ? AND recipe_id = ?", userID, recipeID).
+		Update("verified_cook", verified).Error
+}
+
 // UpdateRecipeRatingSummary calculates and updates the rating summary for a recipe
 func (r *RecipeRatingRepository) UpdateRecipeRatingSummary(ctx context.Context, recipeID uuid.UUID) error {
 	// Calculate the rating count and average
@@ -8,6 +8,7 @@ import (
 	"database/sql/driver"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,14 +16,26 @@ import (
 )
 
 type IngredientEntity struct {
-	Name   string  `json:"name"`
-	Amount float64 `json:"amount"`
-	Unit   string  `json:"unit"`
+	Name         string     `json:"name"`
+	Amount       float64    `json:"amount"`
+	Unit         string     `json:"unit"`
+	AmountMax    float64    `json:"amount_max,omitempty"`
+	Note         string     `json:"note,omitempty"`
+	IngredientID *uuid.UUID `json:"ingredient_id,omitempty"`
+}
+
+type TemperatureEntity struct {
+	Value float64 `json:"value"`
+	Unit  string  `json:"unit"`
 }
 
 type StepEntity struct {
-	Order   int    `json:"order"`
-	Content string `json:"content"`
+	Order          int                `json:"order"`
+	Content        string             `json:"content"`
+	Duration       int                `json:"duration,omitempty"`
+	Temperature    *TemperatureEntity `json:"temperature,omitempty"`
+	Images         []common.Image     `json:"images,omitempty"`
+	IngredientRefs []int              `json:"ingredient_refs,omitempty"`
 }
 
 // Ingredients type for JSON serialization
@@ -67,6 +80,152 @@ func (s *StepsEntity) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, s)
 }
 
+// NutritionEntity stores the nutrition facts of one serving
+type NutritionEntity struct {
+	Calories      float64 `json:"calories"`
+	Protein       float64 `json:"protein"`
+	Carbohydrates float64 `json:"carbohydrates"`
+	Fat           float64 `json:"fat"`
+	Fiber         float64 `json:"fiber"`
+	Sugar         float64 `json:"sugar"`
+	Sodium        float64 `json:"sodium"`
+}
+
+// SectionEntity stores a recipe section; expanded sub-recipes are never persisted
+type SectionEntity struct {
+	Name        string            `json:"name"`
+	Ingredients IngredientsEntity `json:"ingredients,omitempty"`
+	Steps       StepsEntity       `json:"steps,omitempty"`
+	RecipeID    *uuid.UUID        `json:"recipe_id,omitempty"`
+	Multiplier  float64           `json:"multiplier,omitempty"`
+}
+
+// Sections type for JSON serialization
+type SectionsEntity []SectionEntity
+
+// Value implements the driver.Valuer interface for Sections
+func (s SectionsEntity) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for Sections
+func (s *SectionsEntity) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, s)
+}
+
+func (i IngredientsEntity) toDomain() domain.Ingredients {
+	ingredients := make([]domain.Ingredient, len(i))
+	for index, ingredient := range i {
+		ingredients[index] = domain.Ingredient{
+			Name:         ingredient.Name,
+			Amount:       ingredient.Amount,
+			Unit:         ingredient.Unit,
+			AmountMax:    ingredient.AmountMax,
+			Note:         ingredient.Note,
+			IngredientID: ingredient.IngredientID,
+		}
+	}
+	return ingredients
+}
+
+func fromIngredientsDomain(ingredients domain.Ingredients) IngredientsEntity {
+	entities := make(IngredientsEntity, len(ingredients))
+	for i, ingredient := range ingredients {
+		entities[i] = IngredientEntity{
+			Name:         ingredient.Name,
+			Amount:       ingredient.Amount,
+			Unit:         ingredient.Unit,
+			AmountMax:    ingredient.AmountMax,
+			Note:         ingredient.Note,
+			IngredientID: ingredient.IngredientID,
+		}
+	}
+	return entities
+}
+
+func (s StepsEntity) toDomain() domain.Steps {
+	steps := make([]domain.Step, len(s))
+	for i, step := range s {
+		steps[i] = domain.Step{
+			Order:          step.Order,
+			Content:        step.Content,
+			Duration:       step.Duration,
+			Images:         step.Images,
+			IngredientRefs: step.IngredientRefs,
+		}
+		if step.Temperature != nil {
+			steps[i].Temperature = &domain.Temperature{
+				Value: step.Temperature.Value,
+				Unit:  step.Temperature.Unit,
+			}
+		}
+	}
+	return steps
+}
+
+func fromStepsDomain(steps domain.Steps) StepsEntity {
+	entities := make(StepsEntity, len(steps))
+	for i, step := range steps {
+		entities[i] = StepEntity{
+			Order:          step.Order,
+			Content:        step.Content,
+			Duration:       step.Duration,
+			Images:         step.Images,
+			IngredientRefs: step.IngredientRefs,
+		}
+		if step.Temperature != nil {
+			entities[i].Temperature = &TemperatureEntity{
+				Value: step.Temperature.Value,
+				Unit:  step.Temperature.Unit,
+			}
+		}
+	}
+	return entities
+}
+
+func (s SectionsEntity) toDomain() domain.Sections {
+	if len(s) == 0 {
+		return nil
+	}
+	sections := make([]domain.RecipeSection, len(s))
+	for i, section := range s {
+		sections[i] = domain.RecipeSection{
+			Name:       section.Name,
+			RecipeID:   section.RecipeID,
+			Multiplier: section.Multiplier,
+		}
+		if len(section.Ingredients) > 0 {
+			sections[i].Ingredients = section.Ingredients.toDomain()
+		}
+		if len(section.Steps) > 0 {
+			sections[i].Steps = section.Steps.toDomain()
+		}
+	}
+	return sections
+}
+
+func fromSectionsDomain(sections domain.Sections) SectionsEntity {
+	entities := make(SectionsEntity, len(sections))
+	for i, section := range sections {
+		entities[i] = SectionEntity{
+			Name:        section.Name,
+			Ingredients: fromIngredientsDomain(section.Ingredients),
+			Steps:       fromStepsDomain(section.Steps),
+			RecipeID:    section.RecipeID,
+			Multiplier:  section.Multiplier,
+		}
+	}
+	return entities
+}
+
 // StringArray type for JSON serialization of string arrays
 type StringArrayEntity []string
 
@@ -95,12 +254,59 @@ type RecipeEntity struct {
 	Description string            `json:"description"`
 	Time        int               `json:"time" gorm:"not null"` // cooking time in minutes
 	CategoryID  uuid.UUID         `json:"category_id" gorm:"type:char(36);not null"`
-	ServingSize int               `json:"serving_size" gorm:"not null"`            // number of people
-	Images      []common.Image    `json:"images" gorm:"serializer:json;type:text"` // JSON array of image URLs
-	Ingredients IngredientsEntity `json:"ingredients" gorm:"type:json"`            // JSON array of ingredients
-	Steps       StepsEntity       `json:"steps" gorm:"type:json"`                  // JSON array of steps
-	RatingCount int               `json:"rating_count" gorm:"default:0"`           // Number of ratings
-	AvgRating   float64           `json:"avg_rating" gorm:"default:0"`             // Average rating (0-5)
+	ServingSize int               `json:"serving_size" gorm:"not null"`               // number of people
+	Images      []common.Image    `json:"images" gorm:"serializer:json;type:text"`    // JSON array of image URLs
+	Ingredients IngredientsEntity `json:"ingredients" gorm:"type:json"`               // JSON array of ingredients
+	Steps       StepsEntity       `json:"steps" gorm:"type:json"`                     // JSON array of steps
+	Sections    SectionsEntity    `json:"sections" gorm:"type:json"`                  // JSON array of sections
+	Nutrition   *NutritionEntity  `json:"nutrition" gorm:"serializer:json;type:text"` // nutrition facts per serving
+	Tags        StringArrayEntity `json:"tags" gorm:"type:json"`                      // JSON array of tags
+	RatingCount int               `json:"rating_count" gorm:"default:0"`              // Number of ratings
+	AvgRating   float64           `json:"avg_rating" gorm:"default:0"`                // Average rating (0-5)
+	CookCount   int               `json:"cook_count" gorm:"default:0"`                // Number of times the recipe was logged as cooked
+	// ForkedFromID is the recipe this one was copied from, if any
+	ForkedFromID *uuid.UUID `json:"forked_from_id" gorm:"type:char(36);index"`
+	// Locale is the language the recipe was written in
+	Locale       string                   `json:"locale" gorm:"type:varchar(16);not null;default:'en'"`
+	Translations RecipeTranslationsEntity `json:"translations" gorm:"serializer:json;type:text"` // translations keyed by locale
+	// TrashedAt is when the recipe was deleted, so the purge job knows when to remove it for good
+	TrashedAt *time.Time `json:"trashed_at" gorm:"index"`
+}
+
+// RecipeTranslationEntity is the text of a recipe in another locale
+type RecipeTranslationEntity struct {
+	Title             string    `json:"title"`
+	Description       string    `json:"description"`
+	Ingredients       []string  `json:"ingredients,omitempty"`
+	Steps             []string  `json:"steps,omitempty"`
+	SourceIngredients []string  `json:"source_ingredients,omitempty"`
+	SourceSteps       []string  `json:"source_steps,omitempty"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// RecipeTranslationsEntity maps locales to translations
+type RecipeTranslationsEntity map[string]RecipeTranslationEntity
+
+func (t RecipeTranslationsEntity) toDomain() map[string]domain.RecipeTranslation {
+	if len(t) == 0 {
+		return nil
+	}
+	translations := make(map[string]domain.RecipeTranslation, len(t))
+	for locale, translation := range t {
+		translations[locale] = domain.RecipeTranslation(translation)
+	}
+	return translations
+}
+
+func fromTranslationsDomain(translations map[string]domain.RecipeTranslation) RecipeTranslationsEntity {
+	if len(translations) == 0 {
+		return nil
+	}
+	entities := make(RecipeTranslationsEntity, len(translations))
+	for locale, translation := range translations {
+		entities[locale] = RecipeTranslationEntity(translation)
+	}
+	return entities
 }
 
 func (r *RecipeEntity) TableName() string {
@@ -108,44 +314,48 @@ func (r *RecipeEntity) TableName() string {
 }
 
 func (r *RecipeEntity) ToRecipeDomain() *domain.Recipe {
-	ingredients := make([]domain.Ingredient, len(r.Ingredients))
-	for i, ingredient := range r.Ingredients {
-		ingredients[i] = domain.Ingredient{
-			Name:   ingredient.Name,
-			Amount: ingredient.Amount,
-			Unit:   ingredient.Unit,
-		}
-	}
-	steps := make([]domain.Step, len(r.Steps))
-	for i, step := range r.Steps {
-		steps[i] = domain.Step{
-			Order:   step.Order,
-			Content: step.Content,
-		}
-	}
 	var images []common.Image
 	if r.Images != nil {
 		images = r.Images
 	}
 
+	tags := []string(r.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+
+	locale := r.Locale
+	if locale == "" {
+		locale = domain.DefaultLocale
+	}
+
 	return &domain.Recipe{
 		BaseModel: &common.BaseModel{
 			ID:        r.ID,
 			CreatedAt: r.CreatedAt,
 			UpdatedAt: r.UpdatedAt,
 			Status:    r.Status,
+			Version:   r.Version,
 		},
-		UserID:      r.UserID,
-		Title:       r.Title,
-		Description: r.Description,
-		Time:        r.Time,
-		CategoryID:  r.CategoryID,
-		ServingSize: r.ServingSize,
-		Images:      images,
-		Ingredients: domain.Ingredients(ingredients),
-		Steps:       domain.Steps(steps),
-		RatingCount: r.RatingCount,
-		AvgRating:   r.AvgRating,
+		UserID:       r.UserID,
+		Title:        r.Title,
+		Description:  r.Description,
+		Time:         r.Time,
+		CategoryID:   r.CategoryID,
+		ServingSize:  r.ServingSize,
+		Images:       images,
+		Ingredients:  r.Ingredients.toDomain(),
+		Steps:        r.Steps.toDomain(),
+		Sections:     r.Sections.toDomain(),
+		Nutrition:    (*domain.Nutrition)(r.Nutrition),
+		Tags:         tags,
+		RatingCount:  r.RatingCount,
+		AvgRating:    r.AvgRating,
+		CookCount:    r.CookCount,
+		ForkedFromID: r.ForkedFromID,
+		Locale:       locale,
+		Translations: r.Translations.toDomain(),
+		TrashedAt:    r.TrashedAt,
 	}
 }
 
@@ -155,57 +365,49 @@ func FromRecipeDomain(recipe *domain.Recipe) *RecipeEntity {
 		return nil
 	}
 
-	ingredients := make([]IngredientEntity, len(recipe.Ingredients))
-	for i, ingredient := range recipe.Ingredients {
-		ingredients[i] = IngredientEntity{
-			Name:   ingredient.Name,
-			Amount: ingredient.Amount,
-			Unit:   ingredient.Unit,
-		}
-	}
-
-	steps := make([]StepEntity, len(recipe.Steps))
-	for i, step := range recipe.Steps {
-		steps[i] = StepEntity{
-			Order:   step.Order,
-			Content: step.Content,
-		}
-	}
-
 	var images []common.Image
 	if recipe.Images != nil {
 		images = recipe.Images
 	}
 
 	entity := &RecipeEntity{
-		UserID:      recipe.UserID,
-		Title:       recipe.Title,
-		Description: recipe.Description,
-		Time:        recipe.Time,
-		CategoryID:  recipe.CategoryID,
-		ServingSize: recipe.ServingSize,
-		Images:      images,
-		Ingredients: ingredients,
-		Steps:       steps,
-		RatingCount: recipe.RatingCount,
-		AvgRating:   recipe.AvgRating,
-	}
-
-	if recipe.BaseModel != nil {
-		entity.BaseEntity = &common.BaseEntity{
-			ID:        recipe.ID,
-			CreatedAt: recipe.CreatedAt,
-			UpdatedAt: recipe.UpdatedAt,
-			Status:    recipe.Status,
-		}
-	} else {
-		entity.BaseEntity = &common.BaseEntity{
+		UserID:       recipe.UserID,
+		Title:        recipe.Title,
+		Description:  recipe.Description,
+		Time:         recipe.Time,
+		CategoryID:   recipe.CategoryID,
+		ServingSize:  recipe.ServingSize,
+		Images:       images,
+		Ingredients:  fromIngredientsDomain(recipe.Ingredients),
+		Steps:        fromStepsDomain(recipe.Steps),
+		Sections:     fromSectionsDomain(recipe.Sections),
+		Nutrition:    (*NutritionEntity)(recipe.Nutrition),
+		Tags:         StringArrayEntity(recipe.Tags),
+		RatingCount:  recipe.RatingCount,
+		AvgRating:    recipe.AvgRating,
+		CookCount:    recipe.CookCount,
+		ForkedFromID: recipe.ForkedFromID,
+		Locale:       recipe.Locale,
+		Translations: fromTranslationsDomain(recipe.Translations),
+	}
+
+	// New recipes get their ID here; set it on the domain recipe too so callers can refer to it
+	if recipe.BaseModel == nil {
+		recipe.BaseModel = &common.BaseModel{
 			ID:        uuid.New(),
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 			Status:    1,
+			Version:   1,
 		}
 	}
+	entity.BaseEntity = &common.BaseEntity{
+		ID:        recipe.ID,
+		CreatedAt: recipe.CreatedAt,
+		UpdatedAt: recipe.UpdatedAt,
+		Status:    recipe.Status,
+		Version:   recipe.Version,
+	}
 
 	return entity
 }
@@ -219,9 +421,39 @@ func (r *RecipeRepository) CreateRecipe(ctx context.Context, recipe *domain.Reci
 	return r.db.WithContext(ctx).Create(FromRecipeDomain(recipe)).Error
 }
 
-// DeleteRecipe implements interfaces.RecipeRepository.
-func (r *RecipeRepository) DeleteRecipe(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Update("status", 0).Error
+// CreateRecipes implements interfaces.RecipeRepository. The recipes are created in a single
+// transaction, so either all of them are saved or none is.
+func (r *RecipeRepository) CreateRecipes(ctx context.Context, recipes []*domain.Recipe) error {
+	if len(recipes) == 0 {
+		return nil
+	}
+
+	entities := make([]*RecipeEntity, len(recipes))
+	for i, recipe := range recipes {
+		entities[i] = FromRecipeDomain(recipe)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&entities).Error
+	})
+}
+
+// DeleteRecipe implements interfaces.RecipeRepository. It returns interfaces.ErrVersionConflict when
+// the recipe is no longer at the given version.
+func (r *RecipeRepository) DeleteRecipe(ctx context.Context, id uuid.UUID, version int64) error {
+	now := time.Now()
+	result := r.db.WithContext(ctx).Model(&RecipeEntity{}).Where("id = ? AND version = ?", id, version).Updates(map[string]interface{}{
+		"status":     0,
+		"trashed_at": now,
+		"updated_at": now,
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrVersionConflict
+	}
+	return nil
 }
 
 // GetRecipe implements interfaces.RecipeRepository.
@@ -258,6 +490,14 @@ func (r *RecipeRepository) FilterRecipesByCondition(ctx context.Context, conditi
 			query = query.Where("ingredients = ?", value)
 		case "title":
 			query = query.Where("title LIKE ?", "%"+value.(string)+"%")
+		case "max_cost":
+			// Costs per serving are stored per currency by the cost cron job. Costs missing the price of
+			// some ingredient understate the total, so only complete costs count.
+			currency, _ := conditions["currency"].(string)
+			if currency == "" {
+				currency = domain.DefaultCurrency
+			}
+			query = query.Where("EXISTS (SELECT 1 FROM recipe_costs WHERE recipe_costs.recipe_id = recipes.id AND recipe_costs.currency = ? AND recipe_costs.complete = ? AND recipe_costs.cost_per_serving <= ?)", currency, true, value)
 		}
 	}
 
@@ -277,6 +517,81 @@ func (r *RecipeRepository) FilterRecipesByCondition(ctx context.Context, conditi
 	return recipesDomain, nextCursor, nil
 }
 
+// FindRecipesByIngredientNames finds recipes with an ingredient whose name contains any of the given names
+func (r *RecipeRepository) FindRecipesByIngredientNames(ctx context.Context, names []string, limit int) ([]domain.Recipe, error) {
+	var recipes []RecipeEntity
+
+	clauses := make([]string, 0, len(names))
+	args := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		clauses = append(clauses, "LOWER(CAST(JSON_EXTRACT(ingredients, '$[*].name') AS CHAR)) LIKE ?")
+		args = append(args, "%"+likeEscaper.Replace(name)+"%")
+	}
+	if len(clauses) == 0 {
+		return []domain.Recipe{}, nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", 1).
+		Where("("+strings.Join(clauses, " OR ")+")", args...).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+
+	recipesDomain := make([]domain.Recipe, len(recipes))
+	for i, recipe := range recipes {
+		recipesDomain[i] = *recipe.ToRecipeDomain()
+	}
+	return recipesDomain, nil
+}
+
+// ListRecipes lists active recipes in a stable order, for jobs that walk over all recipes
+func (r *RecipeRepository) ListRecipes(ctx context.Context, offset, limit int) ([]domain.Recipe, error) {
+	var recipes []RecipeEntity
+	if err := r.db.WithContext(ctx).
+		Where("status = ?", 1).
+		Order("id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+
+	recipesDomain := make([]domain.Recipe, len(recipes))
+	for i, recipe := range recipes {
+		recipesDomain[i] = *recipe.ToRecipeDomain()
+	}
+	return recipesDomain, nil
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListRecipesByUser implements interfaces.RecipeRepository.
+func (r *RecipeRepository) ListRecipesByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]domain.Recipe, error) {
+	var recipes []RecipeEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, 1).
+		Order("created_at ASC, id ASC").
+		Offset(offset).
+		Limit(limit).
+		Find(&recipes).Error; err != nil {
+		return nil, err
+	}
+
+	recipesDomain := make([]domain.Recipe, len(recipes))
+	for i, recipe := range recipes {
+		recipesDomain[i] = *recipe.ToRecipeDomain()
+	}
+	return recipesDomain, nil
+}
+
 // UpdateRecipe implements interfaces.RecipeRepository.
 func (r *RecipeRepository) UpdateRecipe(ctx context.Context, recipe *domain.Recipe) error {
 	// First get the existing recipe to ensure it exists and belongs to the user
@@ -288,6 +603,9 @@ func (r *RecipeRepository) UpdateRecipe(ctx context.Context, recipe *domain.Reci
 	if existingRecipe.Status == 0 {
 		return errors.New("recipe not found")
 	}
+	if existingRecipe.Version != recipe.Version {
+		return interfaces.ErrVersionConflict
+	}
 
 	updatedRecipe := FromRecipeDomain(recipe)
 	existingRecipe.Title = updatedRecipe.Title
@@ -298,9 +616,30 @@ func (r *RecipeRepository) UpdateRecipe(ctx context.Context, recipe *domain.Reci
 	existingRecipe.Images = updatedRecipe.Images
 	existingRecipe.Ingredients = updatedRecipe.Ingredients
 	existingRecipe.Steps = updatedRecipe.Steps
+	existingRecipe.Sections = updatedRecipe.Sections
+	existingRecipe.Nutrition = updatedRecipe.Nutrition
+	existingRecipe.Tags = updatedRecipe.Tags
+	existingRecipe.Locale = updatedRecipe.Locale
+	existingRecipe.Translations = updatedRecipe.Translations
+
+	// Update the editable columns and bump the version, but only if nobody else updated the recipe since
+	// it was read. The rating and cook counters are left alone: they change without bumping the version.
+	existingRecipe.Version = recipe.Version + 1
+	result := r.db.WithContext(ctx).
+		Select("title", "description", "time", "category_id", "serving_size", "images", "ingredients", "steps",
+			"sections", "nutrition", "tags", "locale", "translations", "version", "updated_at").
+		Where("version = ?", recipe.Version).
+		Updates(&existingRecipe)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrVersionConflict
+	}
 
-	// Update the recipe using Save to trigger hooks
-	return r.db.WithContext(ctx).Save(&existingRecipe).Error
+	recipe.Version++
+	recipe.UpdatedAt = existingRecipe.UpdatedAt
+	return nil
 }
 
 func NewRecipeRepository(db *gorm.DB) interfaces.RecipeRepository {
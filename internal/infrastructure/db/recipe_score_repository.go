@@ -0,0 +1,134 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecipeScoreEntity is the database model for precomputed recipe rankings
+type RecipeScoreEntity struct {
+	RecipeID      uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Window        string    `gorm:"column:time_window;type:varchar(16);primaryKey;index:idx_recipe_scores_trending,priority:1;index:idx_recipe_scores_top,priority:1"`
+	TrendingScore float64   `gorm:"not null;index:idx_recipe_scores_trending,priority:2"`
+	TopScore      float64   `gorm:"not null;index:idx_recipe_scores_top,priority:2"`
+	RatingCount   int       `gorm:"not null"`
+	ComputedAt    time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for this entity
+func (RecipeScoreEntity) TableName() string {
+	return "recipe_scores"
+}
+
+// ToRecipeScoreDomain converts a RecipeScoreEntity to a domain.RecipeScore
+func (r *RecipeScoreEntity) ToRecipeScoreDomain() domain.RecipeScore {
+	return domain.RecipeScore{
+		RecipeID:      r.RecipeID,
+		Window:        r.Window,
+		TrendingScore: r.TrendingScore,
+		TopScore:      r.TopScore,
+		RatingCount:   r.RatingCount,
+		ComputedAt:    r.ComputedAt,
+	}
+}
+
+// RecipeScoreRepository is the repository implementation for recipe rankings
+type RecipeScoreRepository struct {
+	db *gorm.DB
+}
+
+// NewRecipeScoreRepository creates a new recipe score repository
+func NewRecipeScoreRepository(db *gorm.DB) interfaces.RecipeScoreRepository {
+	return &RecipeScoreRepository{db: db}
+}
+
+// GetRecipeEngagement sums the ratings, saves, cooks and views of each active recipe since the given time.
+// Each event is weighted by exp(-age / tau), which halves its weight every half-life.
+func (r *RecipeScoreRepository) GetRecipeEngagement(ctx context.Context, since, now time.Time, halfLife time.Duration) ([]domain.RecipeEngagement, error) {
+	if since.IsZero() {
+		since = time.Unix(0, 0)
+	}
+	tau := halfLife.Seconds() / math.Ln2
+
+	var engagement []domain.RecipeEngagement
+	if err := r.db.WithContext(ctx).Raw(
+		"SELECT events.recipe_id, "+
+			"SUM(CASE WHEN events.kind = 'rating' THEN events.rating / 5 * events.decay ELSE 0 END) AS decayed_rating, "+
+			"SUM(CASE WHEN events.kind = 'save' THEN events.decay ELSE 0 END) AS decayed_saves, "+
+			"SUM(CASE WHEN events.kind = 'cook' THEN events.decay ELSE 0 END) AS decayed_cooks, "+
+			"SUM(CASE WHEN events.kind = 'view' THEN events.decay ELSE 0 END) AS decayed_views, "+
+			"SUM(CASE WHEN events.kind = 'rating' THEN 1 ELSE 0 END) AS rating_count, "+
+			"SUM(CASE WHEN events.kind = 'rating' THEN events.rating ELSE 0 END) AS rating_sum "+
+			"FROM (SELECT recipe_id, kind, rating, EXP(-GREATEST(TIMESTAMPDIFF(SECOND, occurred_at, ?), 0) / ?) AS decay FROM ("+
+			"SELECT recipe_id, 'rating' AS kind, rating, created_at AS occurred_at FROM recipe_ratings WHERE created_at >= ? "+
+			"UNION ALL SELECT recipe_id, 'save', 0, created_at FROM recipe_collections WHERE created_at >= ? "+
+			"UNION ALL SELECT recipe_id, 'cook', 0, cooked_at FROM cook_logs WHERE status = ? AND cooked_at >= ? "+
+			"UNION ALL SELECT recipe_id, 'view', 0, viewed_at FROM recipe_views WHERE viewed_at >= ?"+
+			") raw_events) events "+
+			"JOIN recipes ON recipes.id = events.recipe_id AND recipes.status = ? "+
+			"GROUP BY events.recipe_id",
+		now, tau, since, since, 1, since, since, 1,
+	).Scan(&engagement).Error; err != nil {
+		return nil, err
+	}
+	return engagement, nil
+}
+
+// ReplaceScores replaces the scores of a ranking window in a single transaction
+func (r *RecipeScoreRepository) ReplaceScores(ctx context.Context, window string, scores []domain.RecipeScore) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("time_window = ?", window).Delete(&RecipeScoreEntity{}).Error; err != nil {
+			return err
+		}
+
+		if len(scores) == 0 {
+			return nil
+		}
+
+		entities := make([]RecipeScoreEntity, len(scores))
+		for i, score := range scores {
+			entities[i] = RecipeScoreEntity{
+				RecipeID:      score.RecipeID,
+				Window:        window,
+				TrendingScore: score.TrendingScore,
+				TopScore:      score.TopScore,
+				RatingCount:   score.RatingCount,
+				ComputedAt:    score.ComputedAt,
+			}
+		}
+		return tx.CreateInBatches(&entities, 500).Error
+	})
+}
+
+// GetTrending gets the scores of a ranking window with the highest trending score first
+func (r *RecipeScoreRepository) GetTrending(ctx context.Context, window string, offset, limit int) ([]domain.RecipeScore, error) {
+	return r.getRanking(r.db.WithContext(ctx).
+		Where("time_window = ?", window).
+		Order("trending_score DESC, recipe_id ASC"), offset, limit)
+}
+
+// GetTop gets the scores of recipes rated within a ranking window with the highest top score first
+func (r *RecipeScoreRepository) GetTop(ctx context.Context, window string, offset, limit int) ([]domain.RecipeScore, error) {
+	return r.getRanking(r.db.WithContext(ctx).
+		Where("time_window = ? AND rating_count > 0", window).
+		Order("top_score DESC, rating_count DESC, recipe_id ASC"), offset, limit)
+}
+
+func (r *RecipeScoreRepository) getRanking(query *gorm.DB, offset, limit int) ([]domain.RecipeScore, error) {
+	var entities []RecipeScoreEntity
+	if err := query.Offset(offset).Limit(limit).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	scores := make([]domain.RecipeScore, len(entities))
+	for i, entity := range entities {
+		scores[i] = entity.ToRecipeScoreDomain()
+	}
+	return scores, nil
+}
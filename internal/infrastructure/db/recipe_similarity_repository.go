@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecipeSimilarityEntity is the database model for precomputed similar recipes
+type RecipeSimilarityEntity struct {
+	RecipeID        uuid.UUID `gorm:"type:char(36);primaryKey"`
+	SimilarRecipeID uuid.UUID `gorm:"type:char(36);primaryKey"`
+	Score           float64   `gorm:"not null"`
+	Rank            int       `gorm:"not null"`
+	ComputedAt      time.Time `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for this entity
+func (RecipeSimilarityEntity) TableName() string {
+	return "recipe_similarities"
+}
+
+// ToRecipeSimilarityDomain converts a RecipeSimilarityEntity to a domain.RecipeSimilarity
+func (r *RecipeSimilarityEntity) ToRecipeSimilarityDomain() domain.RecipeSimilarity {
+	return domain.RecipeSimilarity{
+		RecipeID:        r.RecipeID,
+		SimilarRecipeID: r.SimilarRecipeID,
+		Score:           r.Score,
+		Rank:            r.Rank,
+		ComputedAt:      r.ComputedAt,
+	}
+}
+
+// RecipeSimilarityRepository is the repository implementation for precomputed similar recipes
+type RecipeSimilarityRepository struct {
+	db *gorm.DB
+}
+
+// NewRecipeSimilarityRepository creates a new recipe similarity repository
+func NewRecipeSimilarityRepository(db *gorm.DB) interfaces.RecipeSimilarityRepository {
+	return &RecipeSimilarityRepository{db: db}
+}
+
+// GetByRecipeID gets the precomputed neighbours of a recipe, most similar first
+func (r *RecipeSimilarityRepository) GetByRecipeID(ctx context.Context, recipeID uuid.UUID, limit int) ([]domain.RecipeSimilarity, error) {
+	var entities []RecipeSimilarityEntity
+	if err := r.db.WithContext(ctx).
+		Where("recipe_id = ?", recipeID).
+		Order("`rank` ASC").
+		Limit(limit).
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	similarities := make([]domain.RecipeSimilarity, len(entities))
+	for i, entity := range entities {
+		similarities[i] = entity.ToRecipeSimilarityDomain()
+	}
+	return similarities, nil
+}
+
+// ReplaceForRecipe replaces the precomputed neighbours of a recipe in a single transaction
+func (r *RecipeSimilarityRepository) ReplaceForRecipe(ctx context.Context, recipeID uuid.UUID, similarities []domain.RecipeSimilarity) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("recipe_id = ?", recipeID).Delete(&RecipeSimilarityEntity{}).Error; err != nil {
+			return err
+		}
+
+		if len(similarities) == 0 {
+			return nil
+		}
+
+		entities := make([]RecipeSimilarityEntity, len(similarities))
+		for i, similarity := range similarities {
+			entities[i] = RecipeSimilarityEntity{
+				RecipeID:        recipeID,
+				SimilarRecipeID: similarity.SimilarRecipeID,
+				Score:           similarity.Score,
+				Rank:            similarity.Rank,
+				ComputedAt:      similarity.ComputedAt,
+			}
+		}
+		return tx.Create(&entities).Error
+	})
+}
+
+// DeleteComputedBefore deletes neighbours computed before the given time
+func (r *RecipeSimilarityRepository) DeleteComputedBefore(ctx context.Context, before time.Time) error {
+	return r.db.WithContext(ctx).Where("computed_at < ?", before).Delete(&RecipeSimilarityEntity{}).Error
+}
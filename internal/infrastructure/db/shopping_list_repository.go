@@ -0,0 +1,283 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ShoppingListEntity is the database model for shopping lists
+type ShoppingListEntity struct {
+	*common.BaseEntity
+	UserID     uuid.UUID `json:"user_id" gorm:"type:char(36);not null;index"`
+	Name       string    `json:"name" gorm:"not null"`
+	ShareToken *string   `json:"share_token" gorm:"type:varchar(64);uniqueIndex"`
+}
+
+// TableName returns the table name for the ShoppingListEntity
+func (s *ShoppingListEntity) TableName() string {
+	return "shopping_lists"
+}
+
+// ShoppingListItemEntity is the database model for shopping list items
+type ShoppingListItemEntity struct {
+	*common.BaseEntity
+	ListID    uuid.UUID         `json:"list_id" gorm:"type:char(36);not null;index"`
+	Name      string            `json:"name" gorm:"not null"`
+	Amount    float64           `json:"amount"`
+	Unit      string            `json:"unit"`
+	Aisle     string            `json:"aisle"`
+	Checked   bool              `json:"checked" gorm:"default:false"`
+	Manual    bool              `json:"manual" gorm:"default:false"`
+	RecipeIDs StringArrayEntity `json:"recipe_ids" gorm:"type:json"`
+}
+
+// TableName returns the table name for the ShoppingListItemEntity
+func (s *ShoppingListItemEntity) TableName() string {
+	return "shopping_list_items"
+}
+
+// ToShoppingListDomain converts a ShoppingListEntity to a domain.ShoppingList
+func (s *ShoppingListEntity) ToShoppingListDomain() *domain.ShoppingList {
+	list := &domain.ShoppingList{
+		BaseModel: &common.BaseModel{
+			ID:        s.ID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+			Status:    s.Status,
+		},
+		UserID: s.UserID,
+		Name:   s.Name,
+	}
+	if s.ShareToken != nil {
+		list.ShareToken = *s.ShareToken
+	}
+	return list
+}
+
+// FromShoppingListDomain converts a domain.ShoppingList to a ShoppingListEntity
+func FromShoppingListDomain(list *domain.ShoppingList) *ShoppingListEntity {
+	if list.BaseModel == nil {
+		list.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	entity := &ShoppingListEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        list.ID,
+			CreatedAt: list.CreatedAt,
+			UpdatedAt: list.UpdatedAt,
+			Status:    list.Status,
+		},
+		UserID: list.UserID,
+		Name:   list.Name,
+	}
+	// Unshared lists store NULL so the unique index only applies to real tokens
+	if list.ShareToken != "" {
+		token := list.ShareToken
+		entity.ShareToken = &token
+	}
+	return entity
+}
+
+// ToShoppingListItemDomain converts a ShoppingListItemEntity to a domain.ShoppingListItem
+func (s *ShoppingListItemEntity) ToShoppingListItemDomain() *domain.ShoppingListItem {
+	recipeIDs := make([]uuid.UUID, 0, len(s.RecipeIDs))
+	for _, id := range s.RecipeIDs {
+		if parsed, err := uuid.Parse(id); err == nil {
+			recipeIDs = append(recipeIDs, parsed)
+		}
+	}
+
+	return &domain.ShoppingListItem{
+		BaseModel: &common.BaseModel{
+			ID:        s.ID,
+			CreatedAt: s.CreatedAt,
+			UpdatedAt: s.UpdatedAt,
+			Status:    s.Status,
+		},
+		ListID:    s.ListID,
+		Name:      s.Name,
+		Amount:    s.Amount,
+		Unit:      s.Unit,
+		Aisle:     s.Aisle,
+		Checked:   s.Checked,
+		Manual:    s.Manual,
+		RecipeIDs: recipeIDs,
+	}
+}
+
+// FromShoppingListItemDomain converts a domain.ShoppingListItem to a ShoppingListItemEntity
+func FromShoppingListItemDomain(item *domain.ShoppingListItem) *ShoppingListItemEntity {
+	if item.BaseModel == nil {
+		item.BaseModel = &common.BaseModel{
+			ID:        uuid.New(),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+			Status:    1,
+		}
+	}
+
+	recipeIDs := make(StringArrayEntity, len(item.RecipeIDs))
+	for i, id := range item.RecipeIDs {
+		recipeIDs[i] = id.String()
+	}
+
+	return &ShoppingListItemEntity{
+		BaseEntity: &common.BaseEntity{
+			ID:        item.ID,
+			CreatedAt: item.CreatedAt,
+			UpdatedAt: item.UpdatedAt,
+			Status:    item.Status,
+		},
+		ListID:    item.ListID,
+		Name:      item.Name,
+		Amount:    item.Amount,
+		Unit:      item.Unit,
+		Aisle:     item.Aisle,
+		Checked:   item.Checked,
+		Manual:    item.Manual,
+		RecipeIDs: recipeIDs,
+	}
+}
+
+// ShoppingListRepository is the repository implementation for shopping lists
+type ShoppingListRepository struct {
+	db *gorm.DB
+}
+
+// NewShoppingListRepository creates a new shopping list repository
+func NewShoppingListRepository(db *gorm.DB) interfaces.ShoppingListRepository {
+	return &ShoppingListRepository{db: db}
+}
+
+// Create creates a shopping list and its items in a single transaction
+func (r *ShoppingListRepository) Create(ctx context.Context, list *domain.ShoppingList) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(FromShoppingListDomain(list)).Error; err != nil {
+			return err
+		}
+
+		if len(list.Items) == 0 {
+			return nil
+		}
+
+		items := make([]*ShoppingListItemEntity, len(list.Items))
+		for i := range list.Items {
+			list.Items[i].ListID = list.ID
+			items[i] = FromShoppingListItemDomain(&list.Items[i])
+		}
+		return tx.Create(&items).Error
+	})
+}
+
+// GetByID gets a shopping list and its items by ID
+func (r *ShoppingListRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ShoppingList, error) {
+	var entity ShoppingListEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return r.withItems(ctx, &entity)
+}
+
+// GetByShareToken gets a shopping list and its items by share token
+func (r *ShoppingListRepository) GetByShareToken(ctx context.Context, token string) (*domain.ShoppingList, error) {
+	var entity ShoppingListEntity
+	if err := r.db.WithContext(ctx).Where("share_token = ? AND status = ?", token, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return r.withItems(ctx, &entity)
+}
+
+func (r *ShoppingListRepository) withItems(ctx context.Context, entity *ShoppingListEntity) (*domain.ShoppingList, error) {
+	var items []ShoppingListItemEntity
+	if err := r.db.WithContext(ctx).
+		Where("list_id = ? AND status = ?", entity.ID, 1).
+		Order("created_at ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	list := entity.ToShoppingListDomain()
+	list.Items = make([]domain.ShoppingListItem, len(items))
+	for i, item := range items {
+		list.Items[i] = *item.ToShoppingListItemDomain()
+	}
+	return list, nil
+}
+
+// GetByUserID gets all shopping lists of a user, newest first
+func (r *ShoppingListRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.ShoppingList, error) {
+	var entities []ShoppingListEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ?", userID, 1).
+		Order("created_at DESC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	lists := make([]domain.ShoppingList, len(entities))
+	for i, entity := range entities {
+		lists[i] = *entity.ToShoppingListDomain()
+	}
+	return lists, nil
+}
+
+// Update updates the name and share token of a shopping list
+func (r *ShoppingListRepository) Update(ctx context.Context, list *domain.ShoppingList) error {
+	entity := FromShoppingListDomain(list)
+	return r.db.WithContext(ctx).Model(&ShoppingListEntity{}).Where("id = ?", entity.ID).Updates(map[string]interface{}{
+		"name":        entity.Name,
+		"share_token": entity.ShareToken,
+		"updated_at":  time.Now(),
+	}).Error
+}
+
+// Delete soft deletes a shopping list
+func (r *ShoppingListRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Model(&ShoppingListEntity{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":      0,
+		"share_token": nil,
+		"updated_at":  time.Now(),
+	}).Error
+}
+
+// AddItem adds an item to a shopping list
+func (r *ShoppingListRepository) AddItem(ctx context.Context, item *domain.ShoppingListItem) error {
+	return r.db.WithContext(ctx).Create(FromShoppingListItemDomain(item)).Error
+}
+
+// GetItem gets a shopping list item by ID
+func (r *ShoppingListRepository) GetItem(ctx context.Context, id uuid.UUID) (*domain.ShoppingListItem, error) {
+	var entity ShoppingListItemEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ?", id, 1).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToShoppingListItemDomain(), nil
+}
+
+// UpdateItem updates a shopping list item
+func (r *ShoppingListRepository) UpdateItem(ctx context.Context, item *domain.ShoppingListItem) error {
+	return r.db.WithContext(ctx).Model(&ShoppingListItemEntity{}).Where("id = ?", item.ID).Updates(map[string]interface{}{
+		"name":       item.Name,
+		"amount":     item.Amount,
+		"unit":       item.Unit,
+		"aisle":      item.Aisle,
+		"checked":    item.Checked,
+		"updated_at": time.Now(),
+	}).Error
+}
+
+// DeleteItem deletes a shopping list item
+func (r *ShoppingListRepository) DeleteItem(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&ShoppingListItemEntity{}, "id = ?", id).Error
+}
@@ -0,0 +1,254 @@
+package db
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrashRepository is the repository implementation for deleted recipes, collections and categories
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository creates a new trash repository
+func NewTrashRepository(db *gorm.DB) interfaces.TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// GetTrashedRecipes gets the user's deleted recipes, most recently deleted first
+func (r *TrashRepository) GetTrashedRecipes(ctx context.Context, userID uuid.UUID) ([]domain.Recipe, error) {
+	var entities []RecipeEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ? AND trashed_at IS NOT NULL", userID, 0).
+		Order("trashed_at DESC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	recipes := make([]domain.Recipe, len(entities))
+	for i, entity := range entities {
+		recipes[i] = *entity.ToRecipeDomain()
+	}
+	return recipes, nil
+}
+
+// GetTrashedCollections gets the user's deleted collections, most recently deleted first
+func (r *TrashRepository) GetTrashedCollections(ctx context.Context, userID uuid.UUID) ([]domain.Collection, error) {
+	var entities []CollectionEntity
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND status = ? AND trashed_at IS NOT NULL", userID, 0).
+		Order("trashed_at DESC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	collections := make([]domain.Collection, len(entities))
+	for i, entity := range entities {
+		collections[i] = *entity.ToCollectionDomain()
+	}
+	return collections, nil
+}
+
+// GetTrashedCategories gets the deleted categories, most recently deleted first
+func (r *TrashRepository) GetTrashedCategories(ctx context.Context) ([]domain.Category, error) {
+	var entities []CategoryEntity
+	if err := r.db.WithContext(ctx).
+		Where("status = ? AND trashed_at IS NOT NULL", 0).
+		Order("trashed_at DESC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	categories := make([]domain.Category, len(entities))
+	for i, entity := range entities {
+		categories[i] = *entity.ToCategoryDomain()
+	}
+	return categories, nil
+}
+
+// GetTrashedRecipe gets a deleted recipe by ID
+func (r *TrashRepository) GetTrashedRecipe(ctx context.Context, id uuid.UUID) (*domain.Recipe, error) {
+	var entity RecipeEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ? AND trashed_at IS NOT NULL", id, 0).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToRecipeDomain(), nil
+}
+
+// GetTrashedCollection gets a deleted collection by ID
+func (r *TrashRepository) GetTrashedCollection(ctx context.Context, id uuid.UUID) (*domain.Collection, error) {
+	var entity CollectionEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ? AND trashed_at IS NOT NULL", id, 0).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToCollectionDomain(), nil
+}
+
+// GetTrashedCategory gets a deleted category by ID
+func (r *TrashRepository) GetTrashedCategory(ctx context.Context, id uuid.UUID) (*domain.Category, error) {
+	var entity CategoryEntity
+	if err := r.db.WithContext(ctx).Where("id = ? AND status = ? AND trashed_at IS NOT NULL", id, 0).First(&entity).Error; err != nil {
+		return nil, err
+	}
+	return entity.ToCategoryDomain(), nil
+}
+
+// RestoreRecipe restores a deleted recipe
+func (r *TrashRepository) RestoreRecipe(ctx context.Context, id uuid.UUID) error {
+	return r.restore(ctx, &RecipeEntity{}, id)
+}
+
+// RestoreCollection restores a deleted collection
+func (r *TrashRepository) RestoreCollection(ctx context.Context, id uuid.UUID) error {
+	return r.restore(ctx, &CollectionEntity{}, id)
+}
+
+// RestoreCategory restores a deleted category
+func (r *TrashRepository) RestoreCategory(ctx context.Context, id uuid.UUID) error {
+	return r.restore(ctx, &CategoryEntity{}, id)
+}
+
+// restore brings a row back from the trash. It returns gorm.ErrRecordNotFound when the row isn't in the trash.
+func (r *TrashRepository) restore(ctx context.Context, model interface{}, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(model).Where("id = ? AND status = ? AND trashed_at IS NOT NULL", id, 0).Updates(map[string]interface{}{
+		"status":     1,
+		"trashed_at": nil,
+		"updated_at": time.Now(),
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// BackfillTrashedAt moves recipes, collections and categories deleted before the trash existed into it,
+// taking their last update as the time they were deleted, so they can be listed, restored and purged
+func BackfillTrashedAt(database *gorm.DB) error {
+	for _, model := range []interface{}{&RecipeEntity{}, &CollectionEntity{}, &CategoryEntity{}} {
+		if err := database.Model(model).
+			Where("status = ? AND trashed_at IS NULL", 0).
+			UpdateColumn("trashed_at", gorm.Expr("updated_at")).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeBefore permanently deletes the recipes, collections and categories deleted before the cutoff.
+// Purged recipes take every row derived from them along (see purgeRecipeRows), and purged collections their entries.
+// Categories that live recipes still reference are skipped.
+func (r *TrashRepository) PurgeBefore(ctx context.Context, cutoff time.Time) (*domain.TrashPurgeResult, error) {
+	result := &domain.TrashPurgeResult{}
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var recipeIDs []uuid.UUID
+		if err := tx.Model(&RecipeEntity{}).Where("status = ? AND trashed_at < ?", 0, cutoff).Pluck("id", &recipeIDs).Error; err != nil {
+			return err
+		}
+		if len(recipeIDs) > 0 {
+			if err := purgeRecipeRows(tx, recipeIDs); err != nil {
+				return err
+			}
+			deleted := tx.Where("id IN ?", recipeIDs).Delete(&RecipeEntity{})
+			if deleted.Error != nil {
+				return deleted.Error
+			}
+			result.Recipes = deleted.RowsAffected
+		}
+
+		var collectionIDs []uuid.UUID
+		if err := tx.Model(&CollectionEntity{}).Where("status = ? AND trashed_at < ?", 0, cutoff).Pluck("id", &collectionIDs).Error; err != nil {
+			return err
+		}
+		if len(collectionIDs) > 0 {
+			if err := tx.Where("collection_id IN ?", collectionIDs).Delete(&RecipeCollectionEntity{}).Error; err != nil {
+				return err
+			}
+			deleted := tx.Where("id IN ?", collectionIDs).Delete(&CollectionEntity{})
+			if deleted.Error != nil {
+				return deleted.Error
+			}
+			result.Collections = deleted.RowsAffected
+		}
+
+		// Categories still used by live recipes stay in the trash until those recipes move or are purged
+		deleted := tx.Where("status = ? AND trashed_at < ?", 0, cutoff).
+			Where("NOT EXISTS (SELECT 1 FROM recipes WHERE recipes.category_id = categories.id AND recipes.status = ?)", 1).
+			Delete(&CategoryEntity{})
+		if deleted.Error != nil {
+			return deleted.Error
+		}
+		result.Categories = deleted.RowsAffected
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// recipeKeyedEntities are the tables whose rows only exist for the recipe they reference.
+// Cook logs are deliberately missing: they are the user's cooking history and outlive the recipe.
+var recipeKeyedEntities = []interface{}{
+	&RecipeCollectionEntity{},
+	&RecipeRatingEntity{},
+	&RecipeNoteEntity{},
+	&MealPlanEntryEntity{},
+	&FeedItemEntity{},
+	&RecipeScoreEntity{},
+	&RecipeViewEntity{},
+	&RecipeDailyStatsEntity{},
+	&RecipeCostEntity{},
+	&RecipeFingerprintEntity{},
+	&RecipeFingerprintBandEntity{},
+}
+
+// purgeRecipeRows deletes the rows that reference recipes about to be purged and drops
+// the recipes from shopping list items, keeping the items themselves
+func purgeRecipeRows(tx *gorm.DB, recipeIDs []uuid.UUID) error {
+	for _, entity := range recipeKeyedEntities {
+		if err := tx.Where("recipe_id IN ?", recipeIDs).Delete(entity).Error; err != nil {
+			return err
+		}
+	}
+	if err := tx.Where("recipe_id IN ? OR similar_recipe_id IN ?", recipeIDs, recipeIDs).Delete(&RecipeSimilarityEntity{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("recipe_id IN ? OR duplicate_of_id IN ?", recipeIDs, recipeIDs).Delete(&RecipeDuplicateEntity{}).Error; err != nil {
+		return err
+	}
+
+	purged := make(map[string]bool, len(recipeIDs))
+	contains := make([]string, len(recipeIDs))
+	args := make([]interface{}, len(recipeIDs))
+	for i, id := range recipeIDs {
+		purged[id.String()] = true
+		contains[i] = "JSON_CONTAINS(recipe_ids, JSON_QUOTE(?))"
+		args[i] = id.String()
+	}
+	var items []ShoppingListItemEntity
+	if err := tx.Where(strings.Join(contains, " OR "), args...).Find(&items).Error; err != nil {
+		return err
+	}
+	for _, item := range items {
+		kept := StringArrayEntity{}
+		for _, id := range item.RecipeIDs {
+			if !purged[id] {
+				kept = append(kept, id)
+			}
+		}
+		if err := tx.Model(&ShoppingListItemEntity{}).Where("id = ?", item.ID).UpdateColumn("recipe_ids", kept).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
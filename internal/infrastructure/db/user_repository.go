@@ -36,6 +36,7 @@ func (e *UserEntity) ToDomain() *domain.User {
 			CreatedAt: e.CreatedAt,
 			UpdatedAt: e.UpdatedAt,
 			Status:    e.Status,
+			Version:   e.Version,
 		},
 		Username:      e.Username,
 		Email:         e.Email,
@@ -57,6 +58,7 @@ func FromDomain(user *domain.User) *UserEntity {
 			CreatedAt: user.CreatedAt,
 			UpdatedAt: user.UpdatedAt,
 			Status:    user.Status,
+			Version:   user.Version,
 		},
 		Username:      user.Username,
 		Email:         user.Email,
@@ -115,16 +117,43 @@ func (r *userRepository) FindByUsername(ctx context.Context, username string) (*
 	return user.ToDomain(), nil
 }
 
+// Update saves the user unless it was updated since it was read, in which case it returns
+// interfaces.ErrVersionConflict
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
-	return r.db.WithContext(ctx).Save(FromDomain(user)).Error
+	entity := FromDomain(user)
+	entity.Version = user.Version + 1
+	// Only the columns a profile update or OTP resend changes; verification goes through VerifyOTP
+	result := r.db.WithContext(ctx).
+		Select("full_name", "password", "avatar", "bio", "otp", "otp_expires_at", "version", "updated_at").
+		Where("version = ?", user.Version).
+		Updates(entity)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrVersionConflict
+	}
+
+	user.Version++
+	user.UpdatedAt = entity.UpdatedAt
+	return nil
 }
 
-func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Where("id = ?", id).Save(&UserEntity{
-		BaseEntity: &common.BaseEntity{
-			Status: 0,
-		},
-	}).Error
+// Delete deactivates the user unless it is no longer at the given version, in which case it returns
+// interfaces.ErrVersionConflict
+func (r *userRepository) Delete(ctx context.Context, id uuid.UUID, version int64) error {
+	result := r.db.WithContext(ctx).Model(&UserEntity{}).Where("id = ? AND version = ?", id, version).Updates(map[string]interface{}{
+		"status":     0,
+		"updated_at": time.Now(),
+		"version":    gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return interfaces.ErrVersionConflict
+	}
+	return nil
 }
 
 func (r *userRepository) List(ctx context.Context, offset, limit int) ([]domain.User, error) {
@@ -156,10 +185,11 @@ func (r *userRepository) VerifyOTP(ctx context.Context, id uuid.UUID, otp string
 		return errors.New("OTP has been expired")
 	}
 
-	userEntity := FromDomain(user)
-	userEntity.OTP = nil
-	userEntity.OTPExpiresAt = nil
-	userEntity.EmailVerified = true
-
-	return r.db.WithContext(ctx).Where("id = ?", id).Save(userEntity).Error
+	return r.db.WithContext(ctx).Model(&UserEntity{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"otp":            nil,
+		"otp_expires_at": nil,
+		"email_verified": true,
+		"updated_at":     time.Now(),
+		"version":        gorm.Expr("version + 1"),
+	}).Error
 }
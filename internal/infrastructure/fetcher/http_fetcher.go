@@ -0,0 +1,161 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxPageSize limits how much of a remote page is read into memory
+const maxPageSize = 5 << 20
+
+// maxRedirects limits how many redirects are followed for one page
+const maxRedirects = 10
+
+// ErrBlockedAddress is returned when a page, or a page it redirects to, resolves to an address
+// on the server's own network
+var ErrBlockedAddress = errors.New("fetching pages from local or private addresses is not allowed")
+
+// ErrUnsupportedURL is returned when a page URL, or a URL it redirects to, isn't an http or https URL with a host
+var ErrUnsupportedURL = errors.New("only http and https URLs are supported")
+
+// blockedNetworks are the ranges not covered by the net.IP helpers in isBlockedIP
+var blockedNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),     // "this network"
+	mustParseCIDR("100.64.0.0/10"), // carrier-grade NAT
+}
+
+// HTTPPageFetcher downloads web pages over HTTP(S)
+type HTTPPageFetcher struct {
+	client *http.Client
+}
+
+// NewHTTPPageFetcher creates a new HTTPPageFetcher instance. It refuses to connect to loopback,
+// private, link-local and unspecified addresses, so users can't make the server fetch internal
+// services or cloud metadata endpoints.
+func NewHTTPPageFetcher() *HTTPPageFetcher {
+	return newHTTPPageFetcher(isBlockedIP)
+}
+
+// newHTTPPageFetcher creates a fetcher refusing to connect to the addresses blocked reports.
+// The check runs on the resolved address of every connection, so it also covers redirects and
+// host names that resolve to a different address than they did when the URL was submitted.
+func newHTTPPageFetcher(blocked func(ip net.IP) bool) *HTTPPageFetcher {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || blocked(ip) {
+				return ErrBlockedAddress
+			}
+			return nil
+		},
+	}
+
+	return &HTTPPageFetcher{
+		client: &http.Client{
+			Timeout: 15 * time.Second,
+			// No proxy: a proxy would make the connection for us and bypass the address check
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				ForceAttemptHTTP2:   true,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     90 * time.Second,
+				TLSHandshakeTimeout: 10 * time.Second,
+			},
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxRedirects {
+					return errors.New("too many redirects")
+				}
+				return checkScheme(req.URL)
+			},
+		},
+	}
+}
+
+// Fetch downloads the page at the given URL and returns its body
+func (f *HTTPPageFetcher) Fetch(ctx context.Context, rawURL string) ([]byte, error) {
+	pageURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupportedURL, err)
+	}
+	if err := checkScheme(pageURL); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	req.Header.Set("User-Agent", "CookaholicRecipeImporter/1.0")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return nil, ErrBlockedAddress
+		}
+		if errors.Is(err, ErrUnsupportedURL) {
+			return nil, ErrUnsupportedURL
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status fetching page: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxPageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > maxPageSize {
+		return nil, errors.New("page too large")
+	}
+
+	return body, nil
+}
+
+// checkScheme only lets http and https URLs with a host through
+func checkScheme(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if (scheme != "http" && scheme != "https") || u.Host == "" {
+		return ErrUnsupportedURL
+	}
+	return nil
+}
+
+// isBlockedIP tells whether an address is on the server's own network rather than the internet
+func isBlockedIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return true
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+	return network
+}
@@ -0,0 +1,153 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIsBlockedIP(t *testing.T) {
+	tests := []struct {
+		ip      string
+		blocked bool
+	}{
+		{"127.0.0.1", true},
+		{"127.8.9.10", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"172.31.255.255", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"0.0.0.0", true},
+		{"0.1.2.3", true},
+		{"::", true},
+		{"100.64.0.1", true},
+		{"224.0.0.1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:10.0.0.1", true},
+		{"8.8.8.8", false},
+		{"172.32.0.1", false},
+		{"93.184.216.34", false},
+		{"2606:4700::1111", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ip, func(t *testing.T) {
+			ip := net.ParseIP(tt.ip)
+			if ip == nil {
+				t.Fatalf("invalid test IP %q", tt.ip)
+			}
+			if got := isBlockedIP(ip); got != tt.blocked {
+				t.Errorf("isBlockedIP(%s) = %v, want %v", tt.ip, got, tt.blocked)
+			}
+		})
+	}
+}
+
+func TestFetchRejectsUnsupportedSchemes(t *testing.T) {
+	f := NewHTTPPageFetcher()
+	for _, url := range []string{"file:///etc/passwd", "ftp://example.com/recipe", "gopher://example.com", "http:///no-host", "not a url"} {
+		if _, err := f.Fetch(context.Background(), url); !errors.Is(err, ErrUnsupportedURL) {
+			t.Errorf("Fetch(%q) error = %v, want ErrUnsupportedURL", url, err)
+		}
+	}
+}
+
+func TestFetchBlocksLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("blocked server was reached")
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPPageFetcher().Fetch(context.Background(), server.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("Fetch(%s) error = %v, want ErrBlockedAddress", server.URL, err)
+	}
+}
+
+// allowLoopback blocks what the real fetcher blocks except loopback, so httptest servers can stand
+// in for public sites
+func allowLoopback(ip net.IP) bool {
+	return !ip.IsLoopback() && isBlockedIP(ip)
+}
+
+func TestFetchBlocksRedirects(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		blocked  bool
+	}{
+		{"metadata endpoint", "http://169.254.169.254/latest/meta-data/", true},
+		{"private network", "http://10.0.0.1/admin", true},
+		{"unspecified address", "http://0.0.0.0/", true},
+		{"file scheme", "file:///etc/passwd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.RedirectHandler(tt.location, http.StatusFound))
+			defer server.Close()
+
+			_, err := newHTTPPageFetcher(allowLoopback).Fetch(context.Background(), server.URL)
+			if err == nil {
+				t.Fatalf("redirect to %s was followed", tt.location)
+			}
+			if tt.blocked && !errors.Is(err, ErrBlockedAddress) {
+				t.Errorf("redirect to %s error = %v, want ErrBlockedAddress", tt.location, err)
+			}
+			if !tt.blocked && !errors.Is(err, ErrUnsupportedURL) {
+				t.Errorf("redirect to %s error = %v, want ErrUnsupportedURL", tt.location, err)
+			}
+		})
+	}
+}
+
+func TestFetchReturnsPage(t *testing.T) {
+	const page = `<html><head><script type="application/ld+json">{"@type":"Recipe","name":"Pancakes"}</script></head></html>`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old" {
+			http.Redirect(w, r, "/recipe", http.StatusMovedPermanently)
+			return
+		}
+		if got := r.Header.Get("User-Agent"); !strings.HasPrefix(got, "CookaholicRecipeImporter") {
+			t.Errorf("User-Agent = %q", got)
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	body, err := newHTTPPageFetcher(allowLoopback).Fetch(context.Background(), server.URL+"/old")
+	if err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if string(body) != page {
+		t.Errorf("Fetch() = %q, want %q", body, page)
+	}
+}
+
+func TestFetchRejectsErrorsAndLargePages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(make([]byte, maxPageSize+1))
+	}))
+	defer server.Close()
+
+	f := newHTTPPageFetcher(allowLoopback)
+	if _, err := f.Fetch(context.Background(), server.URL+"/missing"); err == nil {
+		t.Error("Fetch() of a 404 page succeeded")
+	}
+	if _, err := f.Fetch(context.Background(), server.URL+"/large"); err == nil {
+		t.Error("Fetch() of an oversized page succeeded")
+	}
+}
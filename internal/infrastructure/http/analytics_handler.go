@@ -0,0 +1,60 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AnalyticsHandler handles HTTP requests for author analytics
+type AnalyticsHandler struct {
+	analyticsService interfaces.AnalyticsService
+}
+
+// NewAnalyticsHandler creates a new AnalyticsHandler
+func NewAnalyticsHandler(analyticsService interfaces.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetAuthorAnalytics handles the request to get the views, saves, ratings and forks of the caller's recipes
+func (h *AnalyticsHandler) GetAuthorAnalytics(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	input := interfaces.AuthorAnalyticsInput{
+		From: c.Query("from"),
+		To:   c.Query("to"),
+	}
+	if recipeIDStr := c.Query("recipe_id"); recipeIDStr != "" {
+		recipeID, err := uuid.Parse(recipeIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID"})
+			return
+		}
+		input.RecipeID = recipeID
+	}
+
+	analytics, err := h.analyticsService.GetAuthorAnalytics(c.Request.Context(), *uid, input)
+	if err != nil {
+		switch e := err.(type) {
+		case *interfaces.NotFoundError:
+			c.JSON(http.StatusNotFound, gin.H{"error": e.Error()})
+		case *interfaces.UnauthorizedError:
+			c.JSON(http.StatusForbidden, gin.H{"error": e.Error()})
+		case *interfaces.ValidationError:
+			c.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// BulkImportHandler handles HTTP requests for bulk recipe imports and exports
+type BulkImportHandler struct {
+	bulkImportService interfaces.BulkImportService
+}
+
+// NewBulkImportHandler creates a new BulkImportHandler
+func NewBulkImportHandler(bulkImportService interfaces.BulkImportService) *BulkImportHandler {
+	return &BulkImportHandler{
+		bulkImportService: bulkImportService,
+	}
+}
+
+// StartImport starts importing the recipes of an uploaded CSV, JSON, Paprika or Mealie file.
+// The optional category_id form field is used for rows without a category.
+func (h *BulkImportHandler) StartImport(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	categoryID := uuid.Nil
+	if value := c.PostForm("category_id"); value != "" {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+			return
+		}
+		categoryID = parsed
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+
+	// Check file size (50MB limit, archives with photos included)
+	if file.Size > 50<<20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File too large"})
+		return
+	}
+
+	content, err := readUploadedFile(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := h.bulkImportService.StartImport(c.Request.Context(), interfaces.BulkImportInput{
+		UserID:     *uid,
+		Format:     c.DefaultQuery("format", interfaces.BulkFormatCSV),
+		CategoryID: categoryID,
+		Content:    content,
+	})
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.Header("Location", "/api/import-jobs/"+job.ID.String())
+	c.JSON(http.StatusAccepted, job)
+}
+
+// GetJob returns the progress and row errors of an import job
+func (h *BulkImportHandler) GetJob(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid import job ID"})
+		return
+	}
+
+	job, err := h.bulkImportService.GetJob(c.Request.Context(), id, *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, job)
+}
+
+// ExportRecipes downloads all of the user's recipes as CSV or JSON
+func (h *BulkImportHandler) ExportRecipes(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	export, err := h.bulkImportService.ExportRecipes(c.Request.Context(), *uid, c.DefaultQuery("format", interfaces.BulkFormatCSV))
+	if err != nil {
+		if errors.Is(err, interfaces.ErrUnsupportedFormat) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename))
+	c.Data(http.StatusOK, export.ContentType, export.Body)
+}
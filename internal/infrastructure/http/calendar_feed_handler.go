@@ -0,0 +1,90 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CalendarFeedHandler handles HTTP requests for meal plan calendar feeds
+type CalendarFeedHandler struct {
+	calendarFeedService interfaces.CalendarFeedService
+}
+
+// NewCalendarFeedHandler creates a new CalendarFeedHandler
+func NewCalendarFeedHandler(calendarFeedService interfaces.CalendarFeedService) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		calendarFeedService: calendarFeedService,
+	}
+}
+
+// GetFeed handles the request to get the caller's calendar subscription URL
+func (h *CalendarFeedHandler) GetFeed(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	feed, err := h.calendarFeedService.GetFeed(c.Request.Context(), *uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// RotateFeed handles the request to replace the caller's calendar subscription URL
+func (h *CalendarFeedHandler) RotateFeed(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	feed, err := h.calendarFeedService.RotateFeed(c.Request.Context(), *uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, feed)
+}
+
+// RevokeFeed handles the request to disable the caller's calendar subscription
+func (h *CalendarFeedHandler) RevokeFeed(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	if err := h.calendarFeedService.RevokeFeed(c.Request.Context(), *uid); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed revoked successfully"})
+}
+
+// ServeFeed serves the public iCalendar document behind a secret token, e.g. /api/calendar/<token>.ics
+func (h *CalendarFeedHandler) ServeFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("file"), ".ics")
+
+	body, err := h.calendarFeedService.RenderFeed(c.Request.Context(), token)
+	if err != nil {
+		switch e := err.(type) {
+		case *interfaces.NotFoundError:
+			c.JSON(http.StatusNotFound, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=900")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", body)
+}
@@ -67,6 +67,7 @@ func (h *CollectionHandler) GetCollection(c *gin.Context) {
 		return
 	}
 
+	setETag(c, collection.Version)
 	c.JSON(http.StatusOK, collection)
 }
 
@@ -87,8 +88,13 @@ func (h *CollectionHandler) GetUserCollections(c *gin.Context) {
 	c.JSON(http.StatusOK, collections)
 }
 
-// UpdateCollection updates an existing collection
+// UpdateCollection updates an existing collection if the If-Match header holds its current ETag
 func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var collection interfaces.UpdateCollectionInput
 	if err := c.ShouldBindJSON(&collection); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -109,17 +115,75 @@ func (h *CollectionHandler) UpdateCollection(c *gin.Context) {
 
 	collection.UserID = *uid
 
-	updatedCollection, updateErr := h.collectionService.UpdateCollection(c.Request.Context(), id, collection)
+	updatedCollection, updateErr := h.collectionService.UpdateCollection(c.Request.Context(), id, version, collection)
 
 	if updateErr != nil {
+		if writeVersionConflict(c, updateErr) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": updateErr.Error()})
 		return
 	}
 
+	setETag(c, updatedCollection.Version)
 	c.JSON(http.StatusOK, updatedCollection)
 }
 
-// DeleteCollection deletes a collection
+// PatchCollection applies a JSON Merge Patch to one of the caller's collections, so the description
+// and image can be cleared with null, if the If-Match header holds the collection's current ETag
+func (h *CollectionHandler) PatchCollection(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid collection ID"})
+		return
+	}
+
+	uid, authErr := AuthorizedPermission(c)
+	if authErr != nil {
+		c.JSON(http.StatusUnauthorized, authErr)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(c)
+	if !ok {
+		return
+	}
+
+	collection, err := h.collectionService.GetCollectionByID(c.Request.Context(), id)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Collection not found"})
+		return
+	}
+
+	if collection.UserID != *uid {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You are not authorized to update this collection"})
+		return
+	}
+
+	patchedCollection, err := h.collectionService.PatchCollection(c.Request.Context(), id, version, patch)
+	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+		switch e := err.(type) {
+		case *interfaces.ValidationError:
+			c.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, patchedCollection.Version)
+	c.JSON(http.StatusOK, patchedCollection)
+}
+
+// DeleteCollection deletes a collection if the If-Match header holds its current ETag
 func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -127,6 +191,11 @@ func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	uid, authErr := AuthorizedPermission(c)
 	if authErr != nil {
 		c.JSON(http.StatusInternalServerError, authErr)
@@ -144,7 +213,10 @@ func (h *CollectionHandler) DeleteCollection(c *gin.Context) {
 		return
 	}
 
-	if err := h.collectionService.DeleteCollection(c.Request.Context(), id); err != nil {
+	if err := h.collectionService.DeleteCollection(c.Request.Context(), id, version); err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -1,6 +1,10 @@
 package http
 
 import (
+	"cookaholic/internal/interfaces"
+	"errors"
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
@@ -28,3 +32,25 @@ func AuthorizedPermission(c *gin.Context) (*uuid.UUID, *ErrorResponse) {
 
 	return &uid, nil
 }
+
+// writeServiceError maps the errors returned by the services to HTTP status codes
+func writeServiceError(c *gin.Context, err error) {
+	var notFound *interfaces.NotFoundError
+	var unauthorized *interfaces.UnauthorizedError
+	var validation *interfaces.ValidationError
+
+	switch {
+	case errors.As(err, &notFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.As(err, &unauthorized):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.As(err, &validation):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, interfaces.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, interfaces.ErrPantryChanged):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,136 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CookLogHandler handles HTTP requests for cook logs
+type CookLogHandler struct {
+	cookLogService interfaces.CookLogService
+}
+
+// NewCookLogHandler creates a new CookLogHandler
+func NewCookLogHandler(cookLogService interfaces.CookLogService) *CookLogHandler {
+	return &CookLogHandler{
+		cookLogService: cookLogService,
+	}
+}
+
+// LogCook handles the request to log that the caller cooked a recipe
+func (h *CookLogHandler) LogCook(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var input interfaces.CreateCookLogInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.UserID = *uid
+
+	cookLog, err := h.cookLogService.LogCook(c.Request.Context(), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, cookLog)
+}
+
+// GetCookHistory handles the request to get the caller's cooking history
+func (h *CookLogHandler) GetCookHistory(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var err error
+	var recipeID uuid.UUID
+	if recipeIDStr := c.Query("recipe_id"); recipeIDStr != "" {
+		recipeID, err = uuid.Parse(recipeIDStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID"})
+			return
+		}
+	}
+
+	var cursor uuid.UUID
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err = uuid.Parse(cursorStr)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cursor"})
+			return
+		}
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	logs, nextCursor, err := h.cookLogService.GetCookHistory(c.Request.Context(), *uid, recipeID, cursor, limit)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"cook_logs":   logs,
+		"next_cursor": nextCursor,
+	})
+}
+
+// GetCookLog handles the request to get one of the caller's cook logs
+func (h *CookLogHandler) GetCookLog(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cook log ID"})
+		return
+	}
+
+	cookLog, err := h.cookLogService.GetCookLog(c.Request.Context(), id, *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cookLog)
+}
+
+// DeleteCookLog handles the request to delete a cook log
+func (h *CookLogHandler) DeleteCookLog(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid cook log ID"})
+		return
+	}
+
+	if err := h.cookLogService.DeleteCookLog(c.Request.Context(), id, *uid); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Cook log deleted successfully"})
+}
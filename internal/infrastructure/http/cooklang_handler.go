@@ -0,0 +1,124 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CooklangHandler handles HTTP requests for importing Cooklang recipes
+type CooklangHandler struct {
+	cooklangService interfaces.CooklangService
+}
+
+// NewCooklangHandler creates a new CooklangHandler
+func NewCooklangHandler(cooklangService interfaces.CooklangService) *CooklangHandler {
+	return &CooklangHandler{
+		cooklangService: cooklangService,
+	}
+}
+
+// ImportRecipe imports a single uploaded .cook file
+func (h *CooklangHandler) ImportRecipe(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.PostForm("category_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+
+	// Check file size (1MB limit)
+	if file.Size > 1<<20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File too large"})
+		return
+	}
+
+	content, err := readUploadedFile(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipe, err := h.cooklangService.ImportRecipe(c.Request.Context(), interfaces.CooklangImportInput{
+		UserID:     *uid,
+		CategoryID: categoryID,
+		Filename:   file.Filename,
+		Content:    content,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, recipe)
+}
+
+// ImportArchive imports every .cook file contained in an uploaded ZIP archive
+func (h *CooklangHandler) ImportArchive(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	categoryID, err := uuid.Parse(c.PostForm("category_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	file, err := c.FormFile("archive")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No archive uploaded"})
+		return
+	}
+
+	// Check archive size (20MB limit)
+	if file.Size > 20<<20 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Archive too large"})
+		return
+	}
+
+	archive, err := readUploadedFile(file)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.cooklangService.ImportArchive(c.Request.Context(), interfaces.CooklangArchiveInput{
+		UserID:     *uid,
+		CategoryID: categoryID,
+		Archive:    archive,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func readUploadedFile(file *multipart.FileHeader) ([]byte, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	return io.ReadAll(src)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CostHandler handles HTTP requests for ingredient prices and recipe cost estimates
+type CostHandler struct {
+	costService interfaces.CostService
+}
+
+// NewCostHandler creates a new CostHandler
+func NewCostHandler(costService interfaces.CostService) *CostHandler {
+	return &CostHandler{
+		costService: costService,
+	}
+}
+
+// GetRecipeCost handles the request to estimate the cost of a recipe in the currency query parameter
+func (h *CostHandler) GetRecipeCost(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	cost, err := h.costService.EstimateRecipeCost(c.Request.Context(), id, *uid, c.Query("currency"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cost)
+}
+
+// GetPrices handles the request to get the shared prices and the caller's own prices
+func (h *CostHandler) GetPrices(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	prices, err := h.costService.GetPrices(c.Request.Context(), *uid, c.Query("currency"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, prices)
+}
+
+// CreatePrice handles the request to add a price of the caller's own
+func (h *CostHandler) CreatePrice(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	h.createPrice(c, uid)
+}
+
+// UpdatePrice handles the request to update a price of the caller's own
+func (h *CostHandler) UpdatePrice(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	h.updatePrice(c, uid)
+}
+
+// DeletePrice handles the request to delete a price of the caller's own
+func (h *CostHandler) DeletePrice(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	h.deletePrice(c, uid)
+}
+
+// CreateSharedPrice handles the request to add a shared price
+func (h *CostHandler) CreateSharedPrice(c *gin.Context) {
+	h.createPrice(c, nil)
+}
+
+// UpdateSharedPrice handles the request to update a shared price
+func (h *CostHandler) UpdateSharedPrice(c *gin.Context) {
+	h.updatePrice(c, nil)
+}
+
+// DeleteSharedPrice handles the request to delete a shared price
+func (h *CostHandler) DeleteSharedPrice(c *gin.Context) {
+	h.deletePrice(c, nil)
+}
+
+func (h *CostHandler) createPrice(c *gin.Context, userID *uuid.UUID) {
+	var input interfaces.CreateIngredientPriceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.UserID = userID
+
+	price, err := h.costService.CreatePrice(c.Request.Context(), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, price)
+}
+
+func (h *CostHandler) updatePrice(c *gin.Context, userID *uuid.UUID) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient price ID"})
+		return
+	}
+
+	var input interfaces.UpdateIngredientPriceInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	price, err := h.costService.UpdatePrice(c.Request.Context(), id, userID, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, price)
+}
+
+func (h *CostHandler) deletePrice(c *gin.Context, userID *uuid.UUID) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient price ID"})
+		return
+	}
+
+	if err := h.costService.DeletePrice(c.Request.Context(), id, userID); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ingredient price deleted successfully"})
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DuplicateHandler handles HTTP requests for duplicate recipe moderation
+type DuplicateHandler struct {
+	duplicateService interfaces.DuplicateService
+}
+
+// NewDuplicateHandler creates a new DuplicateHandler
+func NewDuplicateHandler(duplicateService interfaces.DuplicateService) *DuplicateHandler {
+	return &DuplicateHandler{
+		duplicateService: duplicateService,
+	}
+}
+
+// GetDuplicateClusters handles the request to get the clusters of likely duplicate recipes
+func (h *DuplicateHandler) GetDuplicateClusters(c *gin.Context) {
+	clusters, err := h.duplicateService.GetDuplicateClusters(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"clusters": clusters})
+}
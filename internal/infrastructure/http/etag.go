@@ -0,0 +1,60 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setETag sets the ETag header to the version of a resource, which changes on every update
+func setETag(c *gin.Context, version int64) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// setLocalizedETag sets the ETag header of a representation served in a locale, so each locale of a
+// version has its own validator. ifMatchVersion accepts it as the version it was served at.
+func setLocalizedETag(c *gin.Context, version int64, locale string) {
+	c.Header("ETag", strconv.Quote(strconv.FormatInt(version, 10)+"-"+locale))
+}
+
+// ifMatchVersion reads the version a write is conditional on from the If-Match header.
+// A missing header answers 428 Precondition Required, and a header naming anything other
+// than a single strong ETag or "*" answers 412 Precondition Failed, as it can never match;
+// ok is false in both cases. "*" matches any version.
+func ifMatchVersion(c *gin.Context) (version int64, ok bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match header with the resource ETag is required"})
+		return 0, false
+	}
+	if header == "*" {
+		return interfaces.AnyVersion, true
+	}
+
+	// Weak ETags never match under the strong comparison If-Match requires
+	tag, err := strconv.Unquote(header)
+	if err == nil {
+		// A localized ETag names the version before the locale
+		tag, _, _ = strings.Cut(tag, "-")
+		version, err = strconv.ParseInt(tag, 10, 64)
+	}
+	if err != nil || version <= 0 {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "If-Match does not match the current ETag"})
+		return 0, false
+	}
+	return version, true
+}
+
+// writeVersionConflict answers 412 Precondition Failed when err reports that the resource
+// changed since the client read it, and tells whether it did
+func writeVersionConflict(c *gin.Context, err error) bool {
+	if !errors.Is(err, interfaces.ErrVersionConflict) {
+		return false
+	}
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	return true
+}
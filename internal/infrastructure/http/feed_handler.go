@@ -0,0 +1,49 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedHandler handles HTTP requests for the home feed
+type FeedHandler struct {
+	feedService interfaces.FeedService
+}
+
+// NewFeedHandler creates a new FeedHandler
+func NewFeedHandler(feedService interfaces.FeedService) *FeedHandler {
+	return &FeedHandler{
+		feedService: feedService,
+	}
+}
+
+// GetFeed handles the request to get a page of the caller's home feed
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	page, err := h.feedService.GetFeed(c.Request.Context(), *uid, c.Query("cursor"), limit)
+	if err != nil {
+		switch e := err.(type) {
+		case *interfaces.ValidationError:
+			c.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
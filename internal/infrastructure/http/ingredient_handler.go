@@ -0,0 +1,131 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// IngredientHandler handles HTTP requests for the ingredient catalogue
+type IngredientHandler struct {
+	ingredientService       interfaces.IngredientService
+	ingredientParserService interfaces.IngredientParserService
+}
+
+// NewIngredientHandler creates a new IngredientHandler
+func NewIngredientHandler(ingredientService interfaces.IngredientService, ingredientParserService interfaces.IngredientParserService) *IngredientHandler {
+	return &IngredientHandler{
+		ingredientService:       ingredientService,
+		ingredientParserService: ingredientParserService,
+	}
+}
+
+// Autocomplete handles the request to suggest catalogue ingredients for the text in the q query parameter
+func (h *IngredientHandler) Autocomplete(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil || limit < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	suggestions, err := h.ingredientService.Autocomplete(c.Request.Context(), c.Query("q"), limit)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// ParseIngredients handles the request to turn free-text ingredient lines into structured ingredients
+func (h *IngredientHandler) ParseIngredients(c *gin.Context) {
+	var input interfaces.ParseIngredientsInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredients, err := h.ingredientParserService.ParseIngredients(c.Request.Context(), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"ingredients": ingredients})
+}
+
+// GetIngredient handles the request to get a catalogue ingredient
+func (h *IngredientHandler) GetIngredient(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient ID"})
+		return
+	}
+
+	ingredient, err := h.ingredientService.GetIngredient(c.Request.Context(), id)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// CreateIngredient handles the request to add an ingredient to the catalogue
+func (h *IngredientHandler) CreateIngredient(c *gin.Context) {
+	var input interfaces.IngredientInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredient, err := h.ingredientService.CreateIngredient(c.Request.Context(), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, ingredient)
+}
+
+// UpdateIngredient handles the request to replace a catalogue ingredient
+func (h *IngredientHandler) UpdateIngredient(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient ID"})
+		return
+	}
+
+	var input interfaces.IngredientInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ingredient, err := h.ingredientService.UpdateIngredient(c.Request.Context(), id, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ingredient)
+}
+
+// DeleteIngredient handles the request to remove an ingredient from the catalogue
+func (h *IngredientHandler) DeleteIngredient(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ingredient ID"})
+		return
+	}
+
+	if err := h.ingredientService.DeleteIngredient(c.Request.Context(), id); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Ingredient deleted successfully"})
+}
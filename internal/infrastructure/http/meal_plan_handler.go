@@ -0,0 +1,181 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// MealPlanHandler handles HTTP requests for the meal planner
+type MealPlanHandler struct {
+	mealPlanService interfaces.MealPlanService
+}
+
+// NewMealPlanHandler creates a new MealPlanHandler
+func NewMealPlanHandler(mealPlanService interfaces.MealPlanService) *MealPlanHandler {
+	return &MealPlanHandler{
+		mealPlanService: mealPlanService,
+	}
+}
+
+// AddEntry handles the request to plan a recipe for a date and meal slot
+func (h *MealPlanHandler) AddEntry(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var input interfaces.CreateMealPlanEntryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.UserID = *uid
+
+	entry, err := h.mealPlanService.AddEntry(c.Request.Context(), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, entry)
+}
+
+// UpdateEntry handles the request to move a planned meal or change its servings
+func (h *MealPlanHandler) UpdateEntry(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+
+	var input interfaces.UpdateMealPlanEntryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.mealPlanService.UpdateEntry(c.Request.Context(), id, *uid, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, entry)
+}
+
+// DeleteEntry handles the request to remove a planned meal
+func (h *MealPlanHandler) DeleteEntry(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid entry ID"})
+		return
+	}
+
+	if err := h.mealPlanService.DeleteEntry(c.Request.Context(), id, *uid); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Meal plan entry deleted successfully"})
+}
+
+// GetWeek handles the request to get the plan of the week containing :date
+func (h *MealPlanHandler) GetWeek(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	week, err := h.mealPlanService.GetWeek(c.Request.Context(), *uid, c.Param("date"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, week)
+}
+
+// CopyWeek handles the request to copy another week's plan into the week containing :date
+func (h *MealPlanHandler) CopyWeek(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var input interfaces.CopyMealPlanWeekInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	week, err := h.mealPlanService.CopyWeek(c.Request.Context(), *uid, c.Param("date"), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, week)
+}
+
+// GenerateShoppingList handles the request to create a shopping list for the week containing :date
+func (h *MealPlanHandler) GenerateShoppingList(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var input struct {
+		Name string `json:"name"`
+	}
+	// The body is optional
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	list, err := h.mealPlanService.GenerateShoppingList(c.Request.Context(), *uid, c.Param("date"), input.Name)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// GetWeekSummary handles the request to total the cooking time and nutrition of a week
+func (h *MealPlanHandler) GetWeekSummary(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	summary, err := h.mealPlanService.GetWeekSummary(c.Request.Context(), *uid, c.Param("date"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
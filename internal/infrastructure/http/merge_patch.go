@@ -0,0 +1,30 @@
+package http
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// mergePatchMediaType is the media type of RFC 7396 JSON Merge Patch documents
+const mergePatchMediaType = "application/merge-patch+json"
+
+// readMergePatch reads a JSON Merge Patch from the request body. Plain application/json is
+// accepted too, and any other content type answers 415 Unsupported Media Type; ok is false
+// when a response has been written.
+func readMergePatch(c *gin.Context) (patch []byte, ok bool) {
+	mediaType, _, err := mime.ParseMediaType(c.GetHeader("Content-Type"))
+	if err != nil || (mediaType != mergePatchMediaType && mediaType != "application/json") {
+		c.Header("Accept-Patch", mergePatchMediaType)
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "PATCH requests must be sent as " + mergePatchMediaType})
+		return nil, false
+	}
+
+	patch, err = c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return patch, true
+}
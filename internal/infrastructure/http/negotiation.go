@@ -0,0 +1,109 @@
+package http
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// acceptedType is a single media range from an Accept header
+type acceptedType struct {
+	mediaType string
+	quality   float64
+}
+
+// negotiateContentType picks the offered media type the client prefers most.
+// Offers are listed in server preference order, which breaks ties and handles "*/*".
+// An empty Accept header selects the first offer; no acceptable offer returns "".
+func negotiateContentType(accept string, offers ...string) string {
+	if strings.TrimSpace(accept) == "" {
+		return offers[0]
+	}
+
+	var accepted []acceptedType
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" {
+			continue
+		}
+
+		accepted = append(accepted, acceptedType{mediaType: mediaType, quality: parseQuality(fields[1:])})
+	}
+
+	// Higher quality first, then more specific ranges first
+	sort.SliceStable(accepted, func(i, j int) bool {
+		if accepted[i].quality != accepted[j].quality {
+			return accepted[i].quality > accepted[j].quality
+		}
+		return strings.Count(accepted[i].mediaType, "*") < strings.Count(accepted[j].mediaType, "*")
+	})
+
+	for _, a := range accepted {
+		if a.quality <= 0 {
+			continue
+		}
+		for _, offer := range offers {
+			if mediaTypeMatches(a.mediaType, offer) {
+				return offer
+			}
+		}
+	}
+
+	return ""
+}
+
+func mediaTypeMatches(pattern, mediaType string) bool {
+	if pattern == "*/*" || pattern == mediaType {
+		return true
+	}
+	if strings.HasSuffix(pattern, "/*") {
+		return strings.HasPrefix(mediaType, strings.TrimSuffix(pattern, "*"))
+	}
+	return false
+}
+
+// parseQuality reads the q parameter of an Accept header entry, 1 when it is missing
+func parseQuality(params []string) float64 {
+	quality := 1.0
+	for _, param := range params {
+		key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+		if found && strings.TrimSpace(key) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = q
+			}
+		}
+	}
+	return quality
+}
+
+// acceptedLanguages lists the language tags of an Accept-Language header, most preferred first.
+// Languages with a quality of 0 and the "*" wildcard are left out.
+func acceptedLanguages(acceptLanguage string) []string {
+	type acceptedLanguage struct {
+		tag     string
+		quality float64
+	}
+
+	var accepted []acceptedLanguage
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		if quality := parseQuality(fields[1:]); quality > 0 {
+			accepted = append(accepted, acceptedLanguage{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(accepted, func(i, j int) bool {
+		return accepted[i].quality > accepted[j].quality
+	})
+
+	tags := make([]string, len(accepted))
+	for i, a := range accepted {
+		tags[i] = a.tag
+	}
+	return tags
+}
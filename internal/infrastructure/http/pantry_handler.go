@@ -0,0 +1,156 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PantryHandler handles HTTP requests for the pantry
+type PantryHandler struct {
+	pantryService interfaces.PantryService
+}
+
+// NewPantryHandler creates a new PantryHandler
+func NewPantryHandler(pantryService interfaces.PantryService) *PantryHandler {
+	return &PantryHandler{
+		pantryService: pantryService,
+	}
+}
+
+// GetItems handles the request to get the caller's pantry
+func (h *PantryHandler) GetItems(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	items, err := h.pantryService.GetItems(c.Request.Context(), *uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, items)
+}
+
+// AddItem handles the request to add an item to the pantry
+func (h *PantryHandler) AddItem(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var input interfaces.CreatePantryItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.UserID = *uid
+
+	item, err := h.pantryService.AddItem(c.Request.Context(), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateItem handles the request to update a pantry item
+func (h *PantryHandler) UpdateItem(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pantry item ID"})
+		return
+	}
+
+	var input interfaces.UpdatePantryItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.pantryService.UpdateItem(c.Request.Context(), id, *uid, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// DeleteItem handles the request to remove an item from the pantry
+func (h *PantryHandler) DeleteItem(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pantry item ID"})
+		return
+	}
+
+	if err := h.pantryService.DeleteItem(c.Request.Context(), id, *uid); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Pantry item deleted successfully"})
+}
+
+// SuggestRecipes handles the request to suggest recipes that use the pantry
+func (h *PantryHandler) SuggestRecipes(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	suggestions, err := h.pantryService.SuggestRecipes(c.Request.Context(), *uid, limit)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, suggestions)
+}
+
+// CookRecipe handles the request to deduct a cooked recipe's ingredients from the pantry
+func (h *PantryHandler) CookRecipe(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var input interfaces.CookFromPantryInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.pantryService.CookRecipe(c.Request.Context(), *uid, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
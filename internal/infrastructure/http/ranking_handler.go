@@ -0,0 +1,70 @@
+package http
+
+import (
+	"cookaholic/internal/domain"
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RankingHandler handles HTTP requests for trending and top rated recipes
+type RankingHandler struct {
+	rankingService interfaces.RankingService
+}
+
+// NewRankingHandler creates a new RankingHandler
+func NewRankingHandler(rankingService interfaces.RankingService) *RankingHandler {
+	return &RankingHandler{
+		rankingService: rankingService,
+	}
+}
+
+// GetTrendingRecipes handles the request to get the trending recipes of a window, daily by default
+func (h *RankingHandler) GetTrendingRecipes(c *gin.Context) {
+	offset, limit, ok := rankingPage(c)
+	if !ok {
+		return
+	}
+
+	recipes, err := h.rankingService.GetTrendingRecipes(c.Request.Context(), c.DefaultQuery("window", domain.RankingWindowDaily), offset, limit)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipes)
+}
+
+// GetTopRecipes handles the request to get the top rated recipes of a window, all time by default
+func (h *RankingHandler) GetTopRecipes(c *gin.Context) {
+	offset, limit, ok := rankingPage(c)
+	if !ok {
+		return
+	}
+
+	recipes, err := h.rankingService.GetTopRecipes(c.Request.Context(), c.DefaultQuery("window", domain.RankingWindowAllTime), offset, limit)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipes)
+}
+
+func rankingPage(c *gin.Context) (int, int, bool) {
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid offset"})
+		return 0, 0, false
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return 0, 0, false
+	}
+
+	return offset, limit, true
+}
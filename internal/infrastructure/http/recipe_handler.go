@@ -1,21 +1,39 @@
 package http
 
 import (
+	"cookaholic/internal/domain"
 	"cookaholic/internal/interfaces"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type RecipeHandler struct {
-	recipeService interfaces.RecipeService
+	recipeService       interfaces.RecipeService
+	recipeExportService interfaces.RecipeExportService
+	analyticsService    interfaces.AnalyticsService
+	recipeNoteService   interfaces.RecipeNoteService
 }
 
-func NewRecipeHandler(router *gin.Engine, recipeService interfaces.RecipeService) *RecipeHandler {
+// exportMediaTypes maps the media types accepted on GET /recipes/:id to export formats
+var exportMediaTypes = map[string]string{
+	"application/ld+json": interfaces.ExportFormatJSONLD,
+	"text/markdown":       interfaces.ExportFormatMarkdown,
+	"text/html":           interfaces.ExportFormatHTML,
+	"text/x-cooklang":     interfaces.ExportFormatCooklang,
+}
+
+func NewRecipeHandler(router *gin.Engine, recipeService interfaces.RecipeService, recipeExportService interfaces.RecipeExportService, analyticsService interfaces.AnalyticsService, recipeNoteService interfaces.RecipeNoteService) *RecipeHandler {
 	handler := &RecipeHandler{
-		recipeService: recipeService,
+		recipeService:       recipeService,
+		recipeExportService: recipeExportService,
+		analyticsService:    analyticsService,
+		recipeNoteService:   recipeNoteService,
 	}
 
 	return handler
@@ -39,7 +57,12 @@ func (h *RecipeHandler) CreateRecipe(c *gin.Context) {
 
 	recipe, createErr := h.recipeService.CreateRecipe(c.Request.Context(), input)
 	if createErr != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": createErr.Error()})
+		switch e := createErr.(type) {
+		case *interfaces.ValidationError:
+			c.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": createErr.Error()})
+		}
 		return
 	}
 
@@ -53,15 +76,103 @@ func (h *RecipeHandler) GetRecipe(c *gin.Context) {
 		return
 	}
 
+	mediaType := negotiateContentType(c.GetHeader("Accept"),
+		"application/json", "application/ld+json", "text/markdown", "text/html", "text/x-cooklang")
+	if mediaType == "" {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": "Requested representation is not available"})
+		return
+	}
+
+	if format, ok := exportMediaTypes[mediaType]; ok {
+		h.writeExport(c, id, format, false)
+		return
+	}
+
 	recipe, err := h.recipeService.GetRecipe(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if uid, errResp := AuthorizedPermission(c); errResp == nil {
+		h.analyticsService.RecordView(c.Request.Context(), recipe, *uid)
+
+		// Private notes are only embedded for the user who wrote them
+		notes, err := h.recipeNoteService.GetNote(c.Request.Context(), recipe.ID, *uid)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		recipe.Notes = notes
+	}
+
+	// ?expand=sections inlines the sub-recipes referenced by sections; they are localized along with the recipe
+	if c.Query("expand") == "sections" {
+		if err := h.recipeService.ExpandSections(c.Request.Context(), recipe); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	h.recipeService.LocalizeRecipe(recipe, acceptedLanguages(c.GetHeader("Accept-Language")))
+	c.Header("Content-Language", strings.Join(servedLocales(recipe, nil), ", "))
+	c.Header("Vary", "Accept, Accept-Language")
+	setLocalizedETag(c, recipe.Version, recipe.ServedLocale)
+
 	c.JSON(http.StatusOK, recipe)
 }
 
+// servedLocales appends the locales served for the recipe and its expanded sub-recipes, each once
+func servedLocales(recipe *domain.Recipe, locales []string) []string {
+	if !containsLocale(locales, recipe.ServedLocale) {
+		locales = append(locales, recipe.ServedLocale)
+	}
+	for _, section := range recipe.Sections {
+		if section.Recipe != nil {
+			locales = servedLocales(section.Recipe, locales)
+		}
+	}
+	return locales
+}
+
+func containsLocale(locales []string, locale string) bool {
+	for _, l := range locales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// ExportRecipe downloads a recipe as JSON-LD, Markdown, printable HTML or Cooklang
+func (h *RecipeHandler) ExportRecipe(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	h.writeExport(c, id, c.DefaultQuery("format", interfaces.ExportFormatJSONLD), true)
+}
+
+func (h *RecipeHandler) writeExport(c *gin.Context, id uuid.UUID, format string, attachment bool) {
+	export, err := h.recipeExportService.ExportRecipe(c.Request.Context(), id, format)
+	if err != nil {
+		if errors.Is(err, interfaces.ErrUnsupportedFormat) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if attachment {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename))
+	}
+	c.Header("Vary", "Accept")
+	c.Data(http.StatusOK, export.ContentType, export.Body)
+}
+
 func (h *RecipeHandler) UpdateRecipe(c *gin.Context) {
 	id, err := uuid.Parse(c.Param("id"))
 	if err != nil {
@@ -82,18 +193,173 @@ func (h *RecipeHandler) UpdateRecipe(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var input interfaces.UpdateRecipeInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	recipe, err := h.recipeService.UpdateRecipe(c.Request.Context(), id, uid, input)
+	recipe, err := h.recipeService.UpdateRecipe(c.Request.Context(), id, uid, version, input)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if writeVersionConflict(c, err) {
+			return
+		}
+		switch e := err.(type) {
+		case *interfaces.ValidationError:
+			c.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, recipe.Version)
+	c.JSON(http.StatusOK, recipe)
+}
+
+// PatchRecipe applies a JSON Merge Patch to one of the caller's recipes, so fields can be cleared
+// with null, if the If-Match header holds the recipe's current ETag
+func (h *RecipeHandler) PatchRecipe(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(c)
+	if !ok {
+		return
+	}
+
+	recipe, err := h.recipeService.PatchRecipe(c.Request.Context(), id, *uid, version, patch)
+	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
+		switch e := err.(type) {
+		case *interfaces.NotFoundError:
+			c.JSON(http.StatusNotFound, gin.H{"error": e.Error()})
+		case *interfaces.UnauthorizedError:
+			c.JSON(http.StatusForbidden, gin.H{"error": e.Error()})
+		case *interfaces.ValidationError:
+			c.JSON(http.StatusBadRequest, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	setETag(c, recipe.Version)
+	c.JSON(http.StatusOK, recipe)
+}
+
+// ForkRecipe copies a recipe into the caller's own recipes
+func (h *RecipeHandler) ForkRecipe(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	recipe, err := h.recipeService.ForkRecipe(c.Request.Context(), id, *uid)
+	if err != nil {
+		switch e := err.(type) {
+		case *interfaces.NotFoundError:
+			c.JSON(http.StatusNotFound, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, recipe)
+}
+
+// SetTranslation adds or replaces the translation of one of the caller's recipes into a locale,
+// if the If-Match header holds the recipe's current ETag
+func (h *RecipeHandler) SetTranslation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var input interfaces.RecipeTranslationInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	recipe, err := h.recipeService.SetTranslation(c.Request.Context(), id, *uid, version, c.Param("locale"), input)
+	if err != nil {
+		writeServiceError(c, err)
 		return
 	}
 
+	setETag(c, recipe.Version)
+	c.JSON(http.StatusOK, recipe)
+}
+
+// DeleteTranslation removes the translation of one of the caller's recipes into a locale,
+// if the If-Match header holds the recipe's current ETag
+func (h *RecipeHandler) DeleteTranslation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	recipe, err := h.recipeService.DeleteTranslation(c.Request.Context(), id, *uid, version, c.Param("locale"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	setETag(c, recipe.Version)
 	c.JSON(http.StatusOK, recipe)
 }
 
@@ -104,8 +370,16 @@ func (h *RecipeHandler) DeleteRecipe(c *gin.Context) {
 		return
 	}
 
-	err = h.recipeService.DeleteRecipe(c.Request.Context(), id)
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err = h.recipeService.DeleteRecipe(c.Request.Context(), id, version)
 	if err != nil {
+		if writeVersionConflict(c, err) {
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -167,11 +441,27 @@ func (h *RecipeHandler) FilterRecipes(c *gin.Context) {
 		input.Conditions["user_id"] = userID
 	}
 
+	if maxCostStr := c.Query("max_cost"); maxCostStr != "" {
+		maxCost, err := strconv.ParseFloat(maxCostStr, 64)
+		if err != nil || maxCost < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid max cost"})
+			return
+		}
+		input.Conditions["max_cost"] = maxCost
+		input.Conditions["currency"] = strings.ToUpper(strings.TrimSpace(c.Query("currency")))
+	}
+
 	recipes, nextCursor, err := h.recipeService.FilterRecipesByCondition(c.Request.Context(), input.Conditions, input.Cursor, input.Limit)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	preferred := acceptedLanguages(c.GetHeader("Accept-Language"))
+	for i := range recipes {
+		h.recipeService.LocalizeRecipe(&recipes[i], preferred)
+	}
+	c.Header("Vary", "Accept-Language")
+
 	c.JSON(http.StatusOK, gin.H{"recipes": recipes, "nextCursor": nextCursor})
 }
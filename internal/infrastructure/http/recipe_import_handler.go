@@ -0,0 +1,48 @@
+package http
+
+import (
+	"cookaholic/internal/infrastructure/fetcher"
+	"cookaholic/internal/interfaces"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RecipeImportHandler handles HTTP requests for importing recipes from web pages
+type RecipeImportHandler struct {
+	recipeImportService interfaces.RecipeImportService
+}
+
+// NewRecipeImportHandler creates a new RecipeImportHandler
+func NewRecipeImportHandler(recipeImportService interfaces.RecipeImportService) *RecipeImportHandler {
+	return &RecipeImportHandler{
+		recipeImportService: recipeImportService,
+	}
+}
+
+// PreviewImport extracts a recipe from the given URL and returns it for confirmation
+func (h *RecipeImportHandler) PreviewImport(c *gin.Context) {
+	var input interfaces.ImportRecipeInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	preview, err := h.recipeImportService.PreviewFromURL(c.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, interfaces.ErrNoRecipeFound) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		}
+		// The URL itself is at fault, not the site it points to
+		if errors.Is(err, fetcher.ErrBlockedAddress) || errors.Is(err, fetcher.ErrUnsupportedURL) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RecipeNoteHandler handles HTTP requests for private recipe notes
+type RecipeNoteHandler struct {
+	recipeNoteService interfaces.RecipeNoteService
+}
+
+// NewRecipeNoteHandler creates a new RecipeNoteHandler
+func NewRecipeNoteHandler(recipeNoteService interfaces.RecipeNoteService) *RecipeNoteHandler {
+	return &RecipeNoteHandler{
+		recipeNoteService: recipeNoteService,
+	}
+}
+
+// GetNote handles the request to get the caller's note on a recipe
+func (h *RecipeNoteHandler) GetNote(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	note, err := h.recipeNoteService.GetNote(c.Request.Context(), id, *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+	if note == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "note not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// GetNotes handles the request to get the caller's notes on all recipes
+func (h *RecipeNoteHandler) GetNotes(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	offset, limit, ok := rankingPage(c)
+	if !ok {
+		return
+	}
+
+	notes, err := h.recipeNoteService.GetNotes(c.Request.Context(), *uid, offset, limit)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, notes)
+}
+
+// SaveNote handles the request to create or replace the caller's note on a recipe
+func (h *RecipeNoteHandler) SaveNote(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	var input interfaces.SaveRecipeNoteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	note, err := h.recipeNoteService.SaveNote(c.Request.Context(), id, *uid, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, note)
+}
+
+// DeleteNote handles the request to delete the caller's note on a recipe
+func (h *RecipeNoteHandler) DeleteNote(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	if err := h.recipeNoteService.DeleteNote(c.Request.Context(), id, *uid); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Note deleted successfully"})
+}
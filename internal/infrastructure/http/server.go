@@ -19,6 +19,23 @@ type Server struct {
 	recipeRatingHandler     *RecipeRatingHandler
 	userFollowerHandler     *UserFollowerHandler
 	imageHandler            *ImageHandler
+	recipeImportHandler     *RecipeImportHandler
+	cooklangHandler         *CooklangHandler
+	shoppingListHandler     *ShoppingListHandler
+	mealPlanHandler         *MealPlanHandler
+	calendarFeedHandler     *CalendarFeedHandler
+	pantryHandler           *PantryHandler
+	cookLogHandler          *CookLogHandler
+	similarRecipeHandler    *SimilarRecipeHandler
+	feedHandler             *FeedHandler
+	rankingHandler          *RankingHandler
+	analyticsHandler        *AnalyticsHandler
+	duplicateHandler        *DuplicateHandler
+	bulkImportHandler       *BulkImportHandler
+	costHandler             *CostHandler
+	recipeNoteHandler       *RecipeNoteHandler
+	ingredientHandler       *IngredientHandler
+	trashHandler            *TrashHandler
 }
 
 // NewServer creates a new Server instance
@@ -39,17 +56,36 @@ func NewServer(application interfaces.Application) *Server {
 // setupHandlers initializes all HTTP handlers
 func (s *Server) setupHandlers() {
 	s.userHandler = NewUserHandler(s.router, s.app.GetUserService())
-	s.recipeHandler = NewRecipeHandler(s.router, s.app.GetRecipeService())
+	s.recipeHandler = NewRecipeHandler(s.router, s.app.GetRecipeService(), s.app.GetRecipeExportService(), s.app.GetAnalyticsService(), s.app.GetRecipeNoteService())
 	s.categoryHandler = NewCategoryHandler(s.router, s.app.GetCategoryService())
 	s.collectionHandler = NewCollectionHandler(s.router, s.app.GetCollectionService())
 	s.recipeCollectionHandler = NewRecipeCollectionHandler(s.app.GetRecipeCollectionService())
 	s.recipeRatingHandler = NewRecipeRatingHandler(s.app.GetRecipeRatingService())
 	s.userFollowerHandler = NewUserFollowerHandler(s.app.GetUserFollowerService())
 	s.imageHandler = NewImageHandler(s.router, s.app.GetImageService())
+	s.recipeImportHandler = NewRecipeImportHandler(s.app.GetRecipeImportService())
+	s.cooklangHandler = NewCooklangHandler(s.app.GetCooklangService())
+	s.shoppingListHandler = NewShoppingListHandler(s.app.GetShoppingListService())
+	s.mealPlanHandler = NewMealPlanHandler(s.app.GetMealPlanService())
+	s.calendarFeedHandler = NewCalendarFeedHandler(s.app.GetCalendarFeedService())
+	s.pantryHandler = NewPantryHandler(s.app.GetPantryService())
+	s.cookLogHandler = NewCookLogHandler(s.app.GetCookLogService())
+	s.similarRecipeHandler = NewSimilarRecipeHandler(s.app.GetSimilarRecipeService())
+	s.feedHandler = NewFeedHandler(s.app.GetFeedService())
+	s.rankingHandler = NewRankingHandler(s.app.GetRankingService())
+	s.analyticsHandler = NewAnalyticsHandler(s.app.GetAnalyticsService())
+	s.duplicateHandler = NewDuplicateHandler(s.app.GetDuplicateService())
+	s.bulkImportHandler = NewBulkImportHandler(s.app.GetBulkImportService())
+	s.costHandler = NewCostHandler(s.app.GetCostService())
+	s.recipeNoteHandler = NewRecipeNoteHandler(s.app.GetRecipeNoteService())
+	s.ingredientHandler = NewIngredientHandler(s.app.GetIngredientService(), s.app.GetIngredientParserService())
+	s.trashHandler = NewTrashHandler(s.app.GetTrashService())
 
 	// Public routes
 	s.router.POST("/api/users/login", s.userHandler.Login)
 	s.router.POST("/api/users/register", s.userHandler.Create)
+	s.router.GET("/api/shared/shopping-lists/:token", s.shoppingListHandler.GetSharedShoppingList)
+	s.router.GET("/api/calendar/:file", s.calendarFeedHandler.ServeFeed)
 
 	// Protected routes
 	protected := s.router.Group("/api")
@@ -61,6 +97,7 @@ func (s *Server) setupHandlers() {
 			users.POST("/resend-otp", s.userHandler.ResendOTP)
 			users.GET("/:id", s.userHandler.GetByID)
 			users.PUT("/:id", s.userHandler.Update)
+			users.PATCH("/:id", s.userHandler.Patch)
 			users.DELETE("/:id", s.userHandler.Delete)
 			users.GET("", s.userHandler.List)
 
@@ -79,13 +116,30 @@ func (s *Server) setupHandlers() {
 			recipes.POST("", s.recipeHandler.CreateRecipe)
 			recipes.GET("/:id", s.recipeHandler.GetRecipe)
 			recipes.PUT("/:id", s.recipeHandler.UpdateRecipe)
+			recipes.PATCH("/:id", s.recipeHandler.PatchRecipe)
 			recipes.DELETE("/:id", s.recipeHandler.DeleteRecipe)
+			recipes.POST("/:id/fork", s.recipeHandler.ForkRecipe)
+			recipes.PUT("/:id/translations/:locale", s.recipeHandler.SetTranslation)
+			recipes.DELETE("/:id/translations/:locale", s.recipeHandler.DeleteTranslation)
 			recipes.GET("", s.recipeHandler.FilterRecipes)
+			recipes.GET("/trending", s.rankingHandler.GetTrendingRecipes)
+			recipes.GET("/top", s.rankingHandler.GetTopRecipes)
 			recipes.GET("/:id/collections", s.recipeCollectionHandler.GetCollectionsByRecipeID)
 			recipes.GET("/:id/collections/:collectionId/check", s.recipeCollectionHandler.IsRecipeInCollection)
 			recipes.GET("/:id/ratings", s.recipeRatingHandler.GetRatingsByRecipeID)
 			recipes.GET("/:id/ratings/me", s.recipeRatingHandler.GetUserRatingForRecipe)
+			recipes.GET("/:id/similar", s.similarRecipeHandler.GetSimilarRecipes)
 			recipes.POST("/:id/ratings", s.recipeRatingHandler.RateRecipe)
+			recipes.POST("/import/preview", s.recipeImportHandler.PreviewImport)
+			recipes.GET("/:id/export", s.recipeHandler.ExportRecipe)
+			recipes.POST("/import/cooklang", s.cooklangHandler.ImportRecipe)
+			recipes.POST("/import/cooklang/archive", s.cooklangHandler.ImportArchive)
+			recipes.POST("/import/bulk", s.bulkImportHandler.StartImport)
+			recipes.GET("/export/bulk", s.bulkImportHandler.ExportRecipes)
+			recipes.GET("/:id/cost", s.costHandler.GetRecipeCost)
+			recipes.GET("/:id/notes", s.recipeNoteHandler.GetNote)
+			recipes.PUT("/:id/notes", s.recipeNoteHandler.SaveNote)
+			recipes.DELETE("/:id/notes", s.recipeNoteHandler.DeleteNote)
 		}
 
 		categories := protected.Group("/categories")
@@ -102,6 +156,7 @@ func (s *Server) setupHandlers() {
 			collections.POST("", s.collectionHandler.CreateCollection)
 			collections.GET("/:id", s.collectionHandler.GetCollection)
 			collections.PUT("/:id", s.collectionHandler.UpdateCollection)
+			collections.PATCH("/:id", s.collectionHandler.PatchCollection)
 			collections.DELETE("/:id", s.collectionHandler.DeleteCollection)
 			collections.GET("", s.collectionHandler.GetUserCollections)
 			collections.POST("/:id/recipes/:recipeId", s.recipeCollectionHandler.SaveRecipeToCollection)
@@ -115,6 +170,92 @@ func (s *Server) setupHandlers() {
 			images.POST("/upload-multiple", s.imageHandler.UploadMultipleImages)
 		}
 
+		shoppingLists := protected.Group("/shopping-lists")
+		{
+			shoppingLists.POST("", s.shoppingListHandler.GenerateShoppingList)
+			shoppingLists.GET("", s.shoppingListHandler.GetUserShoppingLists)
+			shoppingLists.GET("/:id", s.shoppingListHandler.GetShoppingList)
+			shoppingLists.DELETE("/:id", s.shoppingListHandler.DeleteShoppingList)
+			shoppingLists.POST("/:id/items", s.shoppingListHandler.AddItem)
+			shoppingLists.PUT("/:id/items/:itemId", s.shoppingListHandler.UpdateItem)
+			shoppingLists.DELETE("/:id/items/:itemId", s.shoppingListHandler.DeleteItem)
+			shoppingLists.POST("/:id/share", s.shoppingListHandler.ShareShoppingList)
+			shoppingLists.DELETE("/:id/share", s.shoppingListHandler.UnshareShoppingList)
+		}
+
+		mealPlans := protected.Group("/meal-plans")
+		{
+			mealPlans.POST("/entries", s.mealPlanHandler.AddEntry)
+			mealPlans.PUT("/entries/:id", s.mealPlanHandler.UpdateEntry)
+			mealPlans.DELETE("/entries/:id", s.mealPlanHandler.DeleteEntry)
+			mealPlans.GET("/weeks/:date", s.mealPlanHandler.GetWeek)
+			mealPlans.POST("/weeks/:date/copy", s.mealPlanHandler.CopyWeek)
+			mealPlans.POST("/weeks/:date/shopping-list", s.mealPlanHandler.GenerateShoppingList)
+			mealPlans.GET("/weeks/:date/summary", s.mealPlanHandler.GetWeekSummary)
+			mealPlans.GET("/feed", s.calendarFeedHandler.GetFeed)
+			mealPlans.POST("/feed/rotate", s.calendarFeedHandler.RotateFeed)
+			mealPlans.DELETE("/feed", s.calendarFeedHandler.RevokeFeed)
+		}
+
+		pantry := protected.Group("/pantry")
+		{
+			pantry.GET("", s.pantryHandler.GetItems)
+			pantry.POST("", s.pantryHandler.AddItem)
+			pantry.GET("/suggestions", s.pantryHandler.SuggestRecipes)
+			pantry.POST("/cook", s.pantryHandler.CookRecipe)
+			pantry.PUT("/:id", s.pantryHandler.UpdateItem)
+			pantry.DELETE("/:id", s.pantryHandler.DeleteItem)
+		}
+
+		trash := protected.Group("/trash")
+		{
+			trash.GET("", s.trashHandler.GetTrash)
+			trash.POST("/recipes/:id/restore", s.trashHandler.RestoreRecipe)
+			trash.POST("/collections/:id/restore", s.trashHandler.RestoreCollection)
+		}
+
+		ingredients := protected.Group("/ingredients")
+		{
+			ingredients.GET("/autocomplete", s.ingredientHandler.Autocomplete)
+			ingredients.POST("/parse", s.ingredientHandler.ParseIngredients)
+			ingredients.GET("/:id", s.ingredientHandler.GetIngredient)
+		}
+
+		ingredientPrices := protected.Group("/ingredient-prices")
+		{
+			ingredientPrices.GET("", s.costHandler.GetPrices)
+			ingredientPrices.POST("", s.costHandler.CreatePrice)
+			ingredientPrices.PUT("/:id", s.costHandler.UpdatePrice)
+			ingredientPrices.DELETE("/:id", s.costHandler.DeletePrice)
+		}
+
+		cookLogs := protected.Group("/cook-logs")
+		{
+			cookLogs.POST("", s.cookLogHandler.LogCook)
+			cookLogs.GET("", s.cookLogHandler.GetCookHistory)
+			cookLogs.GET("/:id", s.cookLogHandler.GetCookLog)
+			cookLogs.DELETE("/:id", s.cookLogHandler.DeleteCookLog)
+		}
+
+		protected.GET("/feed", s.feedHandler.GetFeed)
+		protected.GET("/analytics", s.analyticsHandler.GetAuthorAnalytics)
+		protected.GET("/import-jobs/:id", s.bulkImportHandler.GetJob)
+		protected.GET("/notes", s.recipeNoteHandler.GetNotes)
+
+		admin := protected.Group("/admin")
+		admin.Use(middleware.AdminMiddleware(s.app.GetUserService()))
+		{
+			admin.GET("/duplicates", s.duplicateHandler.GetDuplicateClusters)
+			admin.POST("/ingredient-prices", s.costHandler.CreateSharedPrice)
+			admin.PUT("/ingredient-prices/:id", s.costHandler.UpdateSharedPrice)
+			admin.DELETE("/ingredient-prices/:id", s.costHandler.DeleteSharedPrice)
+			admin.POST("/ingredients", s.ingredientHandler.CreateIngredient)
+			admin.PUT("/ingredients/:id", s.ingredientHandler.UpdateIngredient)
+			admin.DELETE("/ingredients/:id", s.ingredientHandler.DeleteIngredient)
+			admin.GET("/trash/categories", s.trashHandler.GetTrashedCategories)
+			admin.POST("/trash/categories/:id/restore", s.trashHandler.RestoreCategory)
+		}
+
 		ratings := protected.Group("/ratings")
 		{
 			ratings.PUT("/:id", s.recipeRatingHandler.UpdateRating)
@@ -0,0 +1,258 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ShoppingListHandler handles HTTP requests for shopping lists
+type ShoppingListHandler struct {
+	shoppingListService interfaces.ShoppingListService
+}
+
+// NewShoppingListHandler creates a new ShoppingListHandler
+func NewShoppingListHandler(shoppingListService interfaces.ShoppingListService) *ShoppingListHandler {
+	return &ShoppingListHandler{
+		shoppingListService: shoppingListService,
+	}
+}
+
+// GenerateShoppingList handles the request to generate a shopping list from recipes or a collection
+func (h *ShoppingListHandler) GenerateShoppingList(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	var input interfaces.GenerateShoppingListInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.UserID = *uid
+
+	list, err := h.shoppingListService.GenerateShoppingList(c.Request.Context(), input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// GetUserShoppingLists handles the request to get the caller's shopping lists
+func (h *ShoppingListHandler) GetUserShoppingLists(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	lists, err := h.shoppingListService.GetUserShoppingLists(c.Request.Context(), *uid)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, lists)
+}
+
+// GetShoppingList handles the request to get a shopping list grouped by aisle
+func (h *ShoppingListHandler) GetShoppingList(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shopping list ID"})
+		return
+	}
+
+	list, err := h.shoppingListService.GetShoppingList(c.Request.Context(), id, *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// GetSharedShoppingList handles the public request to view a shared shopping list
+func (h *ShoppingListHandler) GetSharedShoppingList(c *gin.Context) {
+	list, err := h.shoppingListService.GetSharedShoppingList(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// DeleteShoppingList handles the request to delete a shopping list
+func (h *ShoppingListHandler) DeleteShoppingList(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shopping list ID"})
+		return
+	}
+
+	if err := h.shoppingListService.DeleteShoppingList(c.Request.Context(), id, *uid); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Shopping list deleted successfully"})
+}
+
+// AddItem handles the request to add a manual item to a shopping list
+func (h *ShoppingListHandler) AddItem(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shopping list ID"})
+		return
+	}
+
+	var input interfaces.AddShoppingListItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.shoppingListService.AddItem(c.Request.Context(), id, *uid, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateItem handles the request to update a shopping list item, e.g. to check it off
+func (h *ShoppingListHandler) UpdateItem(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shopping list ID"})
+		return
+	}
+
+	itemID, err := uuid.Parse(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	var input interfaces.UpdateShoppingListItemInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.shoppingListService.UpdateItem(c.Request.Context(), id, itemID, *uid, input)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, item)
+}
+
+// DeleteItem handles the request to remove an item from a shopping list
+func (h *ShoppingListHandler) DeleteItem(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shopping list ID"})
+		return
+	}
+
+	itemID, err := uuid.Parse(c.Param("itemId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+
+	if err := h.shoppingListService.DeleteItem(c.Request.Context(), id, itemID, *uid); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Item deleted successfully"})
+}
+
+// ShareShoppingList handles the request to share a shopping list
+func (h *ShoppingListHandler) ShareShoppingList(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shopping list ID"})
+		return
+	}
+
+	list, err := h.shoppingListService.ShareShoppingList(c.Request.Context(), id, *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"share_token": list.ShareToken,
+		"share_path":  "/api/shared/shopping-lists/" + list.ShareToken,
+	})
+}
+
+// UnshareShoppingList handles the request to stop sharing a shopping list
+func (h *ShoppingListHandler) UnshareShoppingList(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid shopping list ID"})
+		return
+	}
+
+	if err := h.shoppingListService.UnshareShoppingList(c.Request.Context(), id, *uid); err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Shopping list is no longer shared"})
+}
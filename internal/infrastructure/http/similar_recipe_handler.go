@@ -0,0 +1,50 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// SimilarRecipeHandler handles HTTP requests for similar recipe recommendations
+type SimilarRecipeHandler struct {
+	similarRecipeService interfaces.SimilarRecipeService
+}
+
+// NewSimilarRecipeHandler creates a new SimilarRecipeHandler
+func NewSimilarRecipeHandler(similarRecipeService interfaces.SimilarRecipeService) *SimilarRecipeHandler {
+	return &SimilarRecipeHandler{
+		similarRecipeService: similarRecipeService,
+	}
+}
+
+// GetSimilarRecipes handles the request to get recipes similar to a recipe
+func (h *SimilarRecipeHandler) GetSimilarRecipes(c *gin.Context) {
+	recipeID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	similar, err := h.similarRecipeService.GetSimilarRecipes(c.Request.Context(), recipeID, limit)
+	if err != nil {
+		switch e := err.(type) {
+		case *interfaces.NotFoundError:
+			c.JSON(http.StatusNotFound, gin.H{"error": e.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, similar)
+}
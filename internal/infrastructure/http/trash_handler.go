@@ -0,0 +1,112 @@
+package http
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TrashHandler handles HTTP requests for deleted content
+type TrashHandler struct {
+	trashService interfaces.TrashService
+}
+
+// NewTrashHandler creates a new TrashHandler
+func NewTrashHandler(trashService interfaces.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// GetTrash handles the request to list the caller's deleted recipes and collections
+func (h *TrashHandler) GetTrash(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	trash, err := h.trashService.GetTrash(c.Request.Context(), *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
+
+// RestoreRecipe handles the request to restore one of the caller's deleted recipes
+func (h *TrashHandler) RestoreRecipe(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recipe ID format"})
+		return
+	}
+
+	recipe, err := h.trashService.RestoreRecipe(c.Request.Context(), id, *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recipe)
+}
+
+// RestoreCollection handles the request to restore one of the caller's deleted collections
+func (h *TrashHandler) RestoreCollection(c *gin.Context) {
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid collection ID"})
+		return
+	}
+
+	collection, err := h.trashService.RestoreCollection(c.Request.Context(), id, *uid)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, collection)
+}
+
+// GetTrashedCategories handles the request to list the deleted categories
+func (h *TrashHandler) GetTrashedCategories(c *gin.Context) {
+	categories, err := h.trashService.GetTrashedCategories(c.Request.Context())
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// RestoreCategory handles the request to restore a deleted category
+func (h *TrashHandler) RestoreCategory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+
+	category, err := h.trashService.RestoreCategory(c.Request.Context(), id)
+	if err != nil {
+		writeServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
@@ -62,6 +62,7 @@ func (h *UserHandler) GetByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -72,23 +73,81 @@ func (h *UserHandler) Update(c *gin.Context) {
 		return
 	}
 
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var input interfaces.UpdateUserInput
 	if err := c.ShouldBindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	user, err := h.userService.Update(c.Request.Context(), id, input)
+	user, err := h.userService.Update(c.Request.Context(), id, version, input)
 	if err != nil {
 		switch err {
 		case interfaces.ErrUserNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case interfaces.ErrVersionConflict:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 		}
 		return
 	}
 
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+// Patch applies a JSON Merge Patch to the caller's own profile, so the full name, avatar and bio
+// can be cleared with null, if the If-Match header holds the profile's current ETag
+func (h *UserHandler) Patch(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID format"})
+		return
+	}
+
+	uid, errResp := AuthorizedPermission(c)
+	if errResp != nil {
+		c.JSON(http.StatusUnauthorized, errResp)
+		return
+	}
+	if *uid != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You can only update your own profile"})
+		return
+	}
+
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	patch, ok := readMergePatch(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.Patch(c.Request.Context(), id, version, patch)
+	if err != nil {
+		if validationErr, ok := err.(*interfaces.ValidationError); ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": validationErr.Error()})
+			return
+		}
+		switch err {
+		case interfaces.ErrUserNotFound:
+			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case interfaces.ErrVersionConflict:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		}
+		return
+	}
+
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -99,10 +158,17 @@ func (h *UserHandler) Delete(c *gin.Context) {
 		return
 	}
 
-	if err := h.userService.Delete(c.Request.Context(), id); err != nil {
+	version, ok := ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.Delete(c.Request.Context(), id, version); err != nil {
 		switch err {
 		case interfaces.ErrUserNotFound:
 			c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		case interfaces.ErrVersionConflict:
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
 		}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"cookaholic/internal/interfaces"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// AdminMiddleware only lets through users whose verified email is listed in the comma-separated
+// ADMIN_EMAILS environment variable. The user is loaded rather than trusting the token's email
+// claim, so registering an admin address without being able to receive mail there grants nothing.
+// It must run after AuthMiddleware.
+func AdminMiddleware(userService interfaces.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := c.Get("user_id")
+		id, isID := userID.(uuid.UUID)
+		if !ok || !isID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+
+		user, err := userService.GetByID(c.Request.Context(), id)
+		if err != nil || user.Status != 1 || !user.EmailVerified || !isAdminEmail(strings.TrimSpace(user.Email)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+func isAdminEmail(email string) bool {
+	if email == "" {
+		return false
+	}
+	for _, admin := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		if strings.EqualFold(strings.TrimSpace(admin), email) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type AnalyticsService interface {
+	// Record that a user viewed a recipe. Authors viewing their own recipes are not counted, and
+	// failures are logged rather than returned so tracking never fails a read.
+	RecordView(ctx context.Context, recipe *domain.Recipe, viewerID uuid.UUID)
+
+	// Get the activity on the author's recipes
+	GetAuthorAnalytics(ctx context.Context, authorID uuid.UUID, input AuthorAnalyticsInput) (*domain.AuthorAnalytics, error)
+
+	// Roll the recent activity up into daily stats and delete views that are no longer needed
+	RollupDailyStats(ctx context.Context) error
+}
+
+type AuthorAnalyticsInput struct {
+	From     string    // YYYY-MM-DD, 29 days before To by default
+	To       string    // YYYY-MM-DD, today by default
+	RecipeID uuid.UUID // limits the analytics to a single recipe when set
+}
@@ -11,4 +11,23 @@ type Application interface {
 	GetRecipeRatingService() RecipeRatingService
 	GetUserFollowerService() UserFollowerService
 	GetImageService() ImageService
+	GetRecipeImportService() RecipeImportService
+	GetRecipeExportService() RecipeExportService
+	GetCooklangService() CooklangService
+	GetShoppingListService() ShoppingListService
+	GetMealPlanService() MealPlanService
+	GetCalendarFeedService() CalendarFeedService
+	GetPantryService() PantryService
+	GetCookLogService() CookLogService
+	GetSimilarRecipeService() SimilarRecipeService
+	GetFeedService() FeedService
+	GetRankingService() RankingService
+	GetAnalyticsService() AnalyticsService
+	GetDuplicateService() DuplicateService
+	GetBulkImportService() BulkImportService
+	GetCostService() CostService
+	GetRecipeNoteService() RecipeNoteService
+	GetIngredientService() IngredientService
+	GetIngredientParserService() IngredientParserService
+	GetTrashService() TrashService
 }
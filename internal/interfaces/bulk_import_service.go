@@ -0,0 +1,57 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Supported bulk import and export formats
+const (
+	BulkFormatCSV  = "csv"
+	BulkFormatJSON = "json"
+	// Import only: Paprika .paprikarecipes archives and Mealie JSON exports
+	BulkFormatPaprika = "paprika"
+	BulkFormatMealie  = "mealie"
+)
+
+// BulkImportService moves all of a user's recipes in and out of the app at once
+type BulkImportService interface {
+	// StartImport checks the uploaded file and imports its recipes in the background
+	StartImport(ctx context.Context, input BulkImportInput) (*domain.ImportJob, error)
+
+	// GetJob gets the progress of one of the user's import jobs
+	GetJob(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ImportJob, error)
+
+	// ExportRecipes renders all of the user's recipes in a format StartImport accepts
+	ExportRecipes(ctx context.Context, userID uuid.UUID, format string) (*RecipeExport, error)
+
+	// FailInterruptedJobs marks the jobs left unfinished by a restart as failed
+	FailInterruptedJobs(ctx context.Context) error
+}
+
+type BulkImportInput struct {
+	UserID     uuid.UUID
+	Format     string
+	CategoryID uuid.UUID // category of the rows that have none, may be uuid.Nil
+	Content    []byte
+}
+
+// BulkRecipe is a recipe in a bulk import or export file. Sub-recipe sections and
+// translations are not part of it.
+type BulkRecipe struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Time        int                 `json:"time"`
+	CategoryID  uuid.UUID           `json:"category_id"`
+	ServingSize int                 `json:"serving_size"`
+	Images      []common.Image      `json:"images"`
+	Ingredients []domain.Ingredient `json:"ingredients"`
+	Steps       []domain.Step       `json:"steps"`
+	Sections    domain.Sections     `json:"sections,omitempty"`
+	Nutrition   *domain.Nutrition   `json:"nutrition,omitempty"`
+	Tags        []string            `json:"tags"`
+	Locale      string              `json:"locale"`
+}
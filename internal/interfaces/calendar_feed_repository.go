@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type CalendarFeedRepository interface {
+	// Get the calendar feed of a user
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error)
+
+	// Get a calendar feed by its secret token
+	GetByToken(ctx context.Context, token string) (*domain.CalendarFeed, error)
+
+	// Create or replace the calendar feed of a user
+	Save(ctx context.Context, feed *domain.CalendarFeed) error
+
+	// Delete the calendar feed of a user
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) error
+}
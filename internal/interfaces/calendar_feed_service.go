@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type CalendarFeedService interface {
+	// Get the user's calendar feed, creating it on first use
+	GetFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error)
+
+	// Replace the feed token, invalidating the previous subscription URL
+	RotateFeed(ctx context.Context, userID uuid.UUID) (*domain.CalendarFeed, error)
+
+	// Remove the user's calendar feed
+	RevokeFeed(ctx context.Context, userID uuid.UUID) error
+
+	// Render the meal plan behind a feed token as an iCalendar document
+	RenderFeed(ctx context.Context, token string) ([]byte, error)
+}
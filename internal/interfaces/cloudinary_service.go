@@ -3,6 +3,7 @@ package interfaces
 import (
 	"context"
 	"cookaholic/internal/common"
+	"io"
 	"mime/multipart"
 )
 
@@ -10,4 +11,5 @@ import (
 type CloudinaryService interface {
 	UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (*common.Image, error)
 	UploadMultipleImages(ctx context.Context, files []*multipart.FileHeader, folder string) ([]*common.Image, error)
+	UploadImageData(ctx context.Context, data io.Reader, folder string) (*common.Image, error)
 }
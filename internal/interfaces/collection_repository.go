@@ -11,6 +11,7 @@ type CollectionRepository interface {
 	Create(ctx context.Context, collection *domain.Collection) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Collection, error)
+	// Update and Delete return ErrVersionConflict unless the collection is still at the given version
 	Update(ctx context.Context, collection *domain.Collection) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, version int64) error
 }
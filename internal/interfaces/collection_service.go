@@ -12,8 +12,12 @@ type CollectionService interface {
 	CreateCollection(ctx context.Context, input CreateCollectionInput) (*domain.Collection, error)
 	GetCollectionByID(ctx context.Context, id uuid.UUID) (*domain.Collection, error)
 	GetCollectionByUserID(ctx context.Context, userID uuid.UUID) ([]domain.Collection, error)
-	UpdateCollection(ctx context.Context, id uuid.UUID, input UpdateCollectionInput) (*domain.Collection, error)
-	DeleteCollection(ctx context.Context, id uuid.UUID) error
+	// UpdateCollection and DeleteCollection return ErrVersionConflict unless the collection is still at the given version
+	UpdateCollection(ctx context.Context, id uuid.UUID, version int64, input UpdateCollectionInput) (*domain.Collection, error)
+	DeleteCollection(ctx context.Context, id uuid.UUID, version int64) error
+	// PatchCollection applies a JSON Merge Patch of the name, description and image to a collection,
+	// so they can be cleared with null; it returns ErrVersionConflict unless the collection is at the given version
+	PatchCollection(ctx context.Context, id uuid.UUID, version int64, patch []byte) (*domain.Collection, error)
 }
 
 type CreateCollectionInput struct {
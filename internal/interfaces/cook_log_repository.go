@@ -0,0 +1,26 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type CookLogRepository interface {
+	// Create a cook log and update the recipe's cook count. A pantry deduction on the log is applied
+	// in the same transaction, see PantryRepository.Deduct.
+	Create(ctx context.Context, log *domain.CookLog) error
+
+	// Get a cook log by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.CookLog, error)
+
+	// Get a user's cook logs, most recently cooked first, optionally for a single recipe
+	GetByUserID(ctx context.Context, userID uuid.UUID, recipeID uuid.UUID, cursor uuid.UUID, limit int) ([]domain.CookLog, uuid.UUID, error)
+
+	// Check whether the user has logged cooking the recipe
+	ExistsForUserAndRecipe(ctx context.Context, userID, recipeID uuid.UUID) (bool, error)
+
+	// Delete a cook log and update the recipe's cook count
+	Delete(ctx context.Context, id uuid.UUID) error
+}
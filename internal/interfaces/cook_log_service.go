@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/common"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CookLogService interface {
+	// Log that the user cooked a recipe
+	LogCook(ctx context.Context, input CreateCookLogInput) (*domain.CookLog, error)
+
+	// Get a cook log owned by the user
+	GetCookLog(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.CookLog, error)
+
+	// Get the user's cooking history, optionally for a single recipe
+	GetCookHistory(ctx context.Context, userID uuid.UUID, recipeID uuid.UUID, cursor uuid.UUID, limit int) ([]domain.CookLog, uuid.UUID, error)
+
+	// Delete a cook log owned by the user
+	DeleteCookLog(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+}
+
+type CreateCookLogInput struct {
+	UserID   uuid.UUID      `json:"-"`
+	RecipeID uuid.UUID      `json:"recipe_id" binding:"required"`
+	CookedAt *time.Time     `json:"cooked_at"` // defaults to now
+	Notes    string         `json:"notes"`
+	Photos   []common.Image `json:"photos"`
+	Servings int            `json:"servings"` // defaults to the recipe's serving size
+	// DeductPantry deducts the ingredients used from the user's pantry
+	DeductPantry bool `json:"deduct_pantry"`
+}
@@ -0,0 +1,47 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// CooklangService imports recipes written in the Cooklang (.cook) format
+type CooklangService interface {
+	// ImportRecipe parses a single .cook file and creates the recipe in the caller's account
+	ImportRecipe(ctx context.Context, input CooklangImportInput) (*domain.Recipe, error)
+
+	// ImportArchive creates a recipe for every .cook file in a ZIP archive
+	ImportArchive(ctx context.Context, input CooklangArchiveInput) (*CooklangArchiveResult, error)
+}
+
+type CooklangImportInput struct {
+	UserID     uuid.UUID
+	CategoryID uuid.UUID
+	Filename   string
+	Content    []byte
+}
+
+type CooklangArchiveInput struct {
+	UserID     uuid.UUID
+	CategoryID uuid.UUID
+	Archive    []byte
+}
+
+// CooklangArchiveResult reports the outcome of every file in an imported archive
+type CooklangArchiveResult struct {
+	Imported []CooklangImportedFile `json:"imported"`
+	Failed   []CooklangFailedFile   `json:"failed"`
+}
+
+type CooklangImportedFile struct {
+	Filename string    `json:"filename"`
+	RecipeID uuid.UUID `json:"recipe_id"`
+	Title    string    `json:"title"`
+}
+
+type CooklangFailedFile struct {
+	Filename string `json:"filename"`
+	Error    string `json:"error"`
+}
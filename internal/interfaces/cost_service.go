@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type CostService interface {
+	// Get the shared prices and the user's own prices in a currency
+	GetPrices(ctx context.Context, userID uuid.UUID, currency string) ([]domain.IngredientPrice, error)
+
+	// Add a price to the catalogue; a nil input.UserID adds a shared price
+	CreatePrice(ctx context.Context, input CreateIngredientPriceInput) (*domain.IngredientPrice, error)
+
+	// Update a price kept by the user, or a shared price when userID is nil
+	UpdatePrice(ctx context.Context, id uuid.UUID, userID *uuid.UUID, input UpdateIngredientPriceInput) (*domain.IngredientPrice, error)
+
+	// Delete a price kept by the user, or a shared price when userID is nil
+	DeletePrice(ctx context.Context, id uuid.UUID, userID *uuid.UUID) error
+
+	// Estimate the cost of a recipe from the user's prices and the shared prices
+	EstimateRecipeCost(ctx context.Context, recipeID uuid.UUID, userID uuid.UUID, currency string) (*domain.RecipeCost, error)
+
+	// Recompute the stored cost of every recipe from the shared prices
+	RecomputeCosts(ctx context.Context) error
+}
+
+type CreateIngredientPriceInput struct {
+	UserID   *uuid.UUID `json:"-"`
+	Name     string     `json:"name" binding:"required"`
+	Price    float64    `json:"price"`
+	Unit     string     `json:"unit"`
+	Currency string     `json:"currency"` // DefaultCurrency when empty
+}
+
+type UpdateIngredientPriceInput struct {
+	Name  *string  `json:"name"`
+	Price *float64 `json:"price"`
+	Unit  *string  `json:"unit"`
+}
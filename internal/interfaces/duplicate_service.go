@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+)
+
+type DuplicateService interface {
+	// Fingerprint a recipe and record its likely duplicates. Returns the author's own recipes it duplicates.
+	CheckRecipe(ctx context.Context, recipe *domain.Recipe) ([]domain.DuplicateWarning, error)
+
+	// Fingerprint the recipes saved before duplicate detection, or whose check failed
+	FingerprintMissing(ctx context.Context) error
+
+	// Get the clusters of likely duplicate recipes for moderators
+	GetDuplicateClusters(ctx context.Context) ([]domain.DuplicateCluster, error)
+}
@@ -1,7 +1,11 @@
 package interfaces
 
-import "context"
+import (
+	"context"
+	"cookaholic/internal/domain"
+)
 
 type EmailService interface {
 	SendOTP(ctx context.Context, email, otp string) error
+	SendPantryExpiryReminder(ctx context.Context, email string, items []domain.PantryItem, suggestions []domain.PantrySuggestion) error
 }
@@ -13,8 +13,15 @@ var (
 	ErrCollectionNotFound = errors.New("collection not found")
 	ErrRatingNotFound     = errors.New("rating not found")
 	ErrUnauthorized       = errors.New("unauthorized")
+	ErrNoRecipeFound      = errors.New("no schema.org recipe found on page")
+	ErrUnsupportedFormat  = errors.New("unsupported export format")
+	ErrVersionConflict    = errors.New("the resource has been modified since it was read")
+	ErrPantryChanged      = errors.New("the pantry was changed by another request")
 )
 
+// AnyVersion is passed instead of a version to write to a resource whatever its current version is
+const AnyVersion int64 = 0
+
 // NotFoundError represents a not found error
 type NotFoundError struct {
 	message string
@@ -30,6 +37,21 @@ func (e *NotFoundError) Error() string {
 	return e.message
 }
 
+// ValidationError represents invalid input rejected by a service
+type ValidationError struct {
+	message string
+}
+
+// NewValidationError creates a new validation error
+func NewValidationError(message string) error {
+	return &ValidationError{message: message}
+}
+
+// Error returns the error message
+func (e *ValidationError) Error() string {
+	return e.message
+}
+
 // UnauthorizedError represents an unauthorized error
 type UnauthorizedError struct {
 	message string
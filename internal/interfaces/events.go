@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -19,6 +20,16 @@ func (e UserCreatedEvent) Type() string {
 	return "user.created"
 }
 
+type RecipeCreatedEvent struct {
+	RecipeID  uuid.UUID
+	UserID    uuid.UUID
+	CreatedAt time.Time
+}
+
+func (e RecipeCreatedEvent) Type() string {
+	return "recipe.created"
+}
+
 type EventHandler interface {
 	Handle(ctx context.Context, event Event) error
 }
@@ -0,0 +1,24 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type FeedRepository interface {
+	// Add a new recipe to the feeds of the author's followers
+	FanOutRecipe(ctx context.Context, recipeID, authorID uuid.UUID, publishedAt time.Time) error
+
+	// Get recipes from the feed of a user, published by users they still follow, after the given position
+	GetFollowedRecipes(ctx context.Context, userID uuid.UUID, after *domain.FeedPosition, limit int) ([]domain.FeedItem, error)
+
+	// Get popular recipes in the given categories, leaving out the user's own recipes and those of
+	// users they follow. No categories means all categories.
+	GetPopularRecipes(ctx context.Context, userID uuid.UUID, categoryIDs []uuid.UUID, after *domain.FeedPosition, limit int) ([]domain.FeedItem, error)
+
+	// Get the categories the user engages with most through ratings, saves and cooks
+	GetEngagedCategoryIDs(ctx context.Context, userID uuid.UUID, limit int) ([]uuid.UUID, error)
+}
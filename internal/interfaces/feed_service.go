@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type FeedService interface {
+	// Get a page of the user's home feed; an empty cursor starts at the newest items
+	GetFeed(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*domain.FeedPage, error)
+}
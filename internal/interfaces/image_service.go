@@ -9,6 +9,8 @@ import (
 type ImageService interface {
 	UploadFile(ctx context.Context, file *multipart.FileHeader, folder string) (*common.Image, error)
 	UploadMultipleFiles(ctx context.Context, files []*multipart.FileHeader, folder string) ([]*common.Image, error)
+	// UploadData uploads an image that is not a form upload, such as a photo embedded in an import file
+	UploadData(ctx context.Context, data []byte, folder string) (*common.Image, error)
 }
 
 type UploadImageInput struct {
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ImportJobRepository interface {
+	Create(ctx context.Context, job *domain.ImportJob) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ImportJob, error)
+	Update(ctx context.Context, job *domain.ImportJob) error
+
+	// Mark the jobs that are still pending or running as failed with the given message
+	FailUnfinished(ctx context.Context, message string) error
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+)
+
+type IngredientParserService interface {
+	// Parse free-text ingredient lines into structured ingredients linked to the catalogue
+	ParseIngredients(ctx context.Context, input ParseIngredientsInput) ([]domain.Ingredient, error)
+}
+
+// ParseIngredientsInput holds the lines to parse, either as a list or as pasted text with one
+// ingredient per line
+type ParseIngredientsInput struct {
+	Lines []string `json:"lines"`
+	Text  string   `json:"text"`
+}
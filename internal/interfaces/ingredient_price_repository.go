@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type IngredientPriceRepository interface {
+	// Create an ingredient price
+	Create(ctx context.Context, price *domain.IngredientPrice) error
+
+	// Get an ingredient price by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.IngredientPrice, error)
+
+	// Get the price of an ingredient name in a currency, kept by the user or shared when userID is nil
+	GetByName(ctx context.Context, userID *uuid.UUID, name string, currency string) (*domain.IngredientPrice, error)
+
+	// Get the shared prices and the user's own prices in a currency
+	GetPrices(ctx context.Context, userID uuid.UUID, currency string) ([]domain.IngredientPrice, error)
+
+	// Get the shared prices in every currency
+	GetSharedPrices(ctx context.Context) ([]domain.IngredientPrice, error)
+
+	// Update an ingredient price
+	Update(ctx context.Context, price *domain.IngredientPrice) error
+
+	// Delete an ingredient price
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Replace the stored recipe costs computed from the shared prices
+	ReplaceRecipeCosts(ctx context.Context, costs []domain.RecipeCostSummary) error
+}
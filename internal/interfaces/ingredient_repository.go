@@ -0,0 +1,29 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type IngredientRepository interface {
+	// Create a catalogue ingredient along with its lookup terms
+	Create(ctx context.Context, ingredient *domain.CanonicalIngredient, terms map[string]string) error
+
+	// Get a catalogue ingredient by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.CanonicalIngredient, error)
+
+	// Update a catalogue ingredient and replace its lookup terms
+	Update(ctx context.Context, ingredient *domain.CanonicalIngredient, terms map[string]string) error
+
+	// Delete a catalogue ingredient and its lookup terms
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Get the IDs of the ingredients owning each of the given lookup terms
+	GetIDsByTerms(ctx context.Context, terms []string) (map[string]uuid.UUID, error)
+
+	// Find the ingredients with a lookup term starting with the query, or with a word of it
+	// starting with the query. An ingredient is returned once for each matching term.
+	SearchTerms(ctx context.Context, query string, limit int) ([]domain.IngredientSuggestion, error)
+}
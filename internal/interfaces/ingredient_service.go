@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type IngredientService interface {
+	// Suggest catalogue ingredients for what the user is typing
+	Autocomplete(ctx context.Context, query string, limit int) ([]domain.IngredientSuggestion, error)
+
+	// Get a catalogue ingredient
+	GetIngredient(ctx context.Context, id uuid.UUID) (*domain.CanonicalIngredient, error)
+
+	// Add an ingredient to the catalogue
+	CreateIngredient(ctx context.Context, input IngredientInput) (*domain.CanonicalIngredient, error)
+
+	// Replace a catalogue ingredient
+	UpdateIngredient(ctx context.Context, id uuid.UUID, input IngredientInput) (*domain.CanonicalIngredient, error)
+
+	// Remove an ingredient from the catalogue
+	DeleteIngredient(ctx context.Context, id uuid.UUID) error
+
+	// Link ingredient lines to catalogue ingredients by name, leaving unknown ones unlinked
+	LinkIngredients(ctx context.Context, ingredients []domain.Ingredient) error
+}
+
+type IngredientInput struct {
+	Name        string            `json:"name" binding:"required"`
+	Plural      string            `json:"plural"`
+	Synonyms    []string          `json:"synonyms"`
+	Category    string            `json:"category"` // defaults to "other"
+	Density     *float64          `json:"density"`
+	NutrientRef string            `json:"nutrient_ref"`
+	Nutrition   *domain.Nutrition `json:"nutrition"`
+}
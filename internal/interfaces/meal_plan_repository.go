@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MealPlanRepository interface {
+	// Create a meal plan entry
+	Create(ctx context.Context, entry *domain.MealPlanEntry) error
+
+	// Create several meal plan entries at once
+	CreateEntries(ctx context.Context, entries []domain.MealPlanEntry) error
+
+	// Get a meal plan entry by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.MealPlanEntry, error)
+
+	// Update a meal plan entry
+	Update(ctx context.Context, entry *domain.MealPlanEntry) error
+
+	// Delete a meal plan entry
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Get a user's entries between two dates, both inclusive
+	GetByUserAndDateRange(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]domain.MealPlanEntry, error)
+
+	// Delete a user's entries between two dates, both inclusive
+	DeleteByUserAndDateRange(ctx context.Context, userID uuid.UUID, from, to time.Time) error
+}
@@ -0,0 +1,50 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type MealPlanService interface {
+	// Plan a recipe for a date and meal slot
+	AddEntry(ctx context.Context, input CreateMealPlanEntryInput) (*domain.MealPlanEntry, error)
+
+	// Move an entry or change its servings
+	UpdateEntry(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateMealPlanEntryInput) (*domain.MealPlanEntry, error)
+
+	// Remove an entry from the plan
+	DeleteEntry(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+
+	// Get the plan of the week containing the given date
+	GetWeek(ctx context.Context, userID uuid.UUID, date string) (*domain.MealPlanWeek, error)
+
+	// Copy the entries of one week into the week containing the given date
+	CopyWeek(ctx context.Context, userID uuid.UUID, date string, input CopyMealPlanWeekInput) (*domain.MealPlanWeek, error)
+
+	// Generate a shopping list for the week containing the given date
+	GenerateShoppingList(ctx context.Context, userID uuid.UUID, date string, name string) (*domain.ShoppingList, error)
+
+	// Total the cooking time and nutrition of the week containing the given date
+	GetWeekSummary(ctx context.Context, userID uuid.UUID, date string) (*domain.MealPlanSummary, error)
+}
+
+type CreateMealPlanEntryInput struct {
+	UserID   uuid.UUID `json:"-"`
+	RecipeID uuid.UUID `json:"recipe_id" binding:"required"`
+	Date     string    `json:"date" binding:"required"`
+	Slot     string    `json:"slot" binding:"required"`
+	Servings int       `json:"servings" binding:"omitempty,min=1"`
+}
+
+type UpdateMealPlanEntryInput struct {
+	Date     string `json:"date"`
+	Slot     string `json:"slot"`
+	Servings *int   `json:"servings" binding:"omitempty,min=0"` // 0 resets to the recipe's serving size
+}
+
+type CopyMealPlanWeekInput struct {
+	From    string `json:"from" binding:"required"` // any date in the week to copy
+	Replace bool   `json:"replace"`                 // remove the target week's entries first
+}
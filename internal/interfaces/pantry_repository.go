@@ -0,0 +1,36 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PantryRepository interface {
+	// Create a pantry item
+	Create(ctx context.Context, item *domain.PantryItem) error
+
+	// Get a pantry item by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.PantryItem, error)
+
+	// Get all pantry items of a user, soonest to expire first
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.PantryItem, error)
+
+	// Get the items of all users expiring before the given time that have not been notified yet
+	GetUnnotifiedExpiringBefore(ctx context.Context, before time.Time) ([]domain.PantryItem, error)
+
+	// Update a pantry item
+	Update(ctx context.Context, item *domain.PantryItem) error
+
+	// Deduct the used amounts from the user's pantry items in one transaction. Used up items are
+	// removed. Returns ErrPantryChanged when an item no longer holds the amount to deduct.
+	Deduct(ctx context.Context, userID uuid.UUID, deductions []domain.PantryDeduction) error
+
+	// Mark pantry items as notified about their expiry
+	MarkExpiryNotified(ctx context.Context, ids []uuid.UUID, at time.Time) error
+
+	// Delete a pantry item
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,57 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PantryService interface {
+	// Add an item to the user's pantry
+	AddItem(ctx context.Context, input CreatePantryItemInput) (*domain.PantryItem, error)
+
+	// Update a pantry item owned by the user
+	UpdateItem(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdatePantryItemInput) (*domain.PantryItem, error)
+
+	// Delete a pantry item owned by the user
+	DeleteItem(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+
+	// Get the user's pantry, soonest to expire first
+	GetItems(ctx context.Context, userID uuid.UUID) ([]domain.PantryItem, error)
+
+	// Suggest recipes that use the user's pantry items, preferring items that expire soon
+	SuggestRecipes(ctx context.Context, userID uuid.UUID, limit int) ([]domain.PantrySuggestion, error)
+
+	// Deduct the ingredients of a recipe cooked for the given servings from the user's pantry
+	CookRecipe(ctx context.Context, userID uuid.UUID, input CookFromPantryInput) (*domain.PantryCookResult, error)
+
+	// Work out what cooking a recipe would deduct from the user's pantry, without changing the pantry
+	PlanCook(ctx context.Context, userID uuid.UUID, input CookFromPantryInput) (*domain.PantryCookResult, error)
+
+	// Email every user whose pantry items expire soon, once per item
+	NotifyExpiringItems(ctx context.Context) error
+}
+
+type CreatePantryItemInput struct {
+	UserID    uuid.UUID  `json:"-"`
+	Name      string     `json:"name" binding:"required"`
+	Amount    float64    `json:"amount"`
+	Unit      string     `json:"unit"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type UpdatePantryItemInput struct {
+	Name      *string    `json:"name"`
+	Amount    *float64   `json:"amount"`
+	Unit      *string    `json:"unit"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	// ClearExpiry removes the expiry date, since a null expires_at cannot be told apart from an omitted one
+	ClearExpiry bool `json:"clear_expiry"`
+}
+
+type CookFromPantryInput struct {
+	RecipeID uuid.UUID `json:"recipe_id" binding:"required"`
+	Servings int       `json:"servings"` // zero cooks the recipe's own serving size
+}
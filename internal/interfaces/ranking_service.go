@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+)
+
+type RankingService interface {
+	// Get the recipes trending in a ranking window
+	GetTrendingRecipes(ctx context.Context, window string, offset, limit int) ([]domain.RankedRecipe, error)
+
+	// Get the top rated recipes in a ranking window
+	GetTopRecipes(ctx context.Context, window string, offset, limit int) ([]domain.RankedRecipe, error)
+
+	// Recompute and store the scores of every ranking window
+	RecomputeScores(ctx context.Context) error
+}
@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecipeAnalyticsRepository interface {
+	// Record a view, ignoring it if the viewer already viewed the recipe in the same window
+	RecordView(ctx context.Context, view domain.RecipeView) error
+
+	// Recompute the daily stats of the days from the start of from up to the start of to
+	RollupDailyStats(ctx context.Context, from, to time.Time) error
+
+	// Delete views recorded before the given time, once they are rolled up
+	DeleteViewsBefore(ctx context.Context, before time.Time) error
+
+	// Get the daily stats of an author's recipes within [from, to), optionally of a single recipe
+	GetAuthorDailyStats(ctx context.Context, authorID uuid.UUID, recipeID uuid.UUID, from, to time.Time) ([]domain.RecipeDailyStats, error)
+}
@@ -21,6 +21,9 @@ type RecipeCollectionRepository interface {
 	// GetCollectionsByRecipeID retrieves all collections that contain a recipe
 	GetCollectionsByRecipeID(ctx context.Context, recipeID uuid.UUID) ([]domain.Collection, error)
 
+	// GetCollectionIDsForAllRecipes maps every saved recipe to the collections that contain it
+	GetCollectionIDsForAllRecipes(ctx context.Context) (map[uuid.UUID][]uuid.UUID, error)
+
 	// IsRecipeInCollection checks if a recipe is in a collection
 	IsRecipeInCollection(ctx context.Context, collectionID, recipeID uuid.UUID) (bool, error)
 }
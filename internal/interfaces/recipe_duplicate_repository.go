@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type RecipeDuplicateRepository interface {
+	// Save the fingerprint of a recipe, replacing its previous one
+	SaveFingerprint(ctx context.Context, fingerprint domain.RecipeFingerprint) error
+
+	// Get the fingerprints of active recipes other than the given one sharing at least one band hash
+	FindCandidates(ctx context.Context, recipeID uuid.UUID, bands []uint64, limit int) ([]domain.RecipeFingerprint, error)
+
+	// Get the IDs of active recipes that have no fingerprint yet
+	GetUnfingerprintedRecipeIDs(ctx context.Context, limit int) ([]uuid.UUID, error)
+
+	// Replace the duplicates found for a recipe
+	ReplaceDuplicates(ctx context.Context, recipeID uuid.UUID, duplicates []domain.RecipeDuplicate) error
+
+	// Get the duplicates between active recipes, most similar first
+	GetDuplicates(ctx context.Context, limit int) ([]domain.RecipeDuplicate, error)
+}
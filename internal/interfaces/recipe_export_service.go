@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Supported recipe export formats
+const (
+	ExportFormatJSONLD   = "jsonld"
+	ExportFormatMarkdown = "markdown"
+	ExportFormatHTML     = "html"
+	ExportFormatCooklang = "cooklang"
+)
+
+// RecipeExportService renders recipes into formats meant for use outside the app
+type RecipeExportService interface {
+	// ExportRecipe renders the recipe with the given ID in the requested format
+	ExportRecipe(ctx context.Context, id uuid.UUID, format string) (*RecipeExport, error)
+}
+
+// RecipeExport is a rendered recipe document
+type RecipeExport struct {
+	ContentType string
+	Filename    string
+	Body        []byte
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+)
+
+// PageFetcher retrieves the raw HTML of a web page
+type PageFetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, error)
+}
+
+// RecipeImportService extracts recipes from external web pages
+type RecipeImportService interface {
+	// PreviewFromURL fetches a page and extracts the schema.org recipe it contains
+	PreviewFromURL(ctx context.Context, input ImportRecipeInput) (*RecipeImportPreview, error)
+
+	// PreviewFromHTML extracts the schema.org recipe from an already fetched page
+	PreviewFromHTML(ctx context.Context, html []byte, sourceURL string) (*RecipeImportPreview, error)
+}
+
+type ImportRecipeInput struct {
+	URL string `json:"url" binding:"required,url"`
+}
+
+// RecipeImportPreview is the extracted recipe returned to the user for confirmation.
+// The recipe is not persisted until it is posted back to the create endpoint.
+type RecipeImportPreview struct {
+	SourceURL string            `json:"source_url"`
+	Recipe    CreateRecipeInput `json:"recipe"`
+	Warnings  []string          `json:"warnings,omitempty"`
+}
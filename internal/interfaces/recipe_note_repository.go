@@ -0,0 +1,25 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type RecipeNoteRepository interface {
+	// Get the user's note on a recipe
+	GetByUserAndRecipe(ctx context.Context, userID, recipeID uuid.UUID) (*domain.RecipeNote, error)
+
+	// Get the user's notes, most recently updated first
+	GetByUserID(ctx context.Context, userID uuid.UUID, offset, limit int) ([]domain.RecipeNote, error)
+
+	// Create a note
+	Create(ctx context.Context, note *domain.RecipeNote) error
+
+	// Update a note
+	Update(ctx context.Context, note *domain.RecipeNote) error
+
+	// Delete a note
+	Delete(ctx context.Context, id uuid.UUID) error
+}
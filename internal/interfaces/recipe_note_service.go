@@ -0,0 +1,27 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type RecipeNoteService interface {
+	// Get the user's note on a recipe, or nil when the user has not written one
+	GetNote(ctx context.Context, recipeID, userID uuid.UUID) (*domain.RecipeNote, error)
+
+	// Get the user's notes on all recipes, most recently updated first
+	GetNotes(ctx context.Context, userID uuid.UUID, offset, limit int) ([]domain.RecipeNote, error)
+
+	// Create or replace the user's note on a recipe
+	SaveNote(ctx context.Context, recipeID, userID uuid.UUID, input SaveRecipeNoteInput) (*domain.RecipeNote, error)
+
+	// Delete the user's note on a recipe
+	DeleteNote(ctx context.Context, recipeID, userID uuid.UUID) error
+}
+
+type SaveRecipeNoteInput struct {
+	Content   string            `json:"content"`
+	StepNotes []domain.StepNote `json:"step_notes"`
+}
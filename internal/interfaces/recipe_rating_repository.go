@@ -29,6 +29,9 @@ type RecipeRatingRepository interface {
 	// Get a rating by user and recipe ID
 	GetRatingByUserAndRecipeID(ctx context.Context, userID, recipeID uuid.UUID) (*domain.RecipeRating, error)
 
+	// Mark the user's rating of a recipe as verified cook or not
+	SetVerifiedCook(ctx context.Context, userID, recipeID uuid.UUID, verified bool) error
+
 	// Calculate and update the rating summary (count and average) for a recipe
 	UpdateRecipeRatingSummary(ctx context.Context, recipeID uuid.UUID) error
 }
@@ -9,8 +9,12 @@ import (
 
 type RecipeRepository interface {
 	CreateRecipe(ctx context.Context, recipe *domain.Recipe) error
+	CreateRecipes(ctx context.Context, recipes []*domain.Recipe) error
 	GetRecipe(ctx context.Context, id uuid.UUID) (*domain.Recipe, error)
 	UpdateRecipe(ctx context.Context, recipe *domain.Recipe) error
-	DeleteRecipe(ctx context.Context, id uuid.UUID) error
+	DeleteRecipe(ctx context.Context, id uuid.UUID, version int64) error
 	FilterRecipesByCondition(ctx context.Context, conditions map[string]interface{}, cursor uuid.UUID, limit int) ([]domain.Recipe, uuid.UUID, error)
+	FindRecipesByIngredientNames(ctx context.Context, names []string, limit int) ([]domain.Recipe, error)
+	ListRecipes(ctx context.Context, offset, limit int) ([]domain.Recipe, error)
+	ListRecipesByUser(ctx context.Context, userID uuid.UUID, offset, limit int) ([]domain.Recipe, error)
 }
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+)
+
+type RecipeScoreRepository interface {
+	// Get the engagement with active recipes since the given time, decayed with the given half-life
+	// up to now. A zero since covers all time.
+	GetRecipeEngagement(ctx context.Context, since, now time.Time, halfLife time.Duration) ([]domain.RecipeEngagement, error)
+
+	// Replace the scores of a ranking window
+	ReplaceScores(ctx context.Context, window string, scores []domain.RecipeScore) error
+
+	// Get the scores of a ranking window with the highest trending score first
+	GetTrending(ctx context.Context, window string, offset, limit int) ([]domain.RecipeScore, error)
+
+	// Get the scores of recipes rated within a ranking window with the highest top score first
+	GetTop(ctx context.Context, window string, offset, limit int) ([]domain.RecipeScore, error)
+}
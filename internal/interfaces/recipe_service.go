@@ -11,8 +11,28 @@ import (
 type RecipeService interface {
 	CreateRecipe(ctx context.Context, input CreateRecipeInput) (*domain.Recipe, error)
 	GetRecipe(ctx context.Context, id uuid.UUID) (*domain.Recipe, error)
-	UpdateRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID, input UpdateRecipeInput) (*domain.Recipe, error)
-	DeleteRecipe(ctx context.Context, id uuid.UUID) error
+	// ValidateRecipe checks a recipe the way CreateRecipe, UpdateRecipe and PatchRecipe do, its category included
+	ValidateRecipe(ctx context.Context, recipe *domain.Recipe) error
+	// ValidateRecipeContent is ValidateRecipe without the category check, for callers resolving categories themselves
+	ValidateRecipeContent(ctx context.Context, recipe *domain.Recipe) error
+	// ExpandSections fills in the sub-recipes referenced by the recipe's sections, scaled by their multipliers
+	ExpandSections(ctx context.Context, recipe *domain.Recipe) error
+	// UpdateRecipe and DeleteRecipe return ErrVersionConflict unless the recipe is still at the given version
+	UpdateRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, input UpdateRecipeInput) (*domain.Recipe, error)
+	DeleteRecipe(ctx context.Context, id uuid.UUID, version int64) error
+	// PatchRecipe applies a JSON Merge Patch of UpdateRecipeInput fields to a recipe owned by the user,
+	// so fields can be cleared with null; it returns ErrVersionConflict unless the recipe is at the given version
+	PatchRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, patch []byte) (*domain.Recipe, error)
+	// ForkRecipe copies a recipe into the user's own recipes, keeping a link to the original
+	ForkRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Recipe, error)
+	// SetTranslation adds or replaces the translation of a recipe into a locale; it returns ErrVersionConflict
+	// unless the recipe is at the given version
+	SetTranslation(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, locale string, input RecipeTranslationInput) (*domain.Recipe, error)
+	// DeleteTranslation removes the translation of a recipe into a locale; it returns ErrVersionConflict
+	// unless the recipe is at the given version
+	DeleteTranslation(ctx context.Context, id uuid.UUID, userID uuid.UUID, version int64, locale string) (*domain.Recipe, error)
+	// LocalizeRecipe shows the recipe in the available locale best matching the preferred locales
+	LocalizeRecipe(recipe *domain.Recipe, preferred []string)
 	FilterRecipesByCondition(ctx context.Context, conditions map[string]interface{}, cursor uuid.UUID, limit int) ([]domain.Recipe, uuid.UUID, error)
 }
 
@@ -26,6 +46,10 @@ type CreateRecipeInput struct {
 	Images      []common.Image            `json:"images"`
 	Ingredients []domain.Ingredient `json:"ingredients" binding:"required"`
 	Steps       []domain.Step       `json:"steps" binding:"required"`
+	Sections    []domain.RecipeSection `json:"sections"`
+	Nutrition   *domain.Nutrition      `json:"nutrition"`
+	Tags        []string               `json:"tags"`
+	Locale      string                 `json:"locale"` // language the recipe is written in, "en" by default
 }
 
 type UpdateRecipeInput struct {
@@ -38,6 +62,19 @@ type UpdateRecipeInput struct {
 	Images      []common.Image            `json:"images"`
 	Ingredients []domain.Ingredient `json:"ingredients"`
 	Steps       []domain.Step       `json:"steps"`
+	Sections    []domain.RecipeSection `json:"sections"`
+	Nutrition   *domain.Nutrition      `json:"nutrition"`
+	Tags        []string               `json:"tags"`
+	Locale      string                 `json:"locale"`
+}
+
+// RecipeTranslationInput is the text of a recipe in another locale. Ingredients and Steps are the
+// ingredient names and step contents in reading order: the recipe's own, then those of its sections.
+type RecipeTranslationInput struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Ingredients []string `json:"ingredients"`
+	Steps       []string `json:"steps"`
 }
 
 type FilterRecipesInput struct {
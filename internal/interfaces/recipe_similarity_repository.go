@@ -0,0 +1,20 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type RecipeSimilarityRepository interface {
+	// Get the precomputed neighbours of a recipe, most similar first
+	GetByRecipeID(ctx context.Context, recipeID uuid.UUID, limit int) ([]domain.RecipeSimilarity, error)
+
+	// Replace the precomputed neighbours of a recipe
+	ReplaceForRecipe(ctx context.Context, recipeID uuid.UUID, similarities []domain.RecipeSimilarity) error
+
+	// Delete neighbours computed before the given time, e.g. of recipes deleted since
+	DeleteComputedBefore(ctx context.Context, before time.Time) error
+}
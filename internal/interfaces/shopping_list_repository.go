@@ -0,0 +1,40 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ShoppingListRepository interface {
+	// Create a shopping list together with its items
+	Create(ctx context.Context, list *domain.ShoppingList) error
+
+	// Get a shopping list and its items by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.ShoppingList, error)
+
+	// Get a shopping list and its items by share token
+	GetByShareToken(ctx context.Context, token string) (*domain.ShoppingList, error)
+
+	// Get all shopping lists of a user, without items
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]domain.ShoppingList, error)
+
+	// Update the name and share token of a shopping list
+	Update(ctx context.Context, list *domain.ShoppingList) error
+
+	// Delete a shopping list
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	// Add an item to a shopping list
+	AddItem(ctx context.Context, item *domain.ShoppingListItem) error
+
+	// Get a shopping list item by ID
+	GetItem(ctx context.Context, id uuid.UUID) (*domain.ShoppingListItem, error)
+
+	// Update a shopping list item
+	UpdateItem(ctx context.Context, item *domain.ShoppingListItem) error
+
+	// Delete a shopping list item
+	DeleteItem(ctx context.Context, id uuid.UUID) error
+}
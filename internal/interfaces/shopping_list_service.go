@@ -0,0 +1,67 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type ShoppingListService interface {
+	// Generate a shopping list from recipes and/or a collection, merging matching ingredients
+	GenerateShoppingList(ctx context.Context, input GenerateShoppingListInput) (*domain.ShoppingList, error)
+
+	// Get a shopping list owned by the user
+	GetShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ShoppingList, error)
+
+	// Get a shared shopping list by its share token
+	GetSharedShoppingList(ctx context.Context, token string) (*domain.ShoppingList, error)
+
+	// Get all shopping lists of a user
+	GetUserShoppingLists(ctx context.Context, userID uuid.UUID) ([]domain.ShoppingList, error)
+
+	// Delete a shopping list
+	DeleteShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+
+	// Add a manual item to a shopping list
+	AddItem(ctx context.Context, listID uuid.UUID, userID uuid.UUID, input AddShoppingListItemInput) (*domain.ShoppingListItem, error)
+
+	// Update an item, e.g. to check it off
+	UpdateItem(ctx context.Context, listID uuid.UUID, itemID uuid.UUID, userID uuid.UUID, input UpdateShoppingListItemInput) (*domain.ShoppingListItem, error)
+
+	// Remove an item from a shopping list
+	DeleteItem(ctx context.Context, listID uuid.UUID, itemID uuid.UUID, userID uuid.UUID) error
+
+	// Share a shopping list, returning the list with its share token
+	ShareShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.ShoppingList, error)
+
+	// Stop sharing a shopping list
+	UnshareShoppingList(ctx context.Context, id uuid.UUID, userID uuid.UUID) error
+}
+
+type ShoppingListRecipeInput struct {
+	RecipeID uuid.UUID `json:"recipe_id" binding:"required"`
+	Servings int       `json:"servings" binding:"omitempty,min=1"` // scales the recipe; defaults to its serving size
+}
+
+type GenerateShoppingListInput struct {
+	UserID       uuid.UUID                 `json:"-"`
+	Name         string                    `json:"name"`
+	Recipes      []ShoppingListRecipeInput `json:"recipes" binding:"dive"`
+	CollectionID *uuid.UUID                `json:"collection_id"`
+}
+
+type AddShoppingListItemInput struct {
+	Name   string  `json:"name" binding:"required"`
+	Amount float64 `json:"amount" binding:"omitempty,min=0"`
+	Unit   string  `json:"unit"`
+	Aisle  string  `json:"aisle"`
+}
+
+type UpdateShoppingListItemInput struct {
+	Name    *string  `json:"name"`
+	Amount  *float64 `json:"amount" binding:"omitempty,min=0"`
+	Unit    *string  `json:"unit"`
+	Aisle   *string  `json:"aisle"`
+	Checked *bool    `json:"checked"`
+}
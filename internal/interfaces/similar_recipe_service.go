@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type SimilarRecipeService interface {
+	// Get the recipes most similar to a recipe
+	GetSimilarRecipes(ctx context.Context, recipeID uuid.UUID, limit int) ([]domain.SimilarRecipe, error)
+
+	// Recompute and store the nearest neighbours of every recipe
+	RecomputeSimilarities(ctx context.Context) error
+}
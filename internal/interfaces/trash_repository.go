@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TrashRepository interface {
+	// Get the user's deleted recipes, most recently deleted first
+	GetTrashedRecipes(ctx context.Context, userID uuid.UUID) ([]domain.Recipe, error)
+
+	// Get the user's deleted collections, most recently deleted first
+	GetTrashedCollections(ctx context.Context, userID uuid.UUID) ([]domain.Collection, error)
+
+	// Get the deleted categories, most recently deleted first
+	GetTrashedCategories(ctx context.Context) ([]domain.Category, error)
+
+	// Get a deleted recipe by ID
+	GetTrashedRecipe(ctx context.Context, id uuid.UUID) (*domain.Recipe, error)
+
+	// Get a deleted collection by ID
+	GetTrashedCollection(ctx context.Context, id uuid.UUID) (*domain.Collection, error)
+
+	// Get a deleted category by ID
+	GetTrashedCategory(ctx context.Context, id uuid.UUID) (*domain.Category, error)
+
+	// Restore a deleted recipe; gorm.ErrRecordNotFound if it is not in the trash
+	RestoreRecipe(ctx context.Context, id uuid.UUID) error
+
+	// Restore a deleted collection; gorm.ErrRecordNotFound if it is not in the trash
+	RestoreCollection(ctx context.Context, id uuid.UUID) error
+
+	// Restore a deleted category; gorm.ErrRecordNotFound if it is not in the trash
+	RestoreCategory(ctx context.Context, id uuid.UUID) error
+
+	// Permanently delete the items deleted before the cutoff, along with the collection entries and
+	// ratings of the purged recipes and the entries of the purged collections
+	PurgeBefore(ctx context.Context, cutoff time.Time) (*domain.TrashPurgeResult, error)
+}
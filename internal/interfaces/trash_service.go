@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+	"cookaholic/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type TrashService interface {
+	// Get the user's deleted recipes and collections
+	GetTrash(ctx context.Context, userID uuid.UUID) (*domain.Trash, error)
+
+	// Restore one of the user's deleted recipes
+	RestoreRecipe(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Recipe, error)
+
+	// Restore one of the user's deleted collections
+	RestoreCollection(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain.Collection, error)
+
+	// Get the deleted categories
+	GetTrashedCategories(ctx context.Context) ([]domain.Category, error)
+
+	// Restore a deleted category
+	RestoreCategory(ctx context.Context, id uuid.UUID) (*domain.Category, error)
+
+	// Permanently delete the items whose retention period has passed
+	PurgeExpired(ctx context.Context) (*domain.TrashPurgeResult, error)
+}
@@ -13,7 +13,7 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
 	FindByUsername(ctx context.Context, username string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
-	Delete(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID, version int64) error
 	List(ctx context.Context, offset, limit int) ([]domain.User, error)
 	VerifyOTP(ctx context.Context, id uuid.UUID, otp string) error
 }
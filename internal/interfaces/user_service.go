@@ -12,8 +12,12 @@ import (
 type UserService interface {
 	Create(ctx context.Context, input CreateUserInput) (*domain.User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error)
-	Update(ctx context.Context, id uuid.UUID, input UpdateUserInput) (*domain.User, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	// Update and Delete return ErrVersionConflict unless the user is still at the given version
+	Update(ctx context.Context, id uuid.UUID, version int64, input UpdateUserInput) (*domain.User, error)
+	Delete(ctx context.Context, id uuid.UUID, version int64) error
+	// Patch applies a JSON Merge Patch of UpdateUserInput fields to a user, so the full name, avatar
+	// and bio can be cleared with null; it returns ErrVersionConflict unless the user is at the given version
+	Patch(ctx context.Context, id uuid.UUID, version int64, patch []byte) (*domain.User, error)
 	List(ctx context.Context, page, pageSize int) ([]domain.User, error)
 	ValidateCredentials(ctx context.Context, email, password string) (*domain.User, error)
 	VerifyOTP(ctx context.Context, id uuid.UUID, otp string) error